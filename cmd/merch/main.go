@@ -2,34 +2,59 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"crypto/tls"
+	"flag"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
-	"sync"
 	"syscall"
-	"time"
 
-	"github.com/caarlos0/env/v6"
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
 
+	"github.com/Te8va/MerchStore/internal/app"
 	"github.com/Te8va/MerchStore/internal/config"
 	"github.com/Te8va/MerchStore/internal/handler"
+	"github.com/Te8va/MerchStore/internal/metrics"
 	"github.com/Te8va/MerchStore/internal/middleware"
 	"github.com/Te8va/MerchStore/internal/repository"
 	"github.com/Te8va/MerchStore/internal/service"
+	"github.com/Te8va/MerchStore/internal/tracing"
+	"github.com/Te8va/MerchStore/pkg/certreload"
 	"github.com/Te8va/MerchStore/pkg/logger"
+	"github.com/Te8va/MerchStore/pkg/ratelimit"
 )
 
 func main() {
-	cfg := config.Config{}
-	if err := env.Parse(&cfg); err != nil {
-		logger.Logger().Fatalln("Failed to parse env: %v", err)
+	configPath := flag.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML configuration file")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		logger.Logger().Fatalln("Invalid configuration:", err)
+	}
+
+	if err := logger.Init(loggerOptions(cfg)); err != nil {
+		logger.Logger().Fatalln("Failed to configure logger:", zap.Error(err))
+	}
+
+	logger.Logger().Infow("Configuration loaded", "config", cfg.Redacted())
+
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.Options{
+		Exporter:     cfg.TracingExporter,
+		FilePath:     cfg.TracingFilePath,
+		OTLPEndpoint: cfg.TracingOTLPEndpoint,
+		ServiceName:  cfg.TracingServiceName,
+		SampleRatio:  cfg.TracingSampleRatio,
+	})
+	if err != nil {
+		logger.Logger().Fatalln(zap.Error(err))
 	}
 
-	m, err := migrate.New("file://migrations", cfg.PostgresConn)
+	m, err := migrate.New(cfg.MigrationsURL(), cfg.DSN())
 	if err != nil {
 		logger.Logger().Fatalln(zap.Error(err))
 	}
@@ -41,73 +66,274 @@ func main() {
 
 	logger.Logger().Infoln("Migrations applied successfully")
 
-	pool, err := repository.GetPgxPool(cfg.PostgresConn)
+	pool, err := repository.GetPgxPool(cfg.DSN())
 	if err != nil {
 		logger.Logger().Fatalln(zap.Error(err))
 	}
 
 	logger.Logger().Infoln("Postgres connection pool created")
 
-	var wg sync.WaitGroup
+	prometheus.MustRegister(metrics.NewPgxPoolCollector(pool))
 
 	merchRep := repository.NewMerchService(pool)
-	merchService := service.NewMerch(merchRep)
+	merchService := service.NewMerch(merchRep, cfg.TransferPolicy())
 	merchHandler := handler.NewMerchHandler(merchService, cfg.JWTKey)
 
 	authRepository := repository.NewAuthorizationService(pool)
-	authService := service.NewAuthorization(authRepository, cfg.JWTKey)
+	authService := service.NewAuthorization(authRepository, cfg.JWTKey, cfg.WelcomeBonus)
 	authHandler := handler.NewAuthorizationHandler(authService)
 
-	_, cancelDeleteCtx := context.WithCancel(context.Background())
+	purchaseService := service.NewPurchase(repository.NewPurchaseService(pool), cfg.RefundWindow)
+	purchaseHandler := handler.NewPurchaseHandler(purchaseService, cfg.JWTKey)
+
+	marketService := service.NewMarket(repository.NewMarketService(pool), cfg.TransferPolicy())
+	marketHandler := handler.NewMarketHandler(marketService, cfg.JWTKey)
+
+	redemptionService := service.NewRedemption(repository.NewRedemptionService(pool))
+	redemptionHandler := handler.NewRedemptionHandler(redemptionService, cfg.JWTKey)
+
+	promotionService := service.NewPromotion(repository.NewPromotionService(pool))
+	promotionHandler := handler.NewPromotionHandler(promotionService)
+
+	catalogService := service.NewCatalog(repository.NewCatalogService(pool))
+	catalogHandler := handler.NewCatalogHandler(catalogService)
+
+	itemRuleService := service.NewItemRule(repository.NewItemRuleService(pool))
+	itemRuleHandler := handler.NewItemRuleHandler(itemRuleService)
+
+	wishlistService := service.NewWishlist(repository.NewWishlistService(pool))
+	wishlistHandler := handler.NewWishlistHandler(wishlistService, cfg.JWTKey)
+
+	notificationService := service.NewNotification(repository.NewNotificationService(pool))
+	notificationHandler := handler.NewNotificationHandler(notificationService, cfg.JWTKey)
+
+	eventService := service.NewEvents(repository.NewEventService(pool))
+	eventsHandler := handler.NewEventsHandler(eventService, cfg.JWTKey, cfg.EventsHeartbeatInterval)
+
+	webhookService := service.NewWebhooks(repository.NewWebhookService(pool), &http.Client{Timeout: cfg.WebhookTimeout}, cfg.WebhookMaxAttempts)
+	webhookHandler := handler.NewWebhookHandler(webhookService)
+
+	leaderboardService := service.NewLeaderboard(repository.NewLeaderboardService(pool))
+	leaderboardHandler := handler.NewLeaderboardHandler(leaderboardService, cfg.JWTKey)
+
+	coinAdminRepository := repository.NewCoinAdminService(pool)
+	coinAdminService := service.NewCoinAdmin(coinAdminRepository)
+	coinAdminHandler := handler.NewCoinAdminHandler(coinAdminService)
+
+	issuancePolicies, err := cfg.Issuance()
+	if err != nil {
+		logger.Logger().Fatalln(zap.Error(err))
+	}
+	issuanceService := service.NewIssuance(repository.NewIssuanceService(pool), issuancePolicies)
+
+	migrationVersion, err := repository.LatestMigrationVersion(cfg.MigrationsPath)
+	if err != nil {
+		logger.Logger().Fatalln(zap.Error(err))
+	}
+
+	healthRepository := repository.NewHealthService(pool)
+	healthService := service.NewHealth(healthRepository, migrationVersion)
+	healthHandler := handler.NewHealthHandler(healthService)
 
 	mux := http.NewServeMux()
 
-	mux.Handle("GET /api/info", middleware.Log(http.HandlerFunc(merchHandler.GetUserInfoHandler)))
-	mux.Handle("POST /api/sendCoin", middleware.Log(http.HandlerFunc(merchHandler.SendCoinHandler)))
-	mux.Handle("GET /api/buy/{item}", middleware.Log(http.HandlerFunc(merchHandler.BuyMerchHandler)))
-	mux.Handle("POST /api/auth", middleware.Log(http.HandlerFunc(authHandler.AuthHandler)))
+	bodyLimit := middleware.BodyLimit(cfg.BodyLimitDefault)
+	handle := func(pattern string, h http.Handler) {
+		mux.Handle(pattern, middleware.Trace(middleware.Log(middleware.Metrics(bodyLimit(h)))))
+	}
+	admin := middleware.Admin(cfg.AdminToken)
+
+	limiterStore := ratelimit.NewMemoryStore()
+	limiterKey := middleware.UserOrIPKey(cfg.JWTKey, cfg.ProxyHops())
+	limit := func(policy ratelimit.Policy) func(http.Handler) http.Handler {
+		if !cfg.RateLimitEnabled {
+			return func(h http.Handler) http.Handler { return h }
+		}
+		return middleware.RateLimit(limiterStore, policy, limiterKey)
+	}
+	readLimit := limit(ratelimit.Policy{Name: "read", Rate: cfg.RateLimitDefaultRPS, Burst: cfg.RateLimitDefaultBurst})
+	mutationLimit := limit(ratelimit.Policy{Name: "mutation", Rate: cfg.RateLimitMutationRPS, Burst: cfg.RateLimitMutationBurst})
+	authLimit := limit(ratelimit.Policy{Name: "auth", Rate: cfg.RateLimitAuthRPS, Burst: cfg.RateLimitAuthBurst})
+
+	handle("GET /api/catalog", readLimit(http.HandlerFunc(catalogHandler.ListCatalogHandler)))
+	handle("GET /api/catalog/{item}", readLimit(http.HandlerFunc(catalogHandler.GetCatalogItemHandler)))
+	handle("GET /api/info", readLimit(http.HandlerFunc(merchHandler.GetUserInfoHandler)))
+	handle("GET /api/history", readLimit(http.HandlerFunc(merchHandler.GetUserHistoryHandler)))
+	handle("POST /api/sendCoin", mutationLimit(http.HandlerFunc(merchHandler.SendCoinHandler)))
+	handle("POST /api/gift", mutationLimit(http.HandlerFunc(merchHandler.GiftHandler)))
+	handle("GET /api/buy/{item}", mutationLimit(http.HandlerFunc(merchHandler.BuyMerchHandler)))
+	handle("GET /api/purchases", readLimit(http.HandlerFunc(purchaseHandler.ListPurchasesHandler)))
+	handle("POST /api/purchases/{id}/refund", mutationLimit(http.HandlerFunc(purchaseHandler.RefundHandler)))
+	handle("POST /api/inventory/transfer", mutationLimit(http.HandlerFunc(marketHandler.TransferItemHandler)))
+	handle("GET /api/market/listings", readLimit(http.HandlerFunc(marketHandler.ListListingsHandler)))
+	handle("POST /api/market/listings", mutationLimit(http.HandlerFunc(marketHandler.CreateListingHandler)))
+	handle("DELETE /api/market/listings/{id}", mutationLimit(http.HandlerFunc(marketHandler.CancelListingHandler)))
+	handle("POST /api/market/listings/{id}/buy", mutationLimit(http.HandlerFunc(marketHandler.BuyListingHandler)))
+	handle("GET /api/redemptions", readLimit(http.HandlerFunc(redemptionHandler.ListRedemptionsHandler)))
+	handle("GET /api/redemptions/{id}", readLimit(http.HandlerFunc(redemptionHandler.GetRedemptionHandler)))
+	handle("POST /api/redemptions", mutationLimit(http.HandlerFunc(redemptionHandler.RedeemHandler)))
+	handle("GET /api/wishlist", readLimit(http.HandlerFunc(wishlistHandler.GetWishlistHandler)))
+	handle("POST /api/wishlist", mutationLimit(http.HandlerFunc(wishlistHandler.AddToWishlistHandler)))
+	handle("DELETE /api/wishlist/{item}", mutationLimit(http.HandlerFunc(wishlistHandler.RemoveFromWishlistHandler)))
+	handle("GET /api/notifications", readLimit(http.HandlerFunc(notificationHandler.ListNotificationsHandler)))
+	handle("POST /api/notifications/read", mutationLimit(http.HandlerFunc(notificationHandler.MarkReadHandler)))
+	handle("GET /api/events", readLimit(http.HandlerFunc(eventsHandler.StreamHandler)))
+	handle("GET /api/leaderboard", readLimit(http.HandlerFunc(leaderboardHandler.GetLeaderboardHandler)))
+	handle("PUT /api/leaderboard/opt-out", mutationLimit(http.HandlerFunc(leaderboardHandler.SetOptOutHandler)))
+	handle("POST /api/auth", authLimit(middleware.BodyLimit(cfg.BodyLimitAuth)(http.HandlerFunc(authHandler.AuthHandler))))
+	handle("GET /api/admin/log/level", admin(logger.LevelHandler()))
+	handle("PUT /api/admin/log/level", admin(logger.LevelHandler()))
+	handle("POST /api/admin/coins/grant", admin(http.HandlerFunc(coinAdminHandler.GrantHandler)))
+	handle("POST /api/admin/coins/deduct", admin(http.HandlerFunc(coinAdminHandler.DeductHandler)))
+	handle("POST /api/admin/coins/airdrop", admin(http.HandlerFunc(coinAdminHandler.AirdropHandler)))
+	handle("POST /api/admin/purchases/{id}/refund", admin(http.HandlerFunc(purchaseHandler.AdminRefundHandler)))
+	handle("GET /api/admin/redemptions", admin(http.HandlerFunc(redemptionHandler.AdminListRedemptionsHandler)))
+	handle("PUT /api/admin/redemptions/{id}/status", admin(http.HandlerFunc(redemptionHandler.AdminUpdateStatusHandler)))
+	handle("GET /api/admin/promotions", admin(http.HandlerFunc(promotionHandler.ListPromotionsHandler)))
+	handle("POST /api/admin/promotions", admin(http.HandlerFunc(promotionHandler.CreatePromotionHandler)))
+	handle("DELETE /api/admin/promotions/{id}", admin(http.HandlerFunc(promotionHandler.EndPromotionHandler)))
+	handle("PUT /api/admin/catalog/{item}", admin(http.HandlerFunc(catalogHandler.UpdateCatalogItemHandler)))
+	handle("PUT /api/admin/catalog/{item}/price", admin(http.HandlerFunc(catalogHandler.UpdatePriceHandler)))
+	handle("PUT /api/admin/catalog/{item}/variants/{variant}", admin(http.HandlerFunc(catalogHandler.UpsertVariantHandler)))
+	handle("GET /api/admin/item-rules", admin(http.HandlerFunc(itemRuleHandler.ListItemRulesHandler)))
+	handle("PUT /api/admin/item-rules/{item}", admin(http.HandlerFunc(itemRuleHandler.SaveItemRuleHandler)))
+	handle("DELETE /api/admin/item-rules/{item}", admin(http.HandlerFunc(itemRuleHandler.DeleteItemRuleHandler)))
+	handle("PUT /api/admin/users/{username}/role", admin(http.HandlerFunc(itemRuleHandler.SetUserRoleHandler)))
+	handle("GET /api/admin/webhooks", admin(http.HandlerFunc(webhookHandler.ListWebhooksHandler)))
+	handle("POST /api/admin/webhooks", admin(http.HandlerFunc(webhookHandler.CreateWebhookHandler)))
+	handle("PUT /api/admin/webhooks/{id}", admin(http.HandlerFunc(webhookHandler.UpdateWebhookHandler)))
+	handle("DELETE /api/admin/webhooks/{id}", admin(http.HandlerFunc(webhookHandler.DeleteWebhookHandler)))
+	handle("GET /api/admin/webhooks/deliveries", admin(http.HandlerFunc(webhookHandler.ListDeliveriesHandler)))
+	handle("POST /api/admin/outbox/{id}/replay", admin(http.HandlerFunc(webhookHandler.ReplayEventHandler)))
+	handle("GET /api/admin/health", admin(http.HandlerFunc(healthHandler.HealthReportHandler)))
+	mux.HandleFunc("GET /healthz", healthHandler.LivenessHandler)
+	mux.HandleFunc("GET /readyz", healthHandler.ReadinessHandler)
+	mux.Handle("GET /metrics", promhttp.Handler())
 
 	server := &http.Server{
-		Addr:     fmt.Sprintf("%s:%d", cfg.ServiceHost, cfg.ServicePort),
-		ErrorLog: log.New(logger.Logger(), "", 0),
-		Handler:  mux,
+		Addr:              cfg.Addr(),
+		ErrorLog:          log.New(logger.Logger(), "", 0),
+		Handler:           mux,
+		ReadTimeout:       cfg.HTTPReadTimeout,
+		ReadHeaderTimeout: cfg.HTTPReadHeaderTimeout,
+		WriteTimeout:      cfg.HTTPWriteTimeout,
+		IdleTimeout:       cfg.HTTPIdleTimeout,
+		MaxHeaderBytes:    cfg.HTTPMaxHeaderBytes,
 	}
 
-	go func() {
-		logger.Logger().Infoln("Server started, listening on port", cfg.ServicePort)
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			logger.Logger().Fatalln("ListenAndServe failed", zap.Error(err))
-		}
-	}()
+	application := app.New(server, app.Options{
+		ShutdownTimeout:   cfg.ShutdownTimeout,
+		WorkerStopTimeout: cfg.WorkerStopTimeout,
+	})
+
+	application.AddCloser("logger", func(context.Context) error {
+		logger.Close()
+		return nil
+	})
+	application.AddCloser("tracing", func(ctx context.Context) error {
+		return shutdownTracing(ctx)
+	})
+	application.AddCloser("postgres pool", func(context.Context) error {
+		pool.Close()
+		return nil
+	})
+	application.OnShutdown(healthService.SetDraining)
+	application.OnShutdown(eventService.Close)
+	application.AddWorker(app.NewWorker("sighup", func(ctx context.Context) error {
+		reloadLoggerOnHUP(ctx, *configPath)
+		return nil
+	}))
 
-	quit := make(chan os.Signal, 1)
+	if len(issuancePolicies) > 0 {
+		application.AddWorker(app.NewWorker("issuance", func(ctx context.Context) error {
+			return issuanceService.Run(ctx, cfg.IssuanceInterval)
+		}))
+	}
 
-	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	application.AddWorker(app.NewWorker("wishlist notifier", func(ctx context.Context) error {
+		return wishlistService.Run(ctx, cfg.WishlistNotifyInterval)
+	}))
 
-	<-quit
+	application.AddWorker(app.NewWorker("event listener", eventService.Run))
+	application.AddWorker(app.NewWorker("leaderboard refresher", func(ctx context.Context) error {
+		return leaderboardService.Run(ctx, cfg.LeaderboardRefreshInterval)
+	}))
+	application.AddWorker(app.NewWorker("webhook dispatcher", func(ctx context.Context) error {
+		return webhookService.Run(ctx, cfg.WebhookDispatchInterval)
+	}))
+	application.AddWorker(app.NewWorker("event retention", func(ctx context.Context) error {
+		return eventService.RunRetention(ctx, cfg.EventsRetention)
+	}))
 
-	logger.Logger().Infoln("Shutting down server...")
+	if cfg.TLSEnabled() {
+		reloader, err := certreload.New(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			logger.Logger().Fatalln(zap.Error(err))
+		}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+		server.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: reloader.GetCertificate,
+		}
 
-	if err := server.Shutdown(ctx); err != nil {
-		logger.Logger().Fatalln("Server was forced to shutdown:", zap.Error(err))
+		application.AddWorker(app.NewWorker("tls reloader", func(ctx context.Context) error {
+			reloader.Watch(ctx, cfg.TLSReloadInterval, func(err error) {
+				logger.Logger().Errorln("Failed to reload TLS certificate:", zap.Error(err))
+			})
+			return nil
+		}))
 	}
 
-	waitGroupChan := make(chan struct{})
-	go func() {
-		wg.Wait()
-		waitGroupChan <- struct{}{}
-	}()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := application.Run(ctx); err != nil {
+		logger.Logger().Errorln("Application stopped with error:", zap.Error(err))
+		os.Exit(1)
+	}
+}
 
-	select {
-	case <-waitGroupChan:
-		logger.Logger().Infoln("All delete goroutines successfully finished")
-	case <-time.After(time.Second * 3):
-		cancelDeleteCtx()
-		logger.Logger().Infoln("Some of delete goroutines have not completed their job due to shutdown timeout")
+func loggerOptions(cfg config.Config) logger.Options {
+	return logger.Options{
+		Level:          cfg.LogLevel,
+		Format:         cfg.LogFormat,
+		Outputs:        cfg.LogOutputs,
+		FilePath:       cfg.LogFilePath,
+		MaxSizeMB:      cfg.LogMaxSizeMB,
+		MaxBackups:     cfg.LogMaxBackups,
+		MaxAgeDays:     cfg.LogMaxAgeDays,
+		Compress:       cfg.LogCompress,
+		RotateInterval: cfg.LogRotateInterval,
 	}
+}
+
+func reloadLoggerOnHUP(ctx context.Context, configPath string) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
 
-	logger.Logger().Infoln("Server was shut down")
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+		}
+
+		cfg, err := config.Load(configPath)
+		if err != nil {
+			logger.Logger().Errorln("SIGHUP: failed to reload configuration:", zap.Error(err))
+			continue
+		}
+
+		if err := logger.SetLevel(cfg.LogLevel); err != nil {
+			logger.Logger().Errorln("SIGHUP: failed to set log level:", zap.Error(err))
+			continue
+		}
+
+		if err := logger.Rotate(); err != nil {
+			logger.Logger().Errorln("SIGHUP: failed to reopen log files:", zap.Error(err))
+		}
+
+		logger.Logger().Infoln("SIGHUP: log level set to", logger.Level())
+	}
 }
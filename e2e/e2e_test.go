@@ -65,11 +65,11 @@ func NewServer() *http.Server {
 		log.Println("Postgres connection pool created")
 
 		merchRepo = repository.NewMerchService(pool)
-		merchService := service.NewMerch(merchRepo)
+		merchService := service.NewMerch(merchRepo, cfg.TransferPolicy())
 		merchHandler := handler.NewMerchHandler(merchService, cfg.JWTKey)
 
 		authRepo = repository.NewAuthorizationService(pool)
-		authService := service.NewAuthorization(authRepo, cfg.JWTKey)
+		authService := service.NewAuthorization(authRepo, cfg.JWTKey, cfg.WelcomeBonus)
 		authHandler := handler.NewAuthorizationHandler(authService)
 
 		mux := http.NewServeMux()
@@ -193,7 +193,7 @@ func TestMerchPurchaseInvalidItemNoMoney(t *testing.T) {
 	token, ok := authResponse["token"].(string)
 	require.True(t, ok, "Expected token to be present in response")
 
-	// Успешная покупка розового худи
+	// Успешная покупка розового худи без указания размера
 	item := "pink-hoody"
 	req, err = http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/buy/%s", serverAddr, item), nil)
 	require.NoError(t, err)
@@ -204,6 +204,11 @@ func TestMerchPurchaseInvalidItemNoMoney(t *testing.T) {
 	defer res.Body.Close()
 	require.Equal(t, http.StatusOK, res.StatusCode)
 
+	var purchase domain.Purchase
+	err = json.NewDecoder(res.Body).Decode(&purchase)
+	require.NoError(t, err)
+	require.NotEmpty(t, purchase.Variant, "Expected a variant to be chosen for a legacy purchase")
+
 	// Проверка баланса после покупки
 	req, err = http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/info", serverAddr), nil)
 	require.NoError(t, err)
@@ -781,4 +786,11 @@ func truncateTest(ctx context.Context, pool *pgxpool.Pool) {
 	if err != nil {
 		log.Fatalf("Failed to truncate test tables: %v", err)
 	}
+
+	// Регистрация начисляет приветственный бонус от системного аккаунта,
+	// поэтому он должен пережить очистку таблиц.
+	_, err = pool.Exec(ctx, "INSERT INTO users (username, password, token, balance) VALUES ($1, '!', '', 0)", domain.SystemAccount)
+	if err != nil {
+		log.Fatalf("Failed to seed system account: %v", err)
+	}
 }
@@ -0,0 +1,185 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/pkg/logger"
+)
+
+type Worker interface {
+	Name() string
+	Run(ctx context.Context) error
+}
+
+type workerFunc struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+func NewWorker(name string, run func(ctx context.Context) error) Worker {
+	return &workerFunc{name: name, run: run}
+}
+
+func (w *workerFunc) Name() string {
+	return w.name
+}
+
+func (w *workerFunc) Run(ctx context.Context) error {
+	return w.run(ctx)
+}
+
+type Options struct {
+	ShutdownTimeout   time.Duration
+	WorkerStopTimeout time.Duration
+	Listener          net.Listener
+}
+
+type closer struct {
+	name  string
+	close func(ctx context.Context) error
+}
+
+type runningWorker struct {
+	worker Worker
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+type App struct {
+	server     *http.Server
+	opts       Options
+	workers    []Worker
+	closers    []closer
+	onShutdown []func()
+}
+
+func New(server *http.Server, opts Options) *App {
+	return &App{server: server, opts: opts}
+}
+
+func (a *App) AddWorker(w Worker) {
+	a.workers = append(a.workers, w)
+}
+
+// AddCloser registers a resource that is released after the server and all
+// workers have stopped. Closers run in reverse order of registration.
+func (a *App) AddCloser(name string, close func(ctx context.Context) error) {
+	a.closers = append(a.closers, closer{name: name, close: close})
+}
+
+func (a *App) OnShutdown(fn func()) {
+	a.onShutdown = append(a.onShutdown, fn)
+}
+
+func (a *App) Run(ctx context.Context) error {
+	rootCtx, cancelRoot := context.WithCancel(context.Background())
+	defer cancelRoot()
+
+	fatal := make(chan error, len(a.workers)+1)
+
+	running := make([]runningWorker, 0, len(a.workers))
+	for _, w := range a.workers {
+		running = append(running, a.startWorker(rootCtx, w, fatal))
+	}
+
+	ln := a.opts.Listener
+	if ln == nil {
+		var err error
+		ln, err = net.Listen("tcp", a.server.Addr)
+		if err != nil {
+			fatal <- fmt.Errorf("app.Run: %w", err)
+		}
+	}
+
+	if ln != nil {
+		go func() {
+			logger.Logger().Infoln("Server started, listening on", ln.Addr().String())
+
+			var err error
+			if a.server.TLSConfig != nil {
+				err = a.server.ServeTLS(ln, "", "")
+			} else {
+				err = a.server.Serve(ln)
+			}
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				fatal <- fmt.Errorf("app.Run: server: %w", err)
+			}
+		}()
+	}
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		logger.Logger().Infoln("Shutdown signal received")
+	case runErr = <-fatal:
+		logger.Logger().Errorln("Shutting down after failure:", zap.Error(runErr))
+	}
+
+	return errors.Join(runErr, a.shutdown(running))
+}
+
+func (a *App) startWorker(rootCtx context.Context, w Worker, fatal chan<- error) runningWorker {
+	ctx, cancel := context.WithCancel(rootCtx)
+	rw := runningWorker{worker: w, cancel: cancel, done: make(chan struct{})}
+
+	go func() {
+		defer close(rw.done)
+
+		logger.Logger().Infoln("Worker started:", w.Name())
+		if err := w.Run(ctx); err != nil && ctx.Err() == nil {
+			fatal <- fmt.Errorf("app.Run: worker %s: %w", w.Name(), err)
+		}
+	}()
+
+	return rw
+}
+
+func (a *App) shutdown(running []runningWorker) error {
+	var errs []error
+
+	for _, fn := range a.onShutdown {
+		fn()
+	}
+
+	logger.Logger().Infoln("Draining in-flight requests...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.opts.ShutdownTimeout)
+	defer cancel()
+
+	if err := a.server.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("app.shutdown: server: %w", err))
+		_ = a.server.Close()
+	}
+
+	for _, rw := range slices.Backward(running) {
+		rw.cancel()
+
+		select {
+		case <-rw.done:
+			logger.Logger().Infoln("Worker stopped:", rw.worker.Name())
+		case <-time.After(a.opts.WorkerStopTimeout):
+			errs = append(errs, fmt.Errorf("app.shutdown: worker %s did not stop within %s", rw.worker.Name(), a.opts.WorkerStopTimeout))
+		}
+	}
+
+	closeCtx, cancelClose := context.WithTimeout(context.Background(), a.opts.ShutdownTimeout)
+	defer cancelClose()
+
+	for _, c := range slices.Backward(a.closers) {
+		if err := c.close(closeCtx); err != nil {
+			errs = append(errs, fmt.Errorf("app.shutdown: %s: %w", c.name, err))
+		}
+	}
+
+	logger.Logger().Infoln("Server was shut down")
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,105 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunGracefulShutdown(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	started := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	a := New(&http.Server{Handler: mux}, Options{
+		ShutdownTimeout:   time.Second,
+		WorkerStopTimeout: time.Second,
+		Listener:          ln,
+	})
+
+	var (
+		mu     sync.Mutex
+		events []string
+	)
+	record := func(event string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}
+
+	for _, name := range []string{"first", "second"} {
+		a.AddWorker(NewWorker(name, func(ctx context.Context) error {
+			<-ctx.Done()
+			record("stop " + name)
+			return nil
+		}))
+	}
+	a.AddCloser("pool", func(context.Context) error {
+		record("close pool")
+		return nil
+	})
+	a.AddCloser("logger", func(context.Context) error {
+		record("close logger")
+		return nil
+	})
+	a.OnShutdown(func() { record("draining") })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() { runErr <- a.Run(ctx) }()
+
+	respErr := make(chan error, 1)
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String() + "/slow")
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				err = errors.New(resp.Status)
+			}
+		}
+		respErr <- err
+	}()
+
+	<-started
+	cancel()
+
+	require.NoError(t, <-respErr)
+	require.NoError(t, <-runErr)
+	require.Equal(t, []string{"draining", "stop second", "stop first", "close logger", "close pool"}, events)
+}
+
+func TestRunWorkerFailure(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	a := New(&http.Server{Handler: http.NewServeMux()}, Options{
+		ShutdownTimeout:   time.Second,
+		WorkerStopTimeout: 50 * time.Millisecond,
+		Listener:          ln,
+	})
+
+	a.AddWorker(NewWorker("broken", func(ctx context.Context) error {
+		return errors.New("boom")
+	}))
+	a.AddWorker(NewWorker("stuck", func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}))
+
+	err = a.Run(context.Background())
+	require.ErrorContains(t, err, "worker broken: boom")
+	require.ErrorContains(t, err, "worker stuck did not stop")
+}
@@ -4,6 +4,7 @@ type User struct {
 	Username string
 	Password string
 	Token    string
+	Balance  int
 }
 
 type AuthorizationData struct {
@@ -0,0 +1,36 @@
+package domain
+
+import "context"
+
+const (
+	VariantKindSize   = "size"
+	VariantKindColour = "colour"
+
+	MaxDescriptionLength = 2000
+)
+
+// Variant is a size or colour of an item with its own stock. Items without
+// variants are not stock-limited.
+type Variant struct {
+	Name  string `json:"name"`
+	Kind  string `json:"kind"`
+	Stock int    `json:"stock"`
+}
+
+//go:generate mockgen -destination=mocks/catalog_repo_mock.gen.go -package=mocks . CatalogRepository
+type CatalogRepository interface {
+	ListCatalog(ctx context.Context, category string) ([]MerchItem, error)
+	GetCatalogItem(ctx context.Context, name string) (MerchItem, error)
+	UpdateCatalogItem(ctx context.Context, item MerchItem) (MerchItem, error)
+	UpsertVariant(ctx context.Context, item string, variant Variant) (Variant, error)
+	UpdatePrice(ctx context.Context, item string, price int) (MerchItem, error)
+}
+
+//go:generate mockgen -destination=mocks/catalog_service_mock.gen.go -package=mocks . CatalogService
+type CatalogService interface {
+	ListCatalog(ctx context.Context, category string) ([]MerchItem, error)
+	GetCatalogItem(ctx context.Context, name string) (MerchItem, error)
+	UpdateCatalogItem(ctx context.Context, item MerchItem) (MerchItem, error)
+	UpsertVariant(ctx context.Context, item string, variant Variant) (Variant, error)
+	UpdatePrice(ctx context.Context, item string, price int) (MerchItem, error)
+}
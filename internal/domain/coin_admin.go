@@ -0,0 +1,42 @@
+package domain
+
+import "context"
+
+const SystemAccount = "system"
+
+const (
+	TransactionKindTransfer  = "transfer"
+	TransactionKindGrant     = "grant"
+	TransactionKindDeduction = "deduction"
+	TransactionKindAirdrop   = "airdrop"
+	TransactionKindWelcome   = "welcome_bonus"
+	TransactionKindAllowance = "allowance"
+	TransactionKindRefund    = "refund"
+	TransactionKindGift      = "gift"
+	TransactionKindItem      = "item_transfer"
+	TransactionKindSale      = "market_sale"
+)
+
+type CoinAdjustment struct {
+	Username string `json:"username"`
+	Amount   int    `json:"amount"`
+	Reason   string `json:"reason"`
+}
+
+type AirdropSummary struct {
+	Recipients int `json:"recipients"`
+	Total      int `json:"total"`
+}
+
+//go:generate mockgen -destination=mocks/coin_admin_repo_mock.gen.go -package=mocks . CoinAdminRepository
+type CoinAdminRepository interface {
+	GrantCoins(ctx context.Context, kind string, adjustments []CoinAdjustment) error
+	DeductCoins(ctx context.Context, adjustment CoinAdjustment) error
+}
+
+//go:generate mockgen -destination=mocks/coin_admin_service_mock.gen.go -package=mocks . CoinAdminService
+type CoinAdminService interface {
+	Grant(ctx context.Context, adjustment CoinAdjustment) error
+	Deduct(ctx context.Context, adjustment CoinAdjustment) error
+	Airdrop(ctx context.Context, adjustments []CoinAdjustment) (AirdropSummary, error)
+}
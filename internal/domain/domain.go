@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 )
 
 //go:generate mockgen -destination=mocks/repo_mock.gen.go -package=mocks . MerchRepository
@@ -9,17 +10,26 @@ type MerchRepository interface {
 	GetMerchPrice(ctx context.Context, item string) (int, error)
 	GetUserBalance(ctx context.Context, username string) (int, error)
 	UpdateUserBalance(ctx context.Context, username string, newBalance int) error
-	SavePurchase(ctx context.Context, username, item string, price int) error
+	GetPromotions(ctx context.Context, item, code string, at time.Time) ([]Promotion, error)
+	SavePurchase(ctx context.Context, purchase Purchase, now time.Time) (Purchase, error)
+	SaveGift(ctx context.Context, gift Gift, price int, now time.Time) error
 	UserExists(ctx context.Context, username string) (bool, error)
-	TransferCoins(ctx context.Context, fromUser, toUser string, amount int) error
+	TransferCoins(ctx context.Context, transfer Transfer, policy TransferPolicy, since time.Time) error
 	GetUserInventory(ctx context.Context, username string) ([]string, error)
 	GetUserTransactionHistory(ctx context.Context, username string) (CoinHistory, error)
 	GetUserPurchases(ctx context.Context, username string) ([]InventoryItem, error)
+	GetUserHistory(ctx context.Context, username string, filter HistoryFilter) ([]HistoryEntry, error)
+	GetUserCreatedAt(ctx context.Context, username string) (time.Time, error)
+	GetTransferTotals(ctx context.Context, fromUser, toUser string, since time.Time) (TransferTotals, error)
+	GetItemRule(ctx context.Context, item string) (ItemRule, error)
+	GetPurchaseStats(ctx context.Context, username, item string, since time.Time) (PurchaseStats, error)
 }
 
 //go:generate mockgen -destination=mocks/merch_service_mock.gen.go -package=mocks . MerchService
 type MerchService interface {
-	BuyMerch(ctx context.Context, username, item string) error
-	SendCoin(ctx context.Context, fromUser, toUser string, amount int) error
+	BuyMerch(ctx context.Context, req PurchaseRequest) (Purchase, error)
+	GiftMerch(ctx context.Context, gift Gift) error
+	SendCoin(ctx context.Context, transfer Transfer) error
 	GetUserInfo(ctx context.Context, username string) (UserInfo, error)
+	GetUserHistory(ctx context.Context, username string, filter HistoryFilter) ([]HistoryEntry, error)
 }
@@ -1,13 +1,18 @@
 package domain
 
 type MerchItem struct {
-	ID    int    `json:"id"`
-	Name  string `json:"name"`
-	Price int    `json:"price"`
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Price       int       `json:"price"`
+	Category    string    `json:"category"`
+	Description string    `json:"description,omitempty"`
+	ImageURL    string    `json:"imageUrl,omitempty"`
+	Variants    []Variant `json:"variants,omitempty"`
 }
 
 type InventoryItem struct {
 	Name     string `json:"name"`
+	Variant  string `json:"variant,omitempty"`
 	Quantity int    `json:"quantity"`
 }
 
@@ -20,11 +25,15 @@ type UserInfo struct {
 type ReceivedTransaction struct {
 	FromUser string `json:"fromUser"`
 	Amount   int    `json:"amount"`
+	Message  string `json:"message,omitempty"`
+	Category string `json:"category,omitempty"`
 }
 
 type SentTransaction struct {
-	ToUser string `json:"toUser"`
-	Amount int    `json:"amount"`
+	ToUser   string `json:"toUser"`
+	Amount   int    `json:"amount"`
+	Message  string `json:"message,omitempty"`
+	Category string `json:"category,omitempty"`
 }
 
 type CoinHistory struct {
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const (
+	EventBalance      = "balance"
+	EventTransfer     = "transfer"
+	EventGift         = "gift"
+	EventPurchase     = "purchase"
+	EventItemTransfer = "item_transfer"
+
+	// EventsChannel is the Postgres NOTIFY channel that carries the name of
+	// the user an event was stored for.
+	EventsChannel = "user_events"
+
+	EventBatchSize = 100
+)
+
+// UserEvent is a change pushed to a user's event stream. IDs are numbered
+// per user and become visible in order, so a client can resume after the
+// last ID it has seen without missing events that commit late.
+type UserEvent struct {
+	ID        int64           `json:"id"`
+	Kind      string          `json:"kind"`
+	Data      json.RawMessage `json:"data"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+//go:generate mockgen -destination=mocks/event_repo_mock.gen.go -package=mocks . EventRepository
+type EventRepository interface {
+	ListEvents(ctx context.Context, username string, afterID int64, limit int) ([]UserEvent, error)
+	LatestEventID(ctx context.Context, username string) (int64, error)
+	DeleteEventsBefore(ctx context.Context, before time.Time) (int, error)
+	Listen(ctx context.Context, onNotify func(username string)) error
+}
+
+//go:generate mockgen -destination=mocks/event_service_mock.gen.go -package=mocks . EventService
+type EventService interface {
+	Subscribe(username string) (<-chan struct{}, func())
+	Done() <-chan struct{}
+	ListEvents(ctx context.Context, username string, afterID int64) ([]UserEvent, error)
+	LatestEventID(ctx context.Context, username string) (int64, error)
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "context"
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+type DependencyStatus struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latencyMs"`
+	Details   string  `json:"details,omitempty"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type HealthReport struct {
+	Status       string             `json:"status"`
+	Draining     bool               `json:"draining"`
+	Dependencies []DependencyStatus `json:"dependencies"`
+}
+
+//go:generate mockgen -destination=mocks/health_repo_mock.gen.go -package=mocks . HealthRepository
+type HealthRepository interface {
+	Ping(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (uint, bool, error)
+}
+
+//go:generate mockgen -destination=mocks/health_service_mock.gen.go -package=mocks . HealthService
+type HealthService interface {
+	Ready(ctx context.Context) error
+	Report(ctx context.Context) HealthReport
+	SetDraining()
+}
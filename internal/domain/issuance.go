@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	PeriodDaily   = "daily"
+	PeriodWeekly  = "weekly"
+	PeriodMonthly = "monthly"
+)
+
+type IssuancePolicy struct {
+	Name   string
+	Period string
+	Amount int
+}
+
+// ParseIssuancePolicy parses a policy in the "name:period:amount" form,
+// for example "monthly-allowance:monthly:100".
+func ParseIssuancePolicy(s string) (IssuancePolicy, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) != 3 {
+		return IssuancePolicy{}, fmt.Errorf("issuance policy %q: expected name:period:amount", s)
+	}
+
+	amount, err := strconv.Atoi(parts[2])
+	if err != nil || amount <= 0 {
+		return IssuancePolicy{}, fmt.Errorf("issuance policy %q: amount must be a positive integer", s)
+	}
+
+	policy := IssuancePolicy{Name: parts[0], Period: parts[1], Amount: amount}
+	if policy.Name == "" {
+		return IssuancePolicy{}, fmt.Errorf("issuance policy %q: name is empty", s)
+	}
+
+	switch policy.Period {
+	case PeriodDaily, PeriodWeekly, PeriodMonthly:
+	default:
+		return IssuancePolicy{}, fmt.Errorf("issuance policy %q: unknown period %q", s, policy.Period)
+	}
+
+	return policy, nil
+}
+
+// PeriodKey identifies the period containing t. A policy pays out at most
+// once per key.
+func (p IssuancePolicy) PeriodKey(t time.Time) string {
+	t = t.UTC()
+
+	switch p.Period {
+	case PeriodDaily:
+		return t.Format(time.DateOnly)
+	case PeriodWeekly:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	default:
+		return t.Format("2006-01")
+	}
+}
+
+//go:generate mockgen -destination=mocks/issuance_repo_mock.gen.go -package=mocks . IssuanceRepository
+type IssuanceRepository interface {
+	RunIssuance(ctx context.Context, policy IssuancePolicy, periodKey string) (recipients int, issued bool, err error)
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+)
+
+const (
+	RoleEmployee = "employee"
+
+	RuleMaxPerUser            = "item_max_per_user"
+	RuleMaxPerPeriod          = "item_max_per_period"
+	RuleMinAccountAge         = "item_min_account_age"
+	RuleRoleRequired          = "item_role_required"
+	RuleReceivedCoinsRequired = "item_received_coins_required"
+)
+
+// ItemRule restricts who may buy an item and how much of it. Zero values
+// disable the corresponding check. MaxPerPeriod counts units bought since
+// the start of the current Period (daily, weekly or monthly, in UTC).
+type ItemRule struct {
+	Item                  string    `json:"item"`
+	MaxPerUser            int       `json:"maxPerUser"`
+	MaxPerPeriod          int       `json:"maxPerPeriod"`
+	Period                string    `json:"period"`
+	MinAccountAgeDays     int       `json:"minAccountAgeDays"`
+	RequiredRole          string    `json:"requiredRole,omitempty"`
+	RequiresReceivedCoins bool      `json:"requiresReceivedCoins"`
+	UpdatedAt             time.Time `json:"updatedAt"`
+}
+
+// Active reports whether the rule enables any check.
+func (r ItemRule) Active() bool {
+	return r.MaxPerUser > 0 || r.MaxPerPeriod > 0 || r.MinAccountAgeDays > 0 ||
+		r.RequiredRole != "" || r.RequiresReceivedCoins
+}
+
+// Check applies the rule to a user with stats who is about to get quantity
+// more units. Limits count units already owned net of refunds. It returns
+// a *errors.RuleError naming the violated rule, or nil.
+func (r ItemRule) Check(stats PurchaseStats, quantity int, now time.Time) error {
+	switch {
+	case r.RequiredRole != "" && stats.Role != r.RequiredRole:
+		return &appErrors.RuleError{Err: appErrors.ErrNotEligible, Code: RuleRoleRequired}
+	case r.MinAccountAgeDays > 0 && now.Sub(stats.CreatedAt) < time.Duration(r.MinAccountAgeDays)*24*time.Hour:
+		return &appErrors.RuleError{Err: appErrors.ErrNotEligible, Code: RuleMinAccountAge}
+	case r.RequiresReceivedCoins && !stats.ReceivedCoins:
+		return &appErrors.RuleError{Err: appErrors.ErrNotEligible, Code: RuleReceivedCoinsRequired}
+	case r.MaxPerUser > 0 && stats.Bought+quantity > r.MaxPerUser:
+		return &appErrors.RuleError{Code: RuleMaxPerUser,
+			Err: &appErrors.LimitError{Err: appErrors.ErrItemLimitReached, Remaining: max(r.MaxPerUser-stats.Bought, 0)}}
+	case r.MaxPerPeriod > 0 && stats.BoughtSince+quantity > r.MaxPerPeriod:
+		return &appErrors.RuleError{Code: RuleMaxPerPeriod,
+			Err: &appErrors.LimitError{Err: appErrors.ErrItemPeriodLimitReached, Remaining: max(r.MaxPerPeriod-stats.BoughtSince, 0)}}
+	}
+	return nil
+}
+
+// PurchaseStats is what the item rules are checked against. Bought and
+// BoughtSince count purchased units net of refunds plus units received as
+// gifts.
+type PurchaseStats struct {
+	Bought        int
+	BoughtSince   int
+	Role          string
+	CreatedAt     time.Time
+	ReceivedCoins bool
+}
+
+// PeriodStart returns the beginning of the daily, weekly (ISO, starting on
+// Monday) or monthly period containing t, in UTC.
+func PeriodStart(period string, t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch period {
+	case PeriodDaily:
+		return day
+	case PeriodWeekly:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	default:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+//go:generate mockgen -destination=mocks/item_rule_repo_mock.gen.go -package=mocks . ItemRuleRepository
+type ItemRuleRepository interface {
+	ListItemRules(ctx context.Context) ([]ItemRule, error)
+	SaveItemRule(ctx context.Context, rule ItemRule) (ItemRule, error)
+	DeleteItemRule(ctx context.Context, item string) error
+	SetUserRole(ctx context.Context, username, role string) error
+}
+
+//go:generate mockgen -destination=mocks/item_rule_service_mock.gen.go -package=mocks . ItemRuleService
+type ItemRuleService interface {
+	ListItemRules(ctx context.Context) ([]ItemRule, error)
+	SaveItemRule(ctx context.Context, rule ItemRule) (ItemRule, error)
+	DeleteItemRule(ctx context.Context, item string) error
+	SetUserRole(ctx context.Context, username, role string) error
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	BoardReceived = "received"
+	BoardGiven    = "given"
+	BoardSpent    = "spent"
+
+	LeaderboardWeek    = "week"
+	LeaderboardMonth   = "month"
+	LeaderboardAllTime = "all"
+
+	LeaderboardSize = 50
+)
+
+var (
+	LeaderboardBoards  = []string{BoardReceived, BoardGiven, BoardSpent}
+	LeaderboardPeriods = []string{LeaderboardWeek, LeaderboardMonth, LeaderboardAllTime}
+)
+
+type LeaderboardEntry struct {
+	Rank     int    `json:"rank"`
+	Username string `json:"username"`
+	Amount   int    `json:"amount"`
+}
+
+// Leaderboard is a cached ranking. Week and month are calendar periods in
+// UTC, weeks start on Monday.
+type Leaderboard struct {
+	Board     string             `json:"board"`
+	Period    string             `json:"period"`
+	Since     *time.Time         `json:"since,omitempty"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+	Entries   []LeaderboardEntry `json:"entries"`
+}
+
+//go:generate mockgen -destination=mocks/leaderboard_repo_mock.gen.go -package=mocks . LeaderboardRepository
+type LeaderboardRepository interface {
+	GetLeaderboard(ctx context.Context, board string, since time.Time, limit int) ([]LeaderboardEntry, error)
+	SetLeaderboardOptOut(ctx context.Context, username string, optOut bool) error
+}
+
+//go:generate mockgen -destination=mocks/leaderboard_service_mock.gen.go -package=mocks . LeaderboardService
+type LeaderboardService interface {
+	GetLeaderboard(ctx context.Context, board, period string, limit int) (Leaderboard, error)
+	SetOptOut(ctx context.Context, username string, optOut bool) error
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	ListingStatusOpen      = "open"
+	ListingStatusSold      = "sold"
+	ListingStatusCancelled = "cancelled"
+)
+
+type ItemTransfer struct {
+	FromUser string `json:"fromUser"`
+	ToUser   string `json:"toUser"`
+	Item     string `json:"item"`
+	Variant  string `json:"variant,omitempty"`
+	Quantity int    `json:"quantity"`
+	Message  string `json:"message,omitempty"`
+}
+
+// Listing offers Quantity units of Item for Price coins in total. The
+// listed units are held in escrow until the listing is sold or cancelled.
+type Listing struct {
+	ID        int        `json:"id"`
+	Seller    string     `json:"seller"`
+	Item      string     `json:"item"`
+	Variant   string     `json:"variant,omitempty"`
+	Quantity  int        `json:"quantity"`
+	Price     int        `json:"price"`
+	Status    string     `json:"status"`
+	Buyer     string     `json:"buyer,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ClosedAt  *time.Time `json:"closedAt,omitempty"`
+}
+
+type ListingFilter struct {
+	Item   string
+	Seller string
+	Limit  int
+	Offset int
+}
+
+//go:generate mockgen -destination=mocks/market_repo_mock.gen.go -package=mocks . MarketRepository
+type MarketRepository interface {
+	TransferItem(ctx context.Context, transfer ItemTransfer, now time.Time) error
+	CreateListing(ctx context.Context, listing Listing) (Listing, error)
+	CancelListing(ctx context.Context, id int, seller string) (Listing, error)
+	BuyListing(ctx context.Context, id int, buyer string, policy TransferPolicy, now time.Time) (Listing, error)
+	ListListings(ctx context.Context, filter ListingFilter) ([]Listing, error)
+}
+
+//go:generate mockgen -destination=mocks/market_service_mock.gen.go -package=mocks . MarketService
+type MarketService interface {
+	TransferItem(ctx context.Context, transfer ItemTransfer) error
+	CreateListing(ctx context.Context, listing Listing) (Listing, error)
+	CancelListing(ctx context.Context, id int, seller string) (Listing, error)
+	BuyListing(ctx context.Context, id int, buyer string) (Listing, error)
+	ListListings(ctx context.Context, filter ListingFilter) ([]Listing, error)
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Te8va/MerchStore/internal/domain (interfaces: CatalogRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	domain "github.com/Te8va/MerchStore/internal/domain"
+)
+
+// MockCatalogRepository is a mock of CatalogRepository interface.
+type MockCatalogRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogRepositoryMockRecorder
+}
+
+// MockCatalogRepositoryMockRecorder is the mock recorder for MockCatalogRepository.
+type MockCatalogRepositoryMockRecorder struct {
+	mock *MockCatalogRepository
+}
+
+// NewMockCatalogRepository creates a new mock instance.
+func NewMockCatalogRepository(ctrl *gomock.Controller) *MockCatalogRepository {
+	mock := &MockCatalogRepository{ctrl: ctrl}
+	mock.recorder = &MockCatalogRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogRepository) EXPECT() *MockCatalogRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetCatalogItem mocks base method.
+func (m *MockCatalogRepository) GetCatalogItem(arg0 context.Context, arg1 string) (domain.MerchItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCatalogItem", arg0, arg1)
+	ret0, _ := ret[0].(domain.MerchItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCatalogItem indicates an expected call of GetCatalogItem.
+func (mr *MockCatalogRepositoryMockRecorder) GetCatalogItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogItem", reflect.TypeOf((*MockCatalogRepository)(nil).GetCatalogItem), arg0, arg1)
+}
+
+// ListCatalog mocks base method.
+func (m *MockCatalogRepository) ListCatalog(arg0 context.Context, arg1 string) ([]domain.MerchItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCatalog", arg0, arg1)
+	ret0, _ := ret[0].([]domain.MerchItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCatalog indicates an expected call of ListCatalog.
+func (mr *MockCatalogRepositoryMockRecorder) ListCatalog(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCatalog", reflect.TypeOf((*MockCatalogRepository)(nil).ListCatalog), arg0, arg1)
+}
+
+// UpdateCatalogItem mocks base method.
+func (m *MockCatalogRepository) UpdateCatalogItem(arg0 context.Context, arg1 domain.MerchItem) (domain.MerchItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCatalogItem", arg0, arg1)
+	ret0, _ := ret[0].(domain.MerchItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCatalogItem indicates an expected call of UpdateCatalogItem.
+func (mr *MockCatalogRepositoryMockRecorder) UpdateCatalogItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCatalogItem", reflect.TypeOf((*MockCatalogRepository)(nil).UpdateCatalogItem), arg0, arg1)
+}
+
+// UpdatePrice mocks base method.
+func (m *MockCatalogRepository) UpdatePrice(arg0 context.Context, arg1 string, arg2 int) (domain.MerchItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePrice", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.MerchItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePrice indicates an expected call of UpdatePrice.
+func (mr *MockCatalogRepositoryMockRecorder) UpdatePrice(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePrice", reflect.TypeOf((*MockCatalogRepository)(nil).UpdatePrice), arg0, arg1, arg2)
+}
+
+// UpsertVariant mocks base method.
+func (m *MockCatalogRepository) UpsertVariant(arg0 context.Context, arg1 string, arg2 domain.Variant) (domain.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertVariant", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertVariant indicates an expected call of UpsertVariant.
+func (mr *MockCatalogRepositoryMockRecorder) UpsertVariant(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertVariant", reflect.TypeOf((*MockCatalogRepository)(nil).UpsertVariant), arg0, arg1, arg2)
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Te8va/MerchStore/internal/domain (interfaces: CatalogService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	domain "github.com/Te8va/MerchStore/internal/domain"
+)
+
+// MockCatalogService is a mock of CatalogService interface.
+type MockCatalogService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogServiceMockRecorder
+}
+
+// MockCatalogServiceMockRecorder is the mock recorder for MockCatalogService.
+type MockCatalogServiceMockRecorder struct {
+	mock *MockCatalogService
+}
+
+// NewMockCatalogService creates a new mock instance.
+func NewMockCatalogService(ctrl *gomock.Controller) *MockCatalogService {
+	mock := &MockCatalogService{ctrl: ctrl}
+	mock.recorder = &MockCatalogServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogService) EXPECT() *MockCatalogServiceMockRecorder {
+	return m.recorder
+}
+
+// GetCatalogItem mocks base method.
+func (m *MockCatalogService) GetCatalogItem(arg0 context.Context, arg1 string) (domain.MerchItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCatalogItem", arg0, arg1)
+	ret0, _ := ret[0].(domain.MerchItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCatalogItem indicates an expected call of GetCatalogItem.
+func (mr *MockCatalogServiceMockRecorder) GetCatalogItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogItem", reflect.TypeOf((*MockCatalogService)(nil).GetCatalogItem), arg0, arg1)
+}
+
+// ListCatalog mocks base method.
+func (m *MockCatalogService) ListCatalog(arg0 context.Context, arg1 string) ([]domain.MerchItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCatalog", arg0, arg1)
+	ret0, _ := ret[0].([]domain.MerchItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCatalog indicates an expected call of ListCatalog.
+func (mr *MockCatalogServiceMockRecorder) ListCatalog(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCatalog", reflect.TypeOf((*MockCatalogService)(nil).ListCatalog), arg0, arg1)
+}
+
+// UpdateCatalogItem mocks base method.
+func (m *MockCatalogService) UpdateCatalogItem(arg0 context.Context, arg1 domain.MerchItem) (domain.MerchItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCatalogItem", arg0, arg1)
+	ret0, _ := ret[0].(domain.MerchItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCatalogItem indicates an expected call of UpdateCatalogItem.
+func (mr *MockCatalogServiceMockRecorder) UpdateCatalogItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCatalogItem", reflect.TypeOf((*MockCatalogService)(nil).UpdateCatalogItem), arg0, arg1)
+}
+
+// UpdatePrice mocks base method.
+func (m *MockCatalogService) UpdatePrice(arg0 context.Context, arg1 string, arg2 int) (domain.MerchItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePrice", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.MerchItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePrice indicates an expected call of UpdatePrice.
+func (mr *MockCatalogServiceMockRecorder) UpdatePrice(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePrice", reflect.TypeOf((*MockCatalogService)(nil).UpdatePrice), arg0, arg1, arg2)
+}
+
+// UpsertVariant mocks base method.
+func (m *MockCatalogService) UpsertVariant(arg0 context.Context, arg1 string, arg2 domain.Variant) (domain.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertVariant", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertVariant indicates an expected call of UpsertVariant.
+func (mr *MockCatalogServiceMockRecorder) UpsertVariant(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertVariant", reflect.TypeOf((*MockCatalogService)(nil).UpsertVariant), arg0, arg1, arg2)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Te8va/MerchStore/internal/domain (interfaces: CoinAdminRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	domain "github.com/Te8va/MerchStore/internal/domain"
+)
+
+// MockCoinAdminRepository is a mock of CoinAdminRepository interface.
+type MockCoinAdminRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCoinAdminRepositoryMockRecorder
+}
+
+// MockCoinAdminRepositoryMockRecorder is the mock recorder for MockCoinAdminRepository.
+type MockCoinAdminRepositoryMockRecorder struct {
+	mock *MockCoinAdminRepository
+}
+
+// NewMockCoinAdminRepository creates a new mock instance.
+func NewMockCoinAdminRepository(ctrl *gomock.Controller) *MockCoinAdminRepository {
+	mock := &MockCoinAdminRepository{ctrl: ctrl}
+	mock.recorder = &MockCoinAdminRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCoinAdminRepository) EXPECT() *MockCoinAdminRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeductCoins mocks base method.
+func (m *MockCoinAdminRepository) DeductCoins(arg0 context.Context, arg1 domain.CoinAdjustment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeductCoins", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeductCoins indicates an expected call of DeductCoins.
+func (mr *MockCoinAdminRepositoryMockRecorder) DeductCoins(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeductCoins", reflect.TypeOf((*MockCoinAdminRepository)(nil).DeductCoins), arg0, arg1)
+}
+
+// GrantCoins mocks base method.
+func (m *MockCoinAdminRepository) GrantCoins(arg0 context.Context, arg1 string, arg2 []domain.CoinAdjustment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantCoins", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantCoins indicates an expected call of GrantCoins.
+func (mr *MockCoinAdminRepositoryMockRecorder) GrantCoins(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantCoins", reflect.TypeOf((*MockCoinAdminRepository)(nil).GrantCoins), arg0, arg1, arg2)
+}
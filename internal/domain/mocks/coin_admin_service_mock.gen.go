@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Te8va/MerchStore/internal/domain (interfaces: CoinAdminService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	domain "github.com/Te8va/MerchStore/internal/domain"
+)
+
+// MockCoinAdminService is a mock of CoinAdminService interface.
+type MockCoinAdminService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCoinAdminServiceMockRecorder
+}
+
+// MockCoinAdminServiceMockRecorder is the mock recorder for MockCoinAdminService.
+type MockCoinAdminServiceMockRecorder struct {
+	mock *MockCoinAdminService
+}
+
+// NewMockCoinAdminService creates a new mock instance.
+func NewMockCoinAdminService(ctrl *gomock.Controller) *MockCoinAdminService {
+	mock := &MockCoinAdminService{ctrl: ctrl}
+	mock.recorder = &MockCoinAdminServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCoinAdminService) EXPECT() *MockCoinAdminServiceMockRecorder {
+	return m.recorder
+}
+
+// Airdrop mocks base method.
+func (m *MockCoinAdminService) Airdrop(arg0 context.Context, arg1 []domain.CoinAdjustment) (domain.AirdropSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Airdrop", arg0, arg1)
+	ret0, _ := ret[0].(domain.AirdropSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Airdrop indicates an expected call of Airdrop.
+func (mr *MockCoinAdminServiceMockRecorder) Airdrop(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Airdrop", reflect.TypeOf((*MockCoinAdminService)(nil).Airdrop), arg0, arg1)
+}
+
+// Deduct mocks base method.
+func (m *MockCoinAdminService) Deduct(arg0 context.Context, arg1 domain.CoinAdjustment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deduct", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Deduct indicates an expected call of Deduct.
+func (mr *MockCoinAdminServiceMockRecorder) Deduct(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deduct", reflect.TypeOf((*MockCoinAdminService)(nil).Deduct), arg0, arg1)
+}
+
+// Grant mocks base method.
+func (m *MockCoinAdminService) Grant(arg0 context.Context, arg1 domain.CoinAdjustment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Grant", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Grant indicates an expected call of Grant.
+func (mr *MockCoinAdminServiceMockRecorder) Grant(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Grant", reflect.TypeOf((*MockCoinAdminService)(nil).Grant), arg0, arg1)
+}
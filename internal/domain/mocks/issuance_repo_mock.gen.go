@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Te8va/MerchStore/internal/domain (interfaces: IssuanceRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	domain "github.com/Te8va/MerchStore/internal/domain"
+)
+
+// MockIssuanceRepository is a mock of IssuanceRepository interface.
+type MockIssuanceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIssuanceRepositoryMockRecorder
+}
+
+// MockIssuanceRepositoryMockRecorder is the mock recorder for MockIssuanceRepository.
+type MockIssuanceRepositoryMockRecorder struct {
+	mock *MockIssuanceRepository
+}
+
+// NewMockIssuanceRepository creates a new mock instance.
+func NewMockIssuanceRepository(ctrl *gomock.Controller) *MockIssuanceRepository {
+	mock := &MockIssuanceRepository{ctrl: ctrl}
+	mock.recorder = &MockIssuanceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIssuanceRepository) EXPECT() *MockIssuanceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// RunIssuance mocks base method.
+func (m *MockIssuanceRepository) RunIssuance(arg0 context.Context, arg1 domain.IssuancePolicy, arg2 string) (int, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunIssuance", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RunIssuance indicates an expected call of RunIssuance.
+func (mr *MockIssuanceRepositoryMockRecorder) RunIssuance(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunIssuance", reflect.TypeOf((*MockIssuanceRepository)(nil).RunIssuance), arg0, arg1, arg2)
+}
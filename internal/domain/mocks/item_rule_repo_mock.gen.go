@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Te8va/MerchStore/internal/domain (interfaces: ItemRuleRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	domain "github.com/Te8va/MerchStore/internal/domain"
+)
+
+// MockItemRuleRepository is a mock of ItemRuleRepository interface.
+type MockItemRuleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemRuleRepositoryMockRecorder
+}
+
+// MockItemRuleRepositoryMockRecorder is the mock recorder for MockItemRuleRepository.
+type MockItemRuleRepositoryMockRecorder struct {
+	mock *MockItemRuleRepository
+}
+
+// NewMockItemRuleRepository creates a new mock instance.
+func NewMockItemRuleRepository(ctrl *gomock.Controller) *MockItemRuleRepository {
+	mock := &MockItemRuleRepository{ctrl: ctrl}
+	mock.recorder = &MockItemRuleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemRuleRepository) EXPECT() *MockItemRuleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteItemRule mocks base method.
+func (m *MockItemRuleRepository) DeleteItemRule(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteItemRule", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteItemRule indicates an expected call of DeleteItemRule.
+func (mr *MockItemRuleRepositoryMockRecorder) DeleteItemRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItemRule", reflect.TypeOf((*MockItemRuleRepository)(nil).DeleteItemRule), arg0, arg1)
+}
+
+// ListItemRules mocks base method.
+func (m *MockItemRuleRepository) ListItemRules(arg0 context.Context) ([]domain.ItemRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListItemRules", arg0)
+	ret0, _ := ret[0].([]domain.ItemRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListItemRules indicates an expected call of ListItemRules.
+func (mr *MockItemRuleRepositoryMockRecorder) ListItemRules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItemRules", reflect.TypeOf((*MockItemRuleRepository)(nil).ListItemRules), arg0)
+}
+
+// SaveItemRule mocks base method.
+func (m *MockItemRuleRepository) SaveItemRule(arg0 context.Context, arg1 domain.ItemRule) (domain.ItemRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveItemRule", arg0, arg1)
+	ret0, _ := ret[0].(domain.ItemRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveItemRule indicates an expected call of SaveItemRule.
+func (mr *MockItemRuleRepositoryMockRecorder) SaveItemRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveItemRule", reflect.TypeOf((*MockItemRuleRepository)(nil).SaveItemRule), arg0, arg1)
+}
+
+// SetUserRole mocks base method.
+func (m *MockItemRuleRepository) SetUserRole(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserRole", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserRole indicates an expected call of SetUserRole.
+func (mr *MockItemRuleRepositoryMockRecorder) SetUserRole(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRole", reflect.TypeOf((*MockItemRuleRepository)(nil).SetUserRole), arg0, arg1, arg2)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Te8va/MerchStore/internal/domain (interfaces: ItemRuleService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	domain "github.com/Te8va/MerchStore/internal/domain"
+)
+
+// MockItemRuleService is a mock of ItemRuleService interface.
+type MockItemRuleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemRuleServiceMockRecorder
+}
+
+// MockItemRuleServiceMockRecorder is the mock recorder for MockItemRuleService.
+type MockItemRuleServiceMockRecorder struct {
+	mock *MockItemRuleService
+}
+
+// NewMockItemRuleService creates a new mock instance.
+func NewMockItemRuleService(ctrl *gomock.Controller) *MockItemRuleService {
+	mock := &MockItemRuleService{ctrl: ctrl}
+	mock.recorder = &MockItemRuleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemRuleService) EXPECT() *MockItemRuleServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteItemRule mocks base method.
+func (m *MockItemRuleService) DeleteItemRule(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteItemRule", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteItemRule indicates an expected call of DeleteItemRule.
+func (mr *MockItemRuleServiceMockRecorder) DeleteItemRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItemRule", reflect.TypeOf((*MockItemRuleService)(nil).DeleteItemRule), arg0, arg1)
+}
+
+// ListItemRules mocks base method.
+func (m *MockItemRuleService) ListItemRules(arg0 context.Context) ([]domain.ItemRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListItemRules", arg0)
+	ret0, _ := ret[0].([]domain.ItemRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListItemRules indicates an expected call of ListItemRules.
+func (mr *MockItemRuleServiceMockRecorder) ListItemRules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItemRules", reflect.TypeOf((*MockItemRuleService)(nil).ListItemRules), arg0)
+}
+
+// SaveItemRule mocks base method.
+func (m *MockItemRuleService) SaveItemRule(arg0 context.Context, arg1 domain.ItemRule) (domain.ItemRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveItemRule", arg0, arg1)
+	ret0, _ := ret[0].(domain.ItemRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveItemRule indicates an expected call of SaveItemRule.
+func (mr *MockItemRuleServiceMockRecorder) SaveItemRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveItemRule", reflect.TypeOf((*MockItemRuleService)(nil).SaveItemRule), arg0, arg1)
+}
+
+// SetUserRole mocks base method.
+func (m *MockItemRuleService) SetUserRole(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserRole", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserRole indicates an expected call of SetUserRole.
+func (mr *MockItemRuleServiceMockRecorder) SetUserRole(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRole", reflect.TypeOf((*MockItemRuleService)(nil).SetUserRole), arg0, arg1, arg2)
+}
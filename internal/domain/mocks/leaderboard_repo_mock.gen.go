@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Te8va/MerchStore/internal/domain (interfaces: LeaderboardRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+
+	domain "github.com/Te8va/MerchStore/internal/domain"
+)
+
+// MockLeaderboardRepository is a mock of LeaderboardRepository interface.
+type MockLeaderboardRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLeaderboardRepositoryMockRecorder
+}
+
+// MockLeaderboardRepositoryMockRecorder is the mock recorder for MockLeaderboardRepository.
+type MockLeaderboardRepositoryMockRecorder struct {
+	mock *MockLeaderboardRepository
+}
+
+// NewMockLeaderboardRepository creates a new mock instance.
+func NewMockLeaderboardRepository(ctrl *gomock.Controller) *MockLeaderboardRepository {
+	mock := &MockLeaderboardRepository{ctrl: ctrl}
+	mock.recorder = &MockLeaderboardRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLeaderboardRepository) EXPECT() *MockLeaderboardRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetLeaderboard mocks base method.
+func (m *MockLeaderboardRepository) GetLeaderboard(arg0 context.Context, arg1 string, arg2 time.Time, arg3 int) ([]domain.LeaderboardEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeaderboard", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.LeaderboardEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeaderboard indicates an expected call of GetLeaderboard.
+func (mr *MockLeaderboardRepositoryMockRecorder) GetLeaderboard(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaderboard", reflect.TypeOf((*MockLeaderboardRepository)(nil).GetLeaderboard), arg0, arg1, arg2, arg3)
+}
+
+// SetLeaderboardOptOut mocks base method.
+func (m *MockLeaderboardRepository) SetLeaderboardOptOut(arg0 context.Context, arg1 string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLeaderboardOptOut", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLeaderboardOptOut indicates an expected call of SetLeaderboardOptOut.
+func (mr *MockLeaderboardRepositoryMockRecorder) SetLeaderboardOptOut(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLeaderboardOptOut", reflect.TypeOf((*MockLeaderboardRepository)(nil).SetLeaderboardOptOut), arg0, arg1, arg2)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Te8va/MerchStore/internal/domain (interfaces: LeaderboardService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	domain "github.com/Te8va/MerchStore/internal/domain"
+)
+
+// MockLeaderboardService is a mock of LeaderboardService interface.
+type MockLeaderboardService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLeaderboardServiceMockRecorder
+}
+
+// MockLeaderboardServiceMockRecorder is the mock recorder for MockLeaderboardService.
+type MockLeaderboardServiceMockRecorder struct {
+	mock *MockLeaderboardService
+}
+
+// NewMockLeaderboardService creates a new mock instance.
+func NewMockLeaderboardService(ctrl *gomock.Controller) *MockLeaderboardService {
+	mock := &MockLeaderboardService{ctrl: ctrl}
+	mock.recorder = &MockLeaderboardServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLeaderboardService) EXPECT() *MockLeaderboardServiceMockRecorder {
+	return m.recorder
+}
+
+// GetLeaderboard mocks base method.
+func (m *MockLeaderboardService) GetLeaderboard(arg0 context.Context, arg1, arg2 string, arg3 int) (domain.Leaderboard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeaderboard", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(domain.Leaderboard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeaderboard indicates an expected call of GetLeaderboard.
+func (mr *MockLeaderboardServiceMockRecorder) GetLeaderboard(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaderboard", reflect.TypeOf((*MockLeaderboardService)(nil).GetLeaderboard), arg0, arg1, arg2, arg3)
+}
+
+// SetOptOut mocks base method.
+func (m *MockLeaderboardService) SetOptOut(arg0 context.Context, arg1 string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOptOut", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOptOut indicates an expected call of SetOptOut.
+func (mr *MockLeaderboardServiceMockRecorder) SetOptOut(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOptOut", reflect.TypeOf((*MockLeaderboardService)(nil).SetOptOut), arg0, arg1, arg2)
+}
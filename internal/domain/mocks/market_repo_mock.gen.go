@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Te8va/MerchStore/internal/domain (interfaces: MarketRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+
+	domain "github.com/Te8va/MerchStore/internal/domain"
+)
+
+// MockMarketRepository is a mock of MarketRepository interface.
+type MockMarketRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMarketRepositoryMockRecorder
+}
+
+// MockMarketRepositoryMockRecorder is the mock recorder for MockMarketRepository.
+type MockMarketRepositoryMockRecorder struct {
+	mock *MockMarketRepository
+}
+
+// NewMockMarketRepository creates a new mock instance.
+func NewMockMarketRepository(ctrl *gomock.Controller) *MockMarketRepository {
+	mock := &MockMarketRepository{ctrl: ctrl}
+	mock.recorder = &MockMarketRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMarketRepository) EXPECT() *MockMarketRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BuyListing mocks base method.
+func (m *MockMarketRepository) BuyListing(arg0 context.Context, arg1 int, arg2 string, arg3 domain.TransferPolicy, arg4 time.Time) (domain.Listing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuyListing", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(domain.Listing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BuyListing indicates an expected call of BuyListing.
+func (mr *MockMarketRepositoryMockRecorder) BuyListing(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyListing", reflect.TypeOf((*MockMarketRepository)(nil).BuyListing), arg0, arg1, arg2, arg3, arg4)
+}
+
+// CancelListing mocks base method.
+func (m *MockMarketRepository) CancelListing(arg0 context.Context, arg1 int, arg2 string) (domain.Listing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelListing", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Listing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelListing indicates an expected call of CancelListing.
+func (mr *MockMarketRepositoryMockRecorder) CancelListing(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelListing", reflect.TypeOf((*MockMarketRepository)(nil).CancelListing), arg0, arg1, arg2)
+}
+
+// CreateListing mocks base method.
+func (m *MockMarketRepository) CreateListing(arg0 context.Context, arg1 domain.Listing) (domain.Listing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateListing", arg0, arg1)
+	ret0, _ := ret[0].(domain.Listing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateListing indicates an expected call of CreateListing.
+func (mr *MockMarketRepositoryMockRecorder) CreateListing(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateListing", reflect.TypeOf((*MockMarketRepository)(nil).CreateListing), arg0, arg1)
+}
+
+// ListListings mocks base method.
+func (m *MockMarketRepository) ListListings(arg0 context.Context, arg1 domain.ListingFilter) ([]domain.Listing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListListings", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Listing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListListings indicates an expected call of ListListings.
+func (mr *MockMarketRepositoryMockRecorder) ListListings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListListings", reflect.TypeOf((*MockMarketRepository)(nil).ListListings), arg0, arg1)
+}
+
+// TransferItem mocks base method.
+func (m *MockMarketRepository) TransferItem(arg0 context.Context, arg1 domain.ItemTransfer, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferItem", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransferItem indicates an expected call of TransferItem.
+func (mr *MockMarketRepositoryMockRecorder) TransferItem(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferItem", reflect.TypeOf((*MockMarketRepository)(nil).TransferItem), arg0, arg1, arg2)
+}
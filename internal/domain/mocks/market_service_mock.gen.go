@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Te8va/MerchStore/internal/domain (interfaces: MarketService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	domain "github.com/Te8va/MerchStore/internal/domain"
+)
+
+// MockMarketService is a mock of MarketService interface.
+type MockMarketService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMarketServiceMockRecorder
+}
+
+// MockMarketServiceMockRecorder is the mock recorder for MockMarketService.
+type MockMarketServiceMockRecorder struct {
+	mock *MockMarketService
+}
+
+// NewMockMarketService creates a new mock instance.
+func NewMockMarketService(ctrl *gomock.Controller) *MockMarketService {
+	mock := &MockMarketService{ctrl: ctrl}
+	mock.recorder = &MockMarketServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMarketService) EXPECT() *MockMarketServiceMockRecorder {
+	return m.recorder
+}
+
+// BuyListing mocks base method.
+func (m *MockMarketService) BuyListing(arg0 context.Context, arg1 int, arg2 string) (domain.Listing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuyListing", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Listing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BuyListing indicates an expected call of BuyListing.
+func (mr *MockMarketServiceMockRecorder) BuyListing(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyListing", reflect.TypeOf((*MockMarketService)(nil).BuyListing), arg0, arg1, arg2)
+}
+
+// CancelListing mocks base method.
+func (m *MockMarketService) CancelListing(arg0 context.Context, arg1 int, arg2 string) (domain.Listing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelListing", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Listing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelListing indicates an expected call of CancelListing.
+func (mr *MockMarketServiceMockRecorder) CancelListing(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelListing", reflect.TypeOf((*MockMarketService)(nil).CancelListing), arg0, arg1, arg2)
+}
+
+// CreateListing mocks base method.
+func (m *MockMarketService) CreateListing(arg0 context.Context, arg1 domain.Listing) (domain.Listing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateListing", arg0, arg1)
+	ret0, _ := ret[0].(domain.Listing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateListing indicates an expected call of CreateListing.
+func (mr *MockMarketServiceMockRecorder) CreateListing(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateListing", reflect.TypeOf((*MockMarketService)(nil).CreateListing), arg0, arg1)
+}
+
+// ListListings mocks base method.
+func (m *MockMarketService) ListListings(arg0 context.Context, arg1 domain.ListingFilter) ([]domain.Listing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListListings", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Listing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListListings indicates an expected call of ListListings.
+func (mr *MockMarketServiceMockRecorder) ListListings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListListings", reflect.TypeOf((*MockMarketService)(nil).ListListings), arg0, arg1)
+}
+
+// TransferItem mocks base method.
+func (m *MockMarketService) TransferItem(arg0 context.Context, arg1 domain.ItemTransfer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferItem", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransferItem indicates an expected call of TransferItem.
+func (mr *MockMarketServiceMockRecorder) TransferItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferItem", reflect.TypeOf((*MockMarketService)(nil).TransferItem), arg0, arg1)
+}
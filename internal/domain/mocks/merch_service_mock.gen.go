@@ -37,17 +37,33 @@ func (m *MockMerchService) EXPECT() *MockMerchServiceMockRecorder {
 }
 
 // BuyMerch mocks base method.
-func (m *MockMerchService) BuyMerch(arg0 context.Context, arg1, arg2 string) error {
+func (m *MockMerchService) BuyMerch(arg0 context.Context, arg1 domain.PurchaseRequest) (domain.Purchase, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BuyMerch", arg0, arg1, arg2)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "BuyMerch", arg0, arg1)
+	ret0, _ := ret[0].(domain.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // BuyMerch indicates an expected call of BuyMerch.
-func (mr *MockMerchServiceMockRecorder) BuyMerch(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockMerchServiceMockRecorder) BuyMerch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyMerch", reflect.TypeOf((*MockMerchService)(nil).BuyMerch), arg0, arg1)
+}
+
+// GetUserHistory mocks base method.
+func (m *MockMerchService) GetUserHistory(arg0 context.Context, arg1 string, arg2 domain.HistoryFilter) ([]domain.HistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]domain.HistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserHistory indicates an expected call of GetUserHistory.
+func (mr *MockMerchServiceMockRecorder) GetUserHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyMerch", reflect.TypeOf((*MockMerchService)(nil).BuyMerch), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserHistory", reflect.TypeOf((*MockMerchService)(nil).GetUserHistory), arg0, arg1, arg2)
 }
 
 // GetUserInfo mocks base method.
@@ -65,16 +81,30 @@ func (mr *MockMerchServiceMockRecorder) GetUserInfo(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserInfo", reflect.TypeOf((*MockMerchService)(nil).GetUserInfo), arg0, arg1)
 }
 
+// GiftMerch mocks base method.
+func (m *MockMerchService) GiftMerch(arg0 context.Context, arg1 domain.Gift) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GiftMerch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GiftMerch indicates an expected call of GiftMerch.
+func (mr *MockMerchServiceMockRecorder) GiftMerch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GiftMerch", reflect.TypeOf((*MockMerchService)(nil).GiftMerch), arg0, arg1)
+}
+
 // SendCoin mocks base method.
-func (m *MockMerchService) SendCoin(arg0 context.Context, arg1, arg2 string, arg3 int) error {
+func (m *MockMerchService) SendCoin(arg0 context.Context, arg1 domain.Transfer) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendCoin", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "SendCoin", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SendCoin indicates an expected call of SendCoin.
-func (mr *MockMerchServiceMockRecorder) SendCoin(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockMerchServiceMockRecorder) SendCoin(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCoin", reflect.TypeOf((*MockMerchService)(nil).SendCoin), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCoin", reflect.TypeOf((*MockMerchService)(nil).SendCoin), arg0, arg1)
 }
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Te8va/MerchStore/internal/domain (interfaces: PurchaseRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	domain "github.com/Te8va/MerchStore/internal/domain"
+)
+
+// MockPurchaseRepository is a mock of PurchaseRepository interface.
+type MockPurchaseRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPurchaseRepositoryMockRecorder
+}
+
+// MockPurchaseRepositoryMockRecorder is the mock recorder for MockPurchaseRepository.
+type MockPurchaseRepositoryMockRecorder struct {
+	mock *MockPurchaseRepository
+}
+
+// NewMockPurchaseRepository creates a new mock instance.
+func NewMockPurchaseRepository(ctrl *gomock.Controller) *MockPurchaseRepository {
+	mock := &MockPurchaseRepository{ctrl: ctrl}
+	mock.recorder = &MockPurchaseRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPurchaseRepository) EXPECT() *MockPurchaseRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListPurchases mocks base method.
+func (m *MockPurchaseRepository) ListPurchases(arg0 context.Context, arg1 string, arg2, arg3 int) ([]domain.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPurchases", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPurchases indicates an expected call of ListPurchases.
+func (mr *MockPurchaseRepositoryMockRecorder) ListPurchases(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurchases", reflect.TypeOf((*MockPurchaseRepository)(nil).ListPurchases), arg0, arg1, arg2, arg3)
+}
+
+// RefundPurchase mocks base method.
+func (m *MockPurchaseRepository) RefundPurchase(arg0 context.Context, arg1 domain.RefundRequest) (domain.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundPurchase", arg0, arg1)
+	ret0, _ := ret[0].(domain.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundPurchase indicates an expected call of RefundPurchase.
+func (mr *MockPurchaseRepositoryMockRecorder) RefundPurchase(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundPurchase", reflect.TypeOf((*MockPurchaseRepository)(nil).RefundPurchase), arg0, arg1)
+}
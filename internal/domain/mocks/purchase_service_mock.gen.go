@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Te8va/MerchStore/internal/domain (interfaces: PurchaseService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	domain "github.com/Te8va/MerchStore/internal/domain"
+)
+
+// MockPurchaseService is a mock of PurchaseService interface.
+type MockPurchaseService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPurchaseServiceMockRecorder
+}
+
+// MockPurchaseServiceMockRecorder is the mock recorder for MockPurchaseService.
+type MockPurchaseServiceMockRecorder struct {
+	mock *MockPurchaseService
+}
+
+// NewMockPurchaseService creates a new mock instance.
+func NewMockPurchaseService(ctrl *gomock.Controller) *MockPurchaseService {
+	mock := &MockPurchaseService{ctrl: ctrl}
+	mock.recorder = &MockPurchaseServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPurchaseService) EXPECT() *MockPurchaseServiceMockRecorder {
+	return m.recorder
+}
+
+// AdminRefund mocks base method.
+func (m *MockPurchaseService) AdminRefund(arg0 context.Context, arg1, arg2 int, arg3 string) (domain.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminRefund", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(domain.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminRefund indicates an expected call of AdminRefund.
+func (mr *MockPurchaseServiceMockRecorder) AdminRefund(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminRefund", reflect.TypeOf((*MockPurchaseService)(nil).AdminRefund), arg0, arg1, arg2, arg3)
+}
+
+// ListPurchases mocks base method.
+func (m *MockPurchaseService) ListPurchases(arg0 context.Context, arg1 string, arg2, arg3 int) ([]domain.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPurchases", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPurchases indicates an expected call of ListPurchases.
+func (mr *MockPurchaseServiceMockRecorder) ListPurchases(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurchases", reflect.TypeOf((*MockPurchaseService)(nil).ListPurchases), arg0, arg1, arg2, arg3)
+}
+
+// Refund mocks base method.
+func (m *MockPurchaseService) Refund(arg0 context.Context, arg1 string, arg2, arg3 int) (domain.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(domain.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockPurchaseServiceMockRecorder) Refund(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockPurchaseService)(nil).Refund), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Te8va/MerchStore/internal/domain (interfaces: RedemptionRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	domain "github.com/Te8va/MerchStore/internal/domain"
+)
+
+// MockRedemptionRepository is a mock of RedemptionRepository interface.
+type MockRedemptionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRedemptionRepositoryMockRecorder
+}
+
+// MockRedemptionRepositoryMockRecorder is the mock recorder for MockRedemptionRepository.
+type MockRedemptionRepositoryMockRecorder struct {
+	mock *MockRedemptionRepository
+}
+
+// NewMockRedemptionRepository creates a new mock instance.
+func NewMockRedemptionRepository(ctrl *gomock.Controller) *MockRedemptionRepository {
+	mock := &MockRedemptionRepository{ctrl: ctrl}
+	mock.recorder = &MockRedemptionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRedemptionRepository) EXPECT() *MockRedemptionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateRedemption mocks base method.
+func (m *MockRedemptionRepository) CreateRedemption(arg0 context.Context, arg1 domain.Redemption) (domain.Redemption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRedemption", arg0, arg1)
+	ret0, _ := ret[0].(domain.Redemption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRedemption indicates an expected call of CreateRedemption.
+func (mr *MockRedemptionRepositoryMockRecorder) CreateRedemption(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRedemption", reflect.TypeOf((*MockRedemptionRepository)(nil).CreateRedemption), arg0, arg1)
+}
+
+// GetRedemption mocks base method.
+func (m *MockRedemptionRepository) GetRedemption(arg0 context.Context, arg1 int) (domain.Redemption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRedemption", arg0, arg1)
+	ret0, _ := ret[0].(domain.Redemption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRedemption indicates an expected call of GetRedemption.
+func (mr *MockRedemptionRepositoryMockRecorder) GetRedemption(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRedemption", reflect.TypeOf((*MockRedemptionRepository)(nil).GetRedemption), arg0, arg1)
+}
+
+// ListRedemptions mocks base method.
+func (m *MockRedemptionRepository) ListRedemptions(arg0 context.Context, arg1 domain.RedemptionFilter) ([]domain.Redemption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRedemptions", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Redemption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRedemptions indicates an expected call of ListRedemptions.
+func (mr *MockRedemptionRepositoryMockRecorder) ListRedemptions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRedemptions", reflect.TypeOf((*MockRedemptionRepository)(nil).ListRedemptions), arg0, arg1)
+}
+
+// UpdateRedemptionStatus mocks base method.
+func (m *MockRedemptionRepository) UpdateRedemptionStatus(arg0 context.Context, arg1 int, arg2 string) (domain.Redemption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRedemptionStatus", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Redemption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRedemptionStatus indicates an expected call of UpdateRedemptionStatus.
+func (mr *MockRedemptionRepositoryMockRecorder) UpdateRedemptionStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRedemptionStatus", reflect.TypeOf((*MockRedemptionRepository)(nil).UpdateRedemptionStatus), arg0, arg1, arg2)
+}
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Te8va/MerchStore/internal/domain (interfaces: RedemptionService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	domain "github.com/Te8va/MerchStore/internal/domain"
+)
+
+// MockRedemptionService is a mock of RedemptionService interface.
+type MockRedemptionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRedemptionServiceMockRecorder
+}
+
+// MockRedemptionServiceMockRecorder is the mock recorder for MockRedemptionService.
+type MockRedemptionServiceMockRecorder struct {
+	mock *MockRedemptionService
+}
+
+// NewMockRedemptionService creates a new mock instance.
+func NewMockRedemptionService(ctrl *gomock.Controller) *MockRedemptionService {
+	mock := &MockRedemptionService{ctrl: ctrl}
+	mock.recorder = &MockRedemptionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRedemptionService) EXPECT() *MockRedemptionServiceMockRecorder {
+	return m.recorder
+}
+
+// GetRedemption mocks base method.
+func (m *MockRedemptionService) GetRedemption(arg0 context.Context, arg1 string, arg2 int) (domain.Redemption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRedemption", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Redemption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRedemption indicates an expected call of GetRedemption.
+func (mr *MockRedemptionServiceMockRecorder) GetRedemption(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRedemption", reflect.TypeOf((*MockRedemptionService)(nil).GetRedemption), arg0, arg1, arg2)
+}
+
+// ListRedemptions mocks base method.
+func (m *MockRedemptionService) ListRedemptions(arg0 context.Context, arg1 domain.RedemptionFilter) ([]domain.Redemption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRedemptions", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Redemption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRedemptions indicates an expected call of ListRedemptions.
+func (mr *MockRedemptionServiceMockRecorder) ListRedemptions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRedemptions", reflect.TypeOf((*MockRedemptionService)(nil).ListRedemptions), arg0, arg1)
+}
+
+// Redeem mocks base method.
+func (m *MockRedemptionService) Redeem(arg0 context.Context, arg1 domain.Redemption) (domain.Redemption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeem", arg0, arg1)
+	ret0, _ := ret[0].(domain.Redemption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeem indicates an expected call of Redeem.
+func (mr *MockRedemptionServiceMockRecorder) Redeem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeem", reflect.TypeOf((*MockRedemptionService)(nil).Redeem), arg0, arg1)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockRedemptionService) UpdateStatus(arg0 context.Context, arg1 int, arg2 string) (domain.Redemption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Redemption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockRedemptionServiceMockRecorder) UpdateStatus(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockRedemptionService)(nil).UpdateStatus), arg0, arg1, arg2)
+}
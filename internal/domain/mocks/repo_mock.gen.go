@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 
@@ -36,6 +37,21 @@ func (m *MockMerchRepository) EXPECT() *MockMerchRepositoryMockRecorder {
 	return m.recorder
 }
 
+// GetItemRule mocks base method.
+func (m *MockMerchRepository) GetItemRule(arg0 context.Context, arg1 string) (domain.ItemRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemRule", arg0, arg1)
+	ret0, _ := ret[0].(domain.ItemRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemRule indicates an expected call of GetItemRule.
+func (mr *MockMerchRepositoryMockRecorder) GetItemRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemRule", reflect.TypeOf((*MockMerchRepository)(nil).GetItemRule), arg0, arg1)
+}
+
 // GetMerchPrice mocks base method.
 func (m *MockMerchRepository) GetMerchPrice(arg0 context.Context, arg1 string) (int, error) {
 	m.ctrl.T.Helper()
@@ -51,6 +67,51 @@ func (mr *MockMerchRepositoryMockRecorder) GetMerchPrice(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchPrice", reflect.TypeOf((*MockMerchRepository)(nil).GetMerchPrice), arg0, arg1)
 }
 
+// GetPromotions mocks base method.
+func (m *MockMerchRepository) GetPromotions(arg0 context.Context, arg1, arg2 string, arg3 time.Time) ([]domain.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotions", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotions indicates an expected call of GetPromotions.
+func (mr *MockMerchRepositoryMockRecorder) GetPromotions(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotions", reflect.TypeOf((*MockMerchRepository)(nil).GetPromotions), arg0, arg1, arg2, arg3)
+}
+
+// GetPurchaseStats mocks base method.
+func (m *MockMerchRepository) GetPurchaseStats(arg0 context.Context, arg1, arg2 string, arg3 time.Time) (domain.PurchaseStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseStats", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(domain.PurchaseStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchaseStats indicates an expected call of GetPurchaseStats.
+func (mr *MockMerchRepositoryMockRecorder) GetPurchaseStats(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseStats", reflect.TypeOf((*MockMerchRepository)(nil).GetPurchaseStats), arg0, arg1, arg2, arg3)
+}
+
+// GetTransferTotals mocks base method.
+func (m *MockMerchRepository) GetTransferTotals(arg0 context.Context, arg1, arg2 string, arg3 time.Time) (domain.TransferTotals, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferTotals", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(domain.TransferTotals)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferTotals indicates an expected call of GetTransferTotals.
+func (mr *MockMerchRepositoryMockRecorder) GetTransferTotals(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferTotals", reflect.TypeOf((*MockMerchRepository)(nil).GetTransferTotals), arg0, arg1, arg2, arg3)
+}
+
 // GetUserBalance mocks base method.
 func (m *MockMerchRepository) GetUserBalance(arg0 context.Context, arg1 string) (int, error) {
 	m.ctrl.T.Helper()
@@ -66,6 +127,36 @@ func (mr *MockMerchRepositoryMockRecorder) GetUserBalance(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserBalance", reflect.TypeOf((*MockMerchRepository)(nil).GetUserBalance), arg0, arg1)
 }
 
+// GetUserCreatedAt mocks base method.
+func (m *MockMerchRepository) GetUserCreatedAt(arg0 context.Context, arg1 string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCreatedAt", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserCreatedAt indicates an expected call of GetUserCreatedAt.
+func (mr *MockMerchRepositoryMockRecorder) GetUserCreatedAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCreatedAt", reflect.TypeOf((*MockMerchRepository)(nil).GetUserCreatedAt), arg0, arg1)
+}
+
+// GetUserHistory mocks base method.
+func (m *MockMerchRepository) GetUserHistory(arg0 context.Context, arg1 string, arg2 domain.HistoryFilter) ([]domain.HistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]domain.HistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserHistory indicates an expected call of GetUserHistory.
+func (mr *MockMerchRepositoryMockRecorder) GetUserHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserHistory", reflect.TypeOf((*MockMerchRepository)(nil).GetUserHistory), arg0, arg1, arg2)
+}
+
 // GetUserInventory mocks base method.
 func (m *MockMerchRepository) GetUserInventory(arg0 context.Context, arg1 string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -111,22 +202,37 @@ func (mr *MockMerchRepositoryMockRecorder) GetUserTransactionHistory(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTransactionHistory", reflect.TypeOf((*MockMerchRepository)(nil).GetUserTransactionHistory), arg0, arg1)
 }
 
-// SavePurchase mocks base method.
-func (m *MockMerchRepository) SavePurchase(arg0 context.Context, arg1, arg2 string, arg3 int) error {
+// SaveGift mocks base method.
+func (m *MockMerchRepository) SaveGift(arg0 context.Context, arg1 domain.Gift, arg2 int, arg3 time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SavePurchase", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "SaveGift", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// SaveGift indicates an expected call of SaveGift.
+func (mr *MockMerchRepositoryMockRecorder) SaveGift(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveGift", reflect.TypeOf((*MockMerchRepository)(nil).SaveGift), arg0, arg1, arg2, arg3)
+}
+
+// SavePurchase mocks base method.
+func (m *MockMerchRepository) SavePurchase(arg0 context.Context, arg1 domain.Purchase, arg2 time.Time) (domain.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePurchase", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // SavePurchase indicates an expected call of SavePurchase.
-func (mr *MockMerchRepositoryMockRecorder) SavePurchase(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockMerchRepositoryMockRecorder) SavePurchase(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePurchase", reflect.TypeOf((*MockMerchRepository)(nil).SavePurchase), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePurchase", reflect.TypeOf((*MockMerchRepository)(nil).SavePurchase), arg0, arg1, arg2)
 }
 
 // TransferCoins mocks base method.
-func (m *MockMerchRepository) TransferCoins(arg0 context.Context, arg1, arg2 string, arg3 int) error {
+func (m *MockMerchRepository) TransferCoins(arg0 context.Context, arg1 domain.Transfer, arg2 domain.TransferPolicy, arg3 time.Time) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "TransferCoins", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Te8va/MerchStore/internal/domain (interfaces: WishlistService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	domain "github.com/Te8va/MerchStore/internal/domain"
+)
+
+// MockWishlistService is a mock of WishlistService interface.
+type MockWishlistService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWishlistServiceMockRecorder
+}
+
+// MockWishlistServiceMockRecorder is the mock recorder for MockWishlistService.
+type MockWishlistServiceMockRecorder struct {
+	mock *MockWishlistService
+}
+
+// NewMockWishlistService creates a new mock instance.
+func NewMockWishlistService(ctrl *gomock.Controller) *MockWishlistService {
+	mock := &MockWishlistService{ctrl: ctrl}
+	mock.recorder = &MockWishlistServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWishlistService) EXPECT() *MockWishlistServiceMockRecorder {
+	return m.recorder
+}
+
+// AddToWishlist mocks base method.
+func (m *MockWishlistService) AddToWishlist(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToWishlist", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddToWishlist indicates an expected call of AddToWishlist.
+func (mr *MockWishlistServiceMockRecorder) AddToWishlist(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToWishlist", reflect.TypeOf((*MockWishlistService)(nil).AddToWishlist), arg0, arg1, arg2)
+}
+
+// GetWishlist mocks base method.
+func (m *MockWishlistService) GetWishlist(arg0 context.Context, arg1 string) (domain.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWishlist", arg0, arg1)
+	ret0, _ := ret[0].(domain.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWishlist indicates an expected call of GetWishlist.
+func (mr *MockWishlistServiceMockRecorder) GetWishlist(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWishlist", reflect.TypeOf((*MockWishlistService)(nil).GetWishlist), arg0, arg1)
+}
+
+// RemoveFromWishlist mocks base method.
+func (m *MockWishlistService) RemoveFromWishlist(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromWishlist", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFromWishlist indicates an expected call of RemoveFromWishlist.
+func (mr *MockWishlistServiceMockRecorder) RemoveFromWishlist(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromWishlist", reflect.TypeOf((*MockWishlistService)(nil).RemoveFromWishlist), arg0, arg1, arg2)
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	NotificationPriceDrop        = "price_drop"
+	NotificationPromotion        = "promotion"
+	NotificationCoinsReceived    = "coins_received"
+	NotificationGiftReceived     = "gift_received"
+	NotificationItemReceived     = "item_received"
+	NotificationRefund           = "refund"
+	NotificationCoinsGranted     = "coins_granted"
+	NotificationCoinsDeducted    = "coins_deducted"
+	NotificationRedemptionStatus = "redemption_status"
+
+	MaxMarkReadIDs = 100
+)
+
+type Notification struct {
+	ID        int64      `json:"id"`
+	Kind      string     `json:"kind"`
+	Message   string     `json:"message"`
+	Item      string     `json:"item,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ReadAt    *time.Time `json:"readAt,omitempty"`
+}
+
+type NotificationFilter struct {
+	UnreadOnly bool
+	Limit      int
+	Offset     int
+}
+
+// Inbox is a page of notifications together with the number of unread
+// notifications in the whole inbox.
+type Inbox struct {
+	Unread        int            `json:"unread"`
+	Notifications []Notification `json:"notifications"`
+}
+
+//go:generate mockgen -destination=mocks/notification_repo_mock.gen.go -package=mocks . NotificationRepository
+type NotificationRepository interface {
+	ListNotifications(ctx context.Context, username string, filter NotificationFilter) ([]Notification, error)
+	CountUnread(ctx context.Context, username string) (int, error)
+	MarkRead(ctx context.Context, username string, ids []int64, at time.Time) (int, error)
+}
+
+//go:generate mockgen -destination=mocks/notification_service_mock.gen.go -package=mocks . NotificationService
+type NotificationService interface {
+	GetInbox(ctx context.Context, username string, filter NotificationFilter) (Inbox, error)
+	MarkRead(ctx context.Context, username string, ids []int64) (int, error)
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	DiscountPercent = "percent"
+	DiscountFixed   = "fixed"
+)
+
+// Promotion discounts every unit of Item, or of every item in Category.
+// Promotions without a Code apply automatically while they are running;
+// coded ones only when the buyer passes the code. Zero MaxUses or
+// PerUserLimit means unlimited.
+type Promotion struct {
+	ID            int        `json:"id"`
+	Name          string     `json:"name"`
+	Item          string     `json:"item,omitempty"`
+	Category      string     `json:"category,omitempty"`
+	DiscountType  string     `json:"discountType"`
+	DiscountValue int        `json:"discountValue"`
+	Code          string     `json:"code,omitempty"`
+	MaxUses       int        `json:"maxUses"`
+	PerUserLimit  int        `json:"perUserLimit"`
+	Uses          int        `json:"uses"`
+	StartsAt      time.Time  `json:"startsAt"`
+	EndsAt        *time.Time `json:"endsAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+// Exhausted reports whether the promotion has reached its total MaxUses.
+func (p Promotion) Exhausted() bool {
+	return p.MaxUses > 0 && p.Uses >= p.MaxUses
+}
+
+// UnitDiscount returns how many coins the promotion takes off one unit
+// priced at price. Percentages are rounded down and the discount never
+// exceeds the price.
+func (p Promotion) UnitDiscount(price int) int {
+	var discount int
+	switch p.DiscountType {
+	case DiscountPercent:
+		discount = price * p.DiscountValue / 100
+	case DiscountFixed:
+		discount = p.DiscountValue
+	}
+	return min(max(discount, 0), price)
+}
+
+//go:generate mockgen -destination=mocks/promotion_repo_mock.gen.go -package=mocks . PromotionRepository
+type PromotionRepository interface {
+	CreatePromotion(ctx context.Context, promotion Promotion) (Promotion, error)
+	ListPromotions(ctx context.Context, limit, offset int) ([]Promotion, error)
+	EndPromotion(ctx context.Context, id int, at time.Time) (Promotion, error)
+}
+
+//go:generate mockgen -destination=mocks/promotion_service_mock.gen.go -package=mocks . PromotionService
+type PromotionService interface {
+	CreatePromotion(ctx context.Context, promotion Promotion) (Promotion, error)
+	ListPromotions(ctx context.Context, limit, offset int) ([]Promotion, error)
+	EndPromotion(ctx context.Context, id int) (Promotion, error)
+}
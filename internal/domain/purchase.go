@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const MaxPurchaseQuantity = 100
+
+// PurchaseRequest asks to buy Quantity units of Item. Variant is required
+// for items sold in sizes or colours; Promo is an optional promo code.
+type PurchaseRequest struct {
+	Username string
+	Item     string
+	Variant  string
+	Quantity int
+	Promo    string
+}
+
+// Purchase records Quantity units of Item bought at UnitPrice each.
+// PaidPrice is the unit price after the Discount of the applied promotion.
+type Purchase struct {
+	ID               int        `json:"id"`
+	Username         string     `json:"username"`
+	Item             string     `json:"item"`
+	Variant          string     `json:"variant,omitempty"`
+	UnitPrice        int        `json:"unitPrice"`
+	PaidPrice        int        `json:"paidPrice"`
+	Discount         int        `json:"discount"`
+	PromotionID      int        `json:"promotionId,omitempty"`
+	PromoCode        string     `json:"promo,omitempty"`
+	Quantity         int        `json:"quantity"`
+	RefundedQuantity int        `json:"refundedQuantity"`
+	PurchasedAt      time.Time  `json:"purchasedAt"`
+	RefundedAt       *time.Time `json:"refundedAt,omitempty"`
+}
+
+// RefundRequest describes a refund of Quantity units of a purchase. A zero
+// Quantity refunds everything not refunded yet. Username restricts the
+// refund to the owner's purchases and PurchasedAfter to the refund window;
+// both are left empty for admin refunds.
+type RefundRequest struct {
+	PurchaseID     int
+	Username       string
+	Quantity       int
+	Reason         string
+	PurchasedAfter time.Time
+}
+
+type Refund struct {
+	PurchaseID int    `json:"purchaseId"`
+	Item       string `json:"item"`
+	Variant    string `json:"variant,omitempty"`
+	Quantity   int    `json:"quantity"`
+	Amount     int    `json:"amount"`
+}
+
+//go:generate mockgen -destination=mocks/purchase_repo_mock.gen.go -package=mocks . PurchaseRepository
+type PurchaseRepository interface {
+	ListPurchases(ctx context.Context, username string, limit, offset int) ([]Purchase, error)
+	RefundPurchase(ctx context.Context, req RefundRequest) (Refund, error)
+}
+
+//go:generate mockgen -destination=mocks/purchase_service_mock.gen.go -package=mocks . PurchaseService
+type PurchaseService interface {
+	ListPurchases(ctx context.Context, username string, limit, offset int) ([]Purchase, error)
+	Refund(ctx context.Context, username string, purchaseID, quantity int) (Refund, error)
+	AdminRefund(ctx context.Context, purchaseID, quantity int, reason string) (Refund, error)
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	RedemptionStatusPending   = "pending"
+	RedemptionStatusApproved  = "approved"
+	RedemptionStatusShipped   = "shipped"
+	RedemptionStatusDelivered = "delivered"
+	RedemptionStatusRejected  = "rejected"
+)
+
+// redemptionTransitions lists the statuses an admin may move a redemption
+// to from its current status.
+var redemptionTransitions = map[string][]string{
+	RedemptionStatusPending:  {RedemptionStatusApproved, RedemptionStatusRejected},
+	RedemptionStatusApproved: {RedemptionStatusShipped, RedemptionStatusRejected},
+	RedemptionStatusShipped:  {RedemptionStatusDelivered},
+}
+
+func IsRedemptionStatus(status string) bool {
+	switch status {
+	case RedemptionStatusPending, RedemptionStatusApproved, RedemptionStatusShipped,
+		RedemptionStatusDelivered, RedemptionStatusRejected:
+		return true
+	}
+	return false
+}
+
+func CanTransitionRedemption(from, to string) bool {
+	for _, next := range redemptionTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Redemption is a request to hand over Quantity physical units of Item.
+// The units leave the inventory when the request is created and come back
+// only if it is rejected.
+type Redemption struct {
+	ID        int       `json:"id"`
+	Username  string    `json:"username"`
+	Item      string    `json:"item"`
+	Variant   string    `json:"variant,omitempty"`
+	Quantity  int       `json:"quantity"`
+	Office    string    `json:"office,omitempty"`
+	Note      string    `json:"note,omitempty"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type RedemptionFilter struct {
+	Username string
+	Status   string
+	Limit    int
+	Offset   int
+}
+
+//go:generate mockgen -destination=mocks/redemption_repo_mock.gen.go -package=mocks . RedemptionRepository
+type RedemptionRepository interface {
+	CreateRedemption(ctx context.Context, redemption Redemption) (Redemption, error)
+	GetRedemption(ctx context.Context, id int) (Redemption, error)
+	ListRedemptions(ctx context.Context, filter RedemptionFilter) ([]Redemption, error)
+	UpdateRedemptionStatus(ctx context.Context, id int, status string) (Redemption, error)
+}
+
+//go:generate mockgen -destination=mocks/redemption_service_mock.gen.go -package=mocks . RedemptionService
+type RedemptionService interface {
+	Redeem(ctx context.Context, redemption Redemption) (Redemption, error)
+	GetRedemption(ctx context.Context, username string, id int) (Redemption, error)
+	ListRedemptions(ctx context.Context, filter RedemptionFilter) ([]Redemption, error)
+	UpdateStatus(ctx context.Context, id int, status string) (Redemption, error)
+}
@@ -0,0 +1,73 @@
+package domain
+
+import "time"
+
+const MaxTransferMessageLength = 200
+
+const (
+	CategoryThanks      = "thanks"
+	CategoryHelp        = "help"
+	CategoryTeamwork    = "teamwork"
+	CategoryCelebration = "celebration"
+	CategoryOther       = "other"
+)
+
+var TransferCategories = []string{CategoryThanks, CategoryHelp, CategoryTeamwork, CategoryCelebration, CategoryOther}
+
+const (
+	DirectionIncoming = "incoming"
+	DirectionOutgoing = "outgoing"
+)
+
+type Transfer struct {
+	FromUser string `json:"fromUser"`
+	ToUser   string `json:"toUser"`
+	Amount   int    `json:"amount"`
+	Message  string `json:"message,omitempty"`
+	Category string `json:"category,omitempty"`
+}
+
+// TransferPolicy limits outgoing transfers. A zero value disables the
+// corresponding rule.
+type TransferPolicy struct {
+	MaxPerTransfer       int
+	DailyOutgoingCap     int
+	DailyPerRecipientCap int
+	MinAccountAge        time.Duration
+}
+
+type TransferTotals struct {
+	Outgoing    int
+	ToRecipient int
+}
+
+type Gift struct {
+	FromUser string `json:"fromUser"`
+	ToUser   string `json:"toUser"`
+	Item     string `json:"item"`
+	Variant  string `json:"variant,omitempty"`
+	Quantity int    `json:"quantity"`
+	Message  string `json:"message,omitempty"`
+}
+
+type HistoryFilter struct {
+	Category string
+	Limit    int
+	Offset   int
+}
+
+type HistoryEntry struct {
+	ID        int       `json:"id"`
+	Kind      string    `json:"kind"`
+	Direction string    `json:"direction"`
+	FromUser  string    `json:"fromUser"`
+	ToUser    string    `json:"toUser"`
+	Amount    int       `json:"amount"`
+	Message   string    `json:"message,omitempty"`
+	Category  string    `json:"category,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	Item      string    `json:"item,omitempty"`
+	Variant   string    `json:"variant,omitempty"`
+	Quantity  int       `json:"quantity,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
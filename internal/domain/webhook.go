@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const (
+	OutboxCoinsTransferred = "coins.transferred"
+	OutboxCoinsGranted     = "coins.granted"
+	OutboxCoinsDeducted    = "coins.deducted"
+	OutboxMerchPurchased   = "merch.purchased"
+	OutboxMerchGifted      = "merch.gifted"
+	OutboxMerchRefunded    = "merch.refunded"
+	OutboxMerchTransferred = "merch.transferred"
+	OutboxMarketSale       = "market.sale"
+
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+var OutboxEventTypes = []string{
+	OutboxCoinsTransferred, OutboxCoinsGranted, OutboxCoinsDeducted,
+	OutboxMerchPurchased, OutboxMerchGifted, OutboxMerchRefunded, OutboxMerchTransferred, OutboxMarketSale,
+}
+
+// Webhook is an outgoing subscription. An empty EventTypes list subscribes
+// to every event type. Secret is only returned when the webhook is created.
+type Webhook struct {
+	ID         int64     `json:"id"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret,omitempty"`
+	EventTypes []string  `json:"eventTypes"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// WebhookDelivery is one event queued for one webhook. The event and the
+// webhook target are filled in when the delivery is claimed for sending.
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	WebhookID      int64           `json:"webhookId"`
+	EventID        int64           `json:"eventId"`
+	EventType      string          `json:"eventType"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"nextAttemptAt"`
+	LastStatusCode int             `json:"lastStatusCode,omitempty"`
+	LastError      string          `json:"lastError,omitempty"`
+	DeliveredAt    *time.Time      `json:"deliveredAt,omitempty"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	Payload        json.RawMessage `json:"-"`
+	EventCreatedAt time.Time       `json:"-"`
+	URL            string          `json:"-"`
+	Secret         string          `json:"-"`
+}
+
+type DeliveryFilter struct {
+	WebhookID int64
+	Status    string
+	Limit     int
+	Offset    int
+}
+
+// DeliveryResult is the outcome of one delivery attempt. A failed attempt
+// leaves the delivery pending until NextAttemptAt or marks it dead.
+type DeliveryResult struct {
+	Status        string
+	StatusCode    int
+	Error         string
+	NextAttemptAt time.Time
+	At            time.Time
+}
+
+//go:generate mockgen -destination=mocks/webhook_repo_mock.gen.go -package=mocks . WebhookRepository
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, webhook Webhook) (Webhook, error)
+	ListWebhooks(ctx context.Context) ([]Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook Webhook) (Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	ListDeliveries(ctx context.Context, filter DeliveryFilter) ([]WebhookDelivery, error)
+	ClaimDeliveries(ctx context.Context, at, leaseUntil time.Time, limit int) ([]WebhookDelivery, error)
+	SaveDeliveryResult(ctx context.Context, id int64, result DeliveryResult) error
+	ReplayEvent(ctx context.Context, eventID, webhookID int64, at time.Time) (int, error)
+}
+
+//go:generate mockgen -destination=mocks/webhook_service_mock.gen.go -package=mocks . WebhookService
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, webhook Webhook) (Webhook, error)
+	ListWebhooks(ctx context.Context) ([]Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook Webhook) (Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	ListDeliveries(ctx context.Context, filter DeliveryFilter) ([]WebhookDelivery, error)
+	ReplayEvent(ctx context.Context, eventID, webhookID int64) (int, error)
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const MaxWishlistItems = 50
+
+type WishlistItem struct {
+	Item       string    `json:"item"`
+	Price      int       `json:"price"`
+	Category   string    `json:"category"`
+	Affordable bool      `json:"affordable"`
+	AddedAt    time.Time `json:"addedAt"`
+}
+
+type Wishlist struct {
+	Balance int            `json:"balance"`
+	Items   []WishlistItem `json:"items"`
+}
+
+//go:generate mockgen -destination=mocks/wishlist_repo_mock.gen.go -package=mocks . WishlistRepository
+type WishlistRepository interface {
+	GetWishlist(ctx context.Context, username string) (Wishlist, error)
+	AddToWishlist(ctx context.Context, username, item string) error
+	RemoveFromWishlist(ctx context.Context, username, item string) error
+	NotifyStartedPromotions(ctx context.Context, at time.Time) (int, error)
+}
+
+//go:generate mockgen -destination=mocks/wishlist_service_mock.gen.go -package=mocks . WishlistService
+type WishlistService interface {
+	GetWishlist(ctx context.Context, username string) (Wishlist, error)
+	AddToWishlist(ctx context.Context, username, item string) error
+	RemoveFromWishlist(ctx context.Context, username, item string) error
+}
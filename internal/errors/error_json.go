@@ -1,5 +1,7 @@
 package errors
 
 type JSONError struct {
-	Err string `json:"error"`
+	Err       string `json:"error"`
+	Code      string `json:"code,omitempty"`
+	Remaining *int   `json:"remaining,omitempty"`
 }
@@ -3,14 +3,106 @@ package errors
 import "errors"
 
 var (
-	ErrAlreadyRegistered   = errors.New("user with this username is already registered")
-	ErrInsufficientBalance = errors.New("insufficient balance")
-	ErrItemNotFound        = errors.New("item not found")
-	ErrUnauthorized        = errors.New("unauthorized")
-	ErrUserNotFound        = errors.New("user not found")
-	ErrWrongPassword       = errors.New("wrong password provided")
-	ErrNoLoginOrPassword   = errors.New("no login or password provided")
-	ErrWrongAdminHeader    = errors.New("wrong admin header")
-	ErrWrongMIME           = errors.New("wrong MIME type used")
-	ErrWrongJSON           = errors.New("something is wrong in json")
+	ErrAlreadyRegistered      = errors.New("user with this username is already registered")
+	ErrInsufficientBalance    = errors.New("insufficient balance")
+	ErrItemNotFound           = errors.New("item not found")
+	ErrUnauthorized           = errors.New("unauthorized")
+	ErrUserNotFound           = errors.New("user not found")
+	ErrWrongPassword          = errors.New("wrong password provided")
+	ErrNoLoginOrPassword      = errors.New("no login or password provided")
+	ErrWrongAdminHeader       = errors.New("wrong admin header")
+	ErrWrongMIME              = errors.New("wrong MIME type used")
+	ErrWrongJSON              = errors.New("something is wrong in json")
+	ErrRequestTooLarge        = errors.New("request body too large")
+	ErrTooManyRequests        = errors.New("too many requests")
+	ErrDraining               = errors.New("service is shutting down")
+	ErrMigrationsDirty        = errors.New("database migrations are dirty")
+	ErrMigrationsOutdated     = errors.New("database migrations are not at the expected version")
+	ErrWrongCSV               = errors.New("something is wrong in csv")
+	ErrInvalidAmount          = errors.New("amount must be positive")
+	ErrReasonRequired         = errors.New("reason is required")
+	ErrSystemAccount          = errors.New("operation is not allowed for the system account")
+	ErrNoRecipients           = errors.New("no recipients provided")
+	ErrTooManyRecipients      = errors.New("too many recipients")
+	ErrMessageTooLong         = errors.New("message is too long")
+	ErrInvalidMessage         = errors.New("message contains invalid characters")
+	ErrUnknownCategory        = errors.New("unknown transfer category")
+	ErrInvalidPagination      = errors.New("limit and offset must be non-negative integers")
+	ErrInternal               = errors.New("internal server error")
+	ErrNotReady               = errors.New("service is not ready")
+	ErrTransferLimitExceeded  = errors.New("transfer amount exceeds the per-transfer limit")
+	ErrDailyLimitExceeded     = errors.New("transfer exceeds the daily outgoing limit")
+	ErrRecipientLimitExceeded = errors.New("transfer exceeds the daily limit for this recipient")
+	ErrAccountTooNew          = errors.New("account is too new to send coins")
+	ErrInvalidQuantity        = errors.New("invalid quantity")
+	ErrPurchaseNotFound       = errors.New("purchase not found")
+	ErrRefundWindowExpired    = errors.New("refund window has expired")
+	ErrRefundsDisabled        = errors.New("refunds are disabled")
+	ErrAlreadyRefunded        = errors.New("purchase is already fully refunded")
+	ErrRefundQuantityExceeded = errors.New("refund quantity exceeds the quantity left to refund")
+	ErrItemNotInInventory     = errors.New("item is no longer in the inventory")
+	ErrSelfGift               = errors.New("cannot gift an item to yourself")
+	ErrSelfTransfer           = errors.New("cannot transfer an item to yourself")
+	ErrInvalidPrice           = errors.New("price must be positive")
+	ErrListingNotFound        = errors.New("listing not found")
+	ErrListingClosed          = errors.New("listing is no longer available")
+	ErrOwnListing             = errors.New("cannot buy your own listing")
+	ErrRedemptionNotFound     = errors.New("redemption not found")
+	ErrDeliveryRequired       = errors.New("delivery office or note is required")
+	ErrInvalidStatus          = errors.New("unknown redemption status")
+	ErrStatusTransition       = errors.New("redemption cannot move to this status")
+	ErrInvalidPromoCode       = errors.New("promo code is invalid or expired")
+	ErrPromoCodeExhausted     = errors.New("promo code has no uses left")
+	ErrPromoUserLimit         = errors.New("promo code usage limit reached for this user")
+	ErrPromotionNotFound      = errors.New("promotion not found")
+	ErrInvalidPromotion       = errors.New("invalid promotion")
+	ErrPromoCodeTaken         = errors.New("promo code is already in use")
+	ErrVariantNotFound        = errors.New("variant not found")
+	ErrOutOfStock             = errors.New("variant is out of stock")
+	ErrInvalidCatalogItem     = errors.New("invalid catalog item")
+	ErrItemLimitReached       = errors.New("purchase limit for this item reached")
+	ErrItemPeriodLimitReached = errors.New("purchase limit for this item reached for the current period")
+	ErrNotEligible            = errors.New("not eligible to buy this item")
+	ErrInvalidItemRule        = errors.New("invalid item rule")
+	ErrItemRuleNotFound       = errors.New("item rule not found")
+	ErrInvalidRole            = errors.New("invalid role")
+	ErrWishlistFull           = errors.New("wishlist is full")
+	ErrNotInWishlist          = errors.New("item is not in the wishlist")
+	ErrTooManyNotificationIDs = errors.New("too many notification ids")
+	ErrInvalidEventID         = errors.New("last event id must be a non-negative integer")
+	ErrInvalidWebhook         = errors.New("invalid webhook")
+	ErrWebhookNotFound        = errors.New("webhook not found")
+	ErrOutboxEventNotFound    = errors.New("outbox event not found")
+	ErrInvalidDeliveryStatus  = errors.New("invalid delivery status")
+	ErrInvalidLeaderboard     = errors.New("invalid leaderboard")
 )
+
+// LimitError reports a policy violation together with the amount the user
+// may still use under the violated limit.
+type LimitError struct {
+	Err       error
+	Remaining int
+}
+
+func (e *LimitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LimitError) Unwrap() error {
+	return e.Err
+}
+
+// RuleError reports which per-item purchase rule rejected a purchase. Code
+// is returned to the client so it can tell the rules apart.
+type RuleError struct {
+	Err  error
+	Code string
+}
+
+func (e *RuleError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RuleError) Unwrap() error {
+	return e.Err
+}
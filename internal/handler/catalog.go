@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/pkg/logger"
+	"github.com/Te8va/MerchStore/pkg/validator"
+)
+
+type CatalogHandler struct {
+	srv domain.CatalogService
+}
+
+func NewCatalogHandler(srv domain.CatalogService) *CatalogHandler {
+	return &CatalogHandler{srv: srv}
+}
+
+func (h *CatalogHandler) ListCatalogHandler(w http.ResponseWriter, r *http.Request) {
+	items, err := h.srv.ListCatalog(r.Context(), r.URL.Query().Get("category"))
+	if err != nil {
+		h.writeCatalogError(w, r, err, "handlers.ListCatalogHandler:")
+		return
+	}
+
+	SendJSONResponse(w, items, http.StatusOK)
+}
+
+func (h *CatalogHandler) GetCatalogItemHandler(w http.ResponseWriter, r *http.Request) {
+	item, err := h.srv.GetCatalogItem(r.Context(), r.PathValue("item"))
+	if err != nil {
+		h.writeCatalogError(w, r, err, "handlers.GetCatalogItemHandler:")
+		return
+	}
+
+	SendJSONResponse(w, item, http.StatusOK)
+}
+
+func (h *CatalogHandler) UpdateCatalogItemHandler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Category    string `json:"category"`
+		Description string `json:"description"`
+		ImageURL    string `json:"imageUrl"`
+	}
+	if err := validator.ValidateJSONRequest(r, &req); err != nil {
+		WriteHTTPError(w, err, ValidationErrorStatus(err), "handlers.UpdateCatalogItemHandler:")
+		return
+	}
+
+	item, err := h.srv.UpdateCatalogItem(r.Context(), domain.MerchItem{
+		Name:        r.PathValue("item"),
+		Category:    req.Category,
+		Description: req.Description,
+		ImageURL:    req.ImageURL,
+	})
+	if err != nil {
+		h.writeCatalogError(w, r, err, "handlers.UpdateCatalogItemHandler:")
+		return
+	}
+
+	SendJSONResponse(w, item, http.StatusOK)
+}
+
+func (h *CatalogHandler) UpsertVariantHandler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Kind  string `json:"kind"`
+		Stock int    `json:"stock"`
+	}
+	if err := validator.ValidateJSONRequest(r, &req); err != nil {
+		WriteHTTPError(w, err, ValidationErrorStatus(err), "handlers.UpsertVariantHandler:")
+		return
+	}
+
+	variant, err := h.srv.UpsertVariant(r.Context(), r.PathValue("item"), domain.Variant{
+		Name:  r.PathValue("variant"),
+		Kind:  req.Kind,
+		Stock: req.Stock,
+	})
+	if err != nil {
+		h.writeCatalogError(w, r, err, "handlers.UpsertVariantHandler:")
+		return
+	}
+
+	SendJSONResponse(w, variant, http.StatusOK)
+}
+
+func (h *CatalogHandler) UpdatePriceHandler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Price int `json:"price"`
+	}
+	if err := validator.ValidateJSONRequest(r, &req); err != nil {
+		WriteHTTPError(w, err, ValidationErrorStatus(err), "handlers.UpdatePriceHandler:")
+		return
+	}
+
+	item, err := h.srv.UpdatePrice(r.Context(), r.PathValue("item"), req.Price)
+	if err != nil {
+		h.writeCatalogError(w, r, err, "handlers.UpdatePriceHandler:")
+		return
+	}
+
+	SendJSONResponse(w, item, http.StatusOK)
+}
+
+func (h *CatalogHandler) writeCatalogError(w http.ResponseWriter, r *http.Request, err error, prefix string) {
+	switch {
+	case errors.Is(err, appErrors.ErrItemNotFound):
+		WriteHTTPError(w, appErrors.ErrItemNotFound, http.StatusNotFound, prefix)
+	case errors.Is(err, appErrors.ErrInvalidCatalogItem),
+		errors.Is(err, appErrors.ErrInvalidPrice):
+		WriteHTTPError(w, err, http.StatusBadRequest, prefix)
+	default:
+		logger.FromContext(r.Context()).Error(prefix+" catalog request failed", zap.Error(err))
+		WriteHTTPError(w, appErrors.ErrInternal, http.StatusInternalServerError, prefix)
+	}
+}
@@ -0,0 +1,132 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/handler"
+	"github.com/Te8va/MerchStore/pkg/jwt"
+)
+
+func TestCatalogHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSrv := mocks.NewMockCatalogService(ctrl)
+	catalogHandler := handler.NewCatalogHandler(mockSrv)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/catalog", catalogHandler.ListCatalogHandler)
+	mux.HandleFunc("GET /api/catalog/{item}", catalogHandler.GetCatalogItemHandler)
+	mux.HandleFunc("PUT /api/admin/catalog/{item}", catalogHandler.UpdateCatalogItemHandler)
+	mux.HandleFunc("PUT /api/admin/catalog/{item}/variants/{variant}", catalogHandler.UpsertVariantHandler)
+	mux.HandleFunc("PUT /api/admin/catalog/{item}/price", catalogHandler.UpdatePriceHandler)
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// Каталог с фильтром по категории
+	mockSrv.EXPECT().ListCatalog(gomock.Any(), "clothing").Return([]domain.MerchItem{{
+		Name: "t-shirt", Price: 80, Category: "clothing",
+		Variants: []domain.Variant{{Name: "M", Kind: domain.VariantKindSize, Stock: 3}},
+	}}, nil)
+	rr := do(http.MethodGet, "/api/catalog?category=clothing", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"variants":[{"name":"M","kind":"size","stock":3}]`)
+
+	// Неизвестный товар
+	mockSrv.EXPECT().GetCatalogItem(gomock.Any(), "ghost").Return(domain.MerchItem{}, appErrors.ErrItemNotFound)
+	rr = do(http.MethodGet, "/api/catalog/ghost", "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	// Обновление описания товара
+	mockSrv.EXPECT().UpdateCatalogItem(gomock.Any(), domain.MerchItem{
+		Name: "hoody", Category: "clothing", Description: "Warm", ImageURL: "https://cdn.example.com/hoody.png",
+	}).Return(domain.MerchItem{Name: "hoody", Category: "clothing", Description: "Warm", ImageURL: "https://cdn.example.com/hoody.png"}, nil)
+	rr = do(http.MethodPut, "/api/admin/catalog/hoody",
+		`{"category":"clothing","description":"Warm","imageUrl":"https://cdn.example.com/hoody.png"}`)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"imageUrl":"https://cdn.example.com/hoody.png"`)
+
+	// Некорректный вариант
+	mockSrv.EXPECT().UpsertVariant(gomock.Any(), "hoody", domain.Variant{Name: "XXL", Kind: "weight", Stock: 5}).
+		Return(domain.Variant{}, appErrors.ErrInvalidCatalogItem)
+	rr = do(http.MethodPut, "/api/admin/catalog/hoody/variants/XXL", `{"kind":"weight","stock":5}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	// Снижение цены
+	mockSrv.EXPECT().UpdatePrice(gomock.Any(), "hoody", 250).Return(domain.MerchItem{Name: "hoody", Price: 250}, nil)
+	rr = do(http.MethodPut, "/api/admin/catalog/hoody/price", `{"price":250}`)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"price":250`)
+
+	// Некорректная цена
+	mockSrv.EXPECT().UpdatePrice(gomock.Any(), "hoody", -1).Return(domain.MerchItem{}, appErrors.ErrInvalidPrice)
+	rr = do(http.MethodPut, "/api/admin/catalog/hoody/price", `{"price":-1}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestBuyMerchHandlerVariant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSrv := mocks.NewMockMerchService(ctrl)
+	jwtKey := "test_jwt_key"
+	merchHandler := handler.NewMerchHandler(mockSrv, jwtKey)
+
+	token, err := jwt.CreateJWT("alice", []byte(jwtKey), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/buy/{item}", merchHandler.BuyMerchHandler)
+
+	do := func(target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// Покупка конкретного размера
+	mockSrv.EXPECT().BuyMerch(gomock.Any(), domain.PurchaseRequest{Username: "alice", Item: "t-shirt", Variant: "M", Quantity: 1}).
+		Return(domain.Purchase{ID: 6, Item: "t-shirt", Variant: "M", UnitPrice: 80, PaidPrice: 80, Quantity: 1}, nil)
+	rr := do("/api/buy/t-shirt?variant=M")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"variant":"M"`)
+
+	// Размер не указан - выбирается вариант с наибольшим остатком
+	mockSrv.EXPECT().BuyMerch(gomock.Any(), domain.PurchaseRequest{Username: "alice", Item: "t-shirt", Quantity: 1}).
+		Return(domain.Purchase{ID: 7, Item: "t-shirt", Variant: "L", UnitPrice: 80, PaidPrice: 80, Quantity: 1}, nil)
+	rr = do("/api/buy/t-shirt")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"variant":"L"`)
+
+	// Неизвестный размер
+	mockSrv.EXPECT().BuyMerch(gomock.Any(), domain.PurchaseRequest{Username: "alice", Item: "t-shirt", Variant: "XXS", Quantity: 1}).
+		Return(domain.Purchase{}, appErrors.ErrVariantNotFound)
+	rr = do("/api/buy/t-shirt?variant=XXS")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	// Размер закончился
+	mockSrv.EXPECT().BuyMerch(gomock.Any(), domain.PurchaseRequest{Username: "alice", Item: "t-shirt", Variant: "XL", Quantity: 1}).
+		Return(domain.Purchase{}, appErrors.ErrOutOfStock)
+	rr = do("/api/buy/t-shirt?variant=XL")
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
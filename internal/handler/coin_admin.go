@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/pkg/logger"
+	"github.com/Te8va/MerchStore/pkg/validator"
+)
+
+type CoinAdminHandler struct {
+	srv domain.CoinAdminService
+}
+
+func NewCoinAdminHandler(srv domain.CoinAdminService) *CoinAdminHandler {
+	return &CoinAdminHandler{srv: srv}
+}
+
+func (h *CoinAdminHandler) GrantHandler(w http.ResponseWriter, r *http.Request) {
+	var adjustment domain.CoinAdjustment
+	if err := validator.ValidateJSONRequest(r, &adjustment); err != nil {
+		WriteHTTPError(w, err, ValidationErrorStatus(err), "handlers.GrantHandler:")
+		return
+	}
+
+	if err := h.srv.Grant(r.Context(), adjustment); err != nil {
+		h.writeCoinAdminError(w, r, err, "handlers.GrantHandler:")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *CoinAdminHandler) DeductHandler(w http.ResponseWriter, r *http.Request) {
+	var adjustment domain.CoinAdjustment
+	if err := validator.ValidateJSONRequest(r, &adjustment); err != nil {
+		WriteHTTPError(w, err, ValidationErrorStatus(err), "handlers.DeductHandler:")
+		return
+	}
+
+	if err := h.srv.Deduct(r.Context(), adjustment); err != nil {
+		h.writeCoinAdminError(w, r, err, "handlers.DeductHandler:")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *CoinAdminHandler) AirdropHandler(w http.ResponseWriter, r *http.Request) {
+	var (
+		adjustments []domain.CoinAdjustment
+		err         error
+	)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "text/csv" {
+		adjustments, err = parseAirdropCSV(r)
+	} else {
+		adjustments, err = parseAirdropJSON(r)
+	}
+	if err != nil {
+		WriteHTTPError(w, err, ValidationErrorStatus(err), "handlers.AirdropHandler:")
+		return
+	}
+
+	summary, err := h.srv.Airdrop(r.Context(), adjustments)
+	if err != nil {
+		h.writeCoinAdminError(w, r, err, "handlers.AirdropHandler:")
+		return
+	}
+
+	SendJSONResponse(w, summary, http.StatusOK)
+}
+
+func parseAirdropJSON(r *http.Request) ([]domain.CoinAdjustment, error) {
+	var req struct {
+		Reason     string                  `json:"reason"`
+		Recipients []domain.CoinAdjustment `json:"recipients"`
+	}
+
+	if err := validator.ValidateJSONRequest(r, &req); err != nil {
+		return nil, err
+	}
+
+	for i := range req.Recipients {
+		if req.Recipients[i].Reason == "" {
+			req.Recipients[i].Reason = req.Reason
+		}
+	}
+
+	return req.Recipients, nil
+}
+
+// parseAirdropCSV reads "username,amount[,reason]" rows. A leading header row
+// is skipped and rows without a reason fall back to the reason query parameter.
+func parseAirdropCSV(r *http.Request) ([]domain.CoinAdjustment, error) {
+	reader := csv.NewReader(http.MaxBytesReader(nil, r.Body, validator.MaxBodyBytes))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	defaultReason := r.URL.Query().Get("reason")
+
+	var adjustments []domain.CoinAdjustment
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				return nil, appErrors.ErrRequestTooLarge
+			}
+			return nil, fmt.Errorf("%w: line %d", appErrors.ErrWrongCSV, line)
+		}
+
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "username") {
+			continue
+		}
+
+		if len(record) < 2 || len(record) > 3 {
+			return nil, fmt.Errorf("%w: line %d: expected username,amount[,reason]", appErrors.ErrWrongCSV, line)
+		}
+
+		amount, err := strconv.Atoi(strings.TrimSpace(record[1]))
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: invalid amount", appErrors.ErrWrongCSV, line)
+		}
+
+		adjustment := domain.CoinAdjustment{
+			Username: record[0],
+			Amount:   amount,
+			Reason:   defaultReason,
+		}
+		if len(record) == 3 && strings.TrimSpace(record[2]) != "" {
+			adjustment.Reason = record[2]
+		}
+
+		adjustments = append(adjustments, adjustment)
+	}
+
+	return adjustments, nil
+}
+
+func (h *CoinAdminHandler) writeCoinAdminError(w http.ResponseWriter, r *http.Request, err error, prefix string) {
+	switch {
+	case errors.Is(err, appErrors.ErrUserNotFound),
+		errors.Is(err, appErrors.ErrSystemAccount),
+		errors.Is(err, appErrors.ErrInvalidAmount),
+		errors.Is(err, appErrors.ErrReasonRequired),
+		errors.Is(err, appErrors.ErrNoRecipients),
+		errors.Is(err, appErrors.ErrTooManyRecipients):
+		WriteHTTPError(w, err, http.StatusBadRequest, prefix)
+	case errors.Is(err, appErrors.ErrInsufficientBalance):
+		WriteHTTPError(w, err, http.StatusConflict, prefix)
+	default:
+		logger.FromContext(r.Context()).Error(prefix+" coin adjustment failed", zap.Error(err))
+		WriteHTTPError(w, appErrors.ErrInternal, http.StatusInternalServerError, prefix)
+	}
+}
@@ -0,0 +1,97 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/handler"
+)
+
+func TestCoinAdminHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSrv := mocks.NewMockCoinAdminService(ctrl)
+	coinAdminHandler := handler.NewCoinAdminHandler(mockSrv)
+
+	newRequest := func(endpoint, contentType, body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		return req
+	}
+
+	// Начисление монет
+	mockSrv.EXPECT().Grant(gomock.Any(), domain.CoinAdjustment{Username: "alice", Amount: 100, Reason: "hackathon"}).Return(nil)
+	rr := httptest.NewRecorder()
+	coinAdminHandler.GrantHandler(rr, newRequest("/api/admin/coins/grant", "application/json",
+		`{"username":"alice","amount":100,"reason":"hackathon"}`))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// Списание больше баланса
+	mockSrv.EXPECT().Deduct(gomock.Any(), gomock.Any()).Return(appErrors.ErrInsufficientBalance)
+	rr = httptest.NewRecorder()
+	coinAdminHandler.DeductHandler(rr, newRequest("/api/admin/coins/deduct", "application/json",
+		`{"username":"alice","amount":5000,"reason":"mistake"}`))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	// Ошибка валидации сервиса
+	mockSrv.EXPECT().Grant(gomock.Any(), gomock.Any()).Return(appErrors.ErrReasonRequired)
+	rr = httptest.NewRecorder()
+	coinAdminHandler.GrantHandler(rr, newRequest("/api/admin/coins/grant", "application/json",
+		`{"username":"alice","amount":100}`))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), appErrors.ErrReasonRequired.Error())
+
+	// Airdrop из JSON: общая причина подставляется получателям без своей
+	mockSrv.EXPECT().Airdrop(gomock.Any(), []domain.CoinAdjustment{
+		{Username: "alice", Amount: 10, Reason: "new year"},
+		{Username: "bob", Amount: 20, Reason: "bonus"},
+	}).Return(domain.AirdropSummary{Recipients: 2, Total: 30}, nil)
+	rr = httptest.NewRecorder()
+	coinAdminHandler.AirdropHandler(rr, newRequest("/api/admin/coins/airdrop", "application/json",
+		`{"reason":"new year","recipients":[{"username":"alice","amount":10},{"username":"bob","amount":20,"reason":"bonus"}]}`))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"recipients":2,"total":30}`, rr.Body.String())
+
+	// Airdrop из CSV с заголовком
+	mockSrv.EXPECT().Airdrop(gomock.Any(), []domain.CoinAdjustment{
+		{Username: "alice", Amount: 10, Reason: "q1"},
+		{Username: "bob", Amount: 20, Reason: "top seller"},
+	}).Return(domain.AirdropSummary{Recipients: 2, Total: 30}, nil)
+	rr = httptest.NewRecorder()
+	coinAdminHandler.AirdropHandler(rr, newRequest("/api/admin/coins/airdrop?reason=q1", "text/csv; charset=utf-8",
+		"username,amount,reason\nalice,10\nbob,20,top seller\n"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// Некорректная сумма в CSV
+	rr = httptest.NewRecorder()
+	coinAdminHandler.AirdropHandler(rr, newRequest("/api/admin/coins/airdrop", "text/csv",
+		"alice,ten\n"))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), appErrors.ErrWrongCSV.Error())
+
+	// Неизвестные получатели
+	mockSrv.EXPECT().Airdrop(gomock.Any(), gomock.Any()).Return(domain.AirdropSummary{}, appErrors.ErrUserNotFound)
+	rr = httptest.NewRecorder()
+	coinAdminHandler.AirdropHandler(rr, newRequest("/api/admin/coins/airdrop", "text/csv",
+		"ghost,10,test\n"))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	// Внутренняя ошибка не раскрывается клиенту
+	mockSrv.EXPECT().Grant(gomock.Any(), gomock.Any()).Return(errors.New("repository.GrantCoins: connection refused"))
+	rr = httptest.NewRecorder()
+	coinAdminHandler.GrantHandler(rr, newRequest("/api/admin/coins/grant", "application/json",
+		`{"username":"alice","amount":100,"reason":"bonus"}`))
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Contains(t, rr.Body.String(), appErrors.ErrInternal.Error())
+	assert.NotContains(t, rr.Body.String(), "connection refused")
+}
@@ -2,18 +2,15 @@ package handler
 
 import (
 	"encoding/json"
+	stdErrors "errors"
 	"net/http"
 
 	"github.com/Te8va/MerchStore/internal/errors"
-	"github.com/Te8va/MerchStore/pkg/logger"
+	"github.com/Te8va/MerchStore/internal/httperr"
 )
 
 func WriteHTTPError(w http.ResponseWriter, err error, statusCode int, prefix string) {
-	w.Header().Add("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	if err := json.NewEncoder(w).Encode(errors.JSONError{Err: err.Error()}); err != nil {
-		logger.Logger().Errorln(prefix, err.Error())
-	}
+	httperr.Write(w, err, statusCode, prefix)
 }
 
 func SendJSONResponse(w http.ResponseWriter, data interface{}, statusCode int) {
@@ -23,3 +20,10 @@ func SendJSONResponse(w http.ResponseWriter, data interface{}, statusCode int) {
 		WriteHTTPError(w, err, http.StatusInternalServerError, "SendJSONResponse:")
 	}
 }
+
+func ValidationErrorStatus(err error) int {
+	if stdErrors.Is(err, errors.ErrRequestTooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/pkg"
+	"github.com/Te8va/MerchStore/pkg/logger"
+)
+
+type EventsHandler struct {
+	srv       domain.EventService
+	JWTKey    string
+	heartbeat time.Duration
+}
+
+func NewEventsHandler(srv domain.EventService, jwtKey string, heartbeat time.Duration) *EventsHandler {
+	return &EventsHandler{srv: srv, JWTKey: jwtKey, heartbeat: heartbeat}
+}
+
+// StreamHandler streams the user's events as Server-Sent Events. A client
+// that reconnects with Last-Event-ID gets every event it missed; a new client
+// only gets events created after it connected.
+func (h *EventsHandler) StreamHandler(w http.ResponseWriter, r *http.Request) {
+	username, err := pkg.ExtractUsernameFromRequest(r, h.JWTKey)
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrUnauthorized, http.StatusUnauthorized, "handlers.StreamHandler:")
+		return
+	}
+
+	lastID, err := parseLastEventID(r)
+	if err != nil {
+		WriteHTTPError(w, err, http.StatusBadRequest, "handlers.StreamHandler:")
+		return
+	}
+
+	wake, unsubscribe := h.srv.Subscribe(username)
+	defer unsubscribe()
+
+	if lastID < 0 {
+		lastID, err = h.srv.LatestEventID(r.Context(), username)
+		if err != nil {
+			logger.FromContext(r.Context()).Error("handlers.StreamHandler: could not get latest event", zap.Error(err))
+			WriteHTTPError(w, appErrors.ErrInternal, http.StatusInternalServerError, "handlers.StreamHandler:")
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	stream := &eventStream{w: w, rc: http.NewResponseController(w), timeout: 2 * h.heartbeat}
+	if err := stream.write(fmt.Sprintf("retry: %d\n\n", h.heartbeat.Milliseconds())); err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		lastID, err = h.sendEvents(r, stream, username, lastID)
+		if err != nil {
+			logger.FromContext(r.Context()).Info("handlers.StreamHandler: stream closed", zap.Error(err))
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-h.srv.Done():
+			return
+		case <-wake:
+		case <-ticker.C:
+			// The heartbeat keeps proxies from closing an idle stream and
+			// also catches events whose notification was lost.
+			if err := stream.write(": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (h *EventsHandler) sendEvents(r *http.Request, stream *eventStream, username string, lastID int64) (int64, error) {
+	for {
+		events, err := h.srv.ListEvents(r.Context(), username, lastID)
+		if err != nil {
+			return lastID, err
+		}
+
+		for _, event := range events {
+			data, err := json.Marshal(event)
+			if err != nil {
+				return lastID, err
+			}
+			if err := stream.write(fmt.Sprintf("id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Kind, data)); err != nil {
+				return lastID, err
+			}
+			lastID = event.ID
+		}
+
+		if len(events) < domain.EventBatchSize {
+			return lastID, nil
+		}
+	}
+}
+
+// parseLastEventID returns -1 when the client did not send an event ID. The
+// query parameter is for clients that cannot set headers on reconnect.
+func parseLastEventID(r *http.Request) (int64, error) {
+	raw := r.Header.Get("Last-Event-ID")
+	if raw == "" {
+		raw = r.URL.Query().Get("lastEventId")
+	}
+	if raw == "" {
+		return -1, nil
+	}
+
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id < 0 {
+		return 0, appErrors.ErrInvalidEventID
+	}
+	return id, nil
+}
+
+type eventStream struct {
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	timeout time.Duration
+}
+
+// write sends msg and flushes it. Every write gets its own deadline, so the
+// server write timeout does not cut the stream but a stuck client still does.
+func (s *eventStream) write(msg string) error {
+	if err := s.rc.SetWriteDeadline(time.Now().Add(s.timeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	if _, err := s.w.Write([]byte(msg)); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
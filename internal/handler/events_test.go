@@ -0,0 +1,74 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	"github.com/Te8va/MerchStore/internal/handler"
+	"github.com/Te8va/MerchStore/pkg/jwt"
+)
+
+func TestStreamHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSrv := mocks.NewMockEventService(ctrl)
+	jwtKey := "test_jwt_key"
+	eventsHandler := handler.NewEventsHandler(mockSrv, jwtKey, time.Minute)
+
+	token, err := jwt.CreateJWT("alice", []byte(jwtKey), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	// Закрытый канал завершает поток сразу после отправки пропущенных событий
+	done := make(chan struct{})
+	close(done)
+	mockSrv.EXPECT().Done().Return(done).AnyTimes()
+	mockSrv.EXPECT().Subscribe("alice").Return(make(chan struct{}), func() {}).AnyTimes()
+
+	do := func(lastEventID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/events", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
+		rr := httptest.NewRecorder()
+		eventsHandler.StreamHandler(rr, req)
+		return rr
+	}
+
+	// Переподключение с Last-Event-ID получает пропущенные события
+	mockSrv.EXPECT().ListEvents(gomock.Any(), "alice", int64(5)).Return([]domain.UserEvent{
+		{ID: 6, Kind: domain.EventTransfer, Data: json.RawMessage(`{"from":"bob","amount":50}`)},
+		{ID: 7, Kind: domain.EventBalance, Data: json.RawMessage(`{"balance":1050}`)},
+	}, nil)
+	rr := do("5")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/event-stream", rr.Header().Get("Content-Type"))
+	assert.Contains(t, rr.Body.String(), "id: 6\nevent: transfer\ndata: ")
+	assert.Contains(t, rr.Body.String(), `"data":{"balance":1050}`)
+
+	// Новый клиент начинает с последнего события
+	mockSrv.EXPECT().LatestEventID(gomock.Any(), "alice").Return(int64(9), nil)
+	mockSrv.EXPECT().ListEvents(gomock.Any(), "alice", int64(9)).Return([]domain.UserEvent{}, nil)
+	rr = do("")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.NotContains(t, rr.Body.String(), "id:")
+
+	// Некорректный Last-Event-ID
+	rr = do("abc")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	// Без токена
+	req := httptest.NewRequest(http.MethodGet, "/api/events", nil)
+	rr = httptest.NewRecorder()
+	eventsHandler.StreamHandler(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
+
+	"go.uber.org/zap"
 
 	"github.com/Te8va/MerchStore/internal/domain"
 	appErrors "github.com/Te8va/MerchStore/internal/errors"
@@ -31,11 +34,17 @@ func (h *MerchHandler) SendCoinHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req struct {
-		ToUser string `json:"toUser"`
-		Amount int    `json:"amount"`
+		ToUser   string `json:"toUser"`
+		Amount   int    `json:"amount"`
+		Message  string `json:"message"`
+		Category string `json:"category"`
 	}
 
 	if err := validator.ValidateJSONRequest(r, &req); err != nil {
+		if errors.Is(err, appErrors.ErrRequestTooLarge) {
+			WriteHTTPError(w, err, http.StatusRequestEntityTooLarge, "handlers.SendCoinHandler:")
+			return
+		}
 		http.Error(w, "Invalid request", http.StatusBadRequest)
 		return
 	}
@@ -45,13 +54,28 @@ func (h *MerchHandler) SendCoinHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.srv.SendCoin(r.Context(), fromUser, req.ToUser, req.Amount)
+	err = h.srv.SendCoin(r.Context(), domain.Transfer{
+		FromUser: fromUser,
+		ToUser:   req.ToUser,
+		Amount:   req.Amount,
+		Message:  req.Message,
+		Category: req.Category,
+	})
 	if err != nil {
 		switch {
-		case errors.Is(err, appErrors.ErrUserNotFound):
+		case errors.Is(err, appErrors.ErrUserNotFound), errors.Is(err, appErrors.ErrSystemAccount):
 			http.Error(w, "Receiver not found", http.StatusBadRequest)
 		case errors.Is(err, appErrors.ErrInsufficientBalance):
 			http.Error(w, "Insufficient balance", http.StatusBadRequest)
+		case errors.Is(err, appErrors.ErrTransferLimitExceeded),
+			errors.Is(err, appErrors.ErrDailyLimitExceeded),
+			errors.Is(err, appErrors.ErrRecipientLimitExceeded),
+			errors.Is(err, appErrors.ErrAccountTooNew):
+			WriteHTTPError(w, err, http.StatusBadRequest, "handlers.SendCoinHandler:")
+		case errors.Is(err, appErrors.ErrMessageTooLong),
+			errors.Is(err, appErrors.ErrInvalidMessage),
+			errors.Is(err, appErrors.ErrUnknownCategory):
+			http.Error(w, err.Error(), http.StatusBadRequest)
 		default:
 			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		}
@@ -100,11 +124,44 @@ func (h *MerchHandler) GetUserInfoHandler(w http.ResponseWriter, r *http.Request
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(response); err != nil {
-		logger.Logger().Errorln("GetUserInfoHandler: failed to encode response:", err)
+		logger.FromContext(r.Context()).Error("GetUserInfoHandler: failed to encode response", zap.Error(err))
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 	}
 }
 
+func (h *MerchHandler) GetUserHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	username, err := pkg.ExtractUsernameFromRequest(r, h.JWTKey)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	limit, offset, err := parsePagination(r)
+	if err != nil {
+		WriteHTTPError(w, err, http.StatusBadRequest, "handlers.GetUserHistoryHandler:")
+		return
+	}
+
+	filter := domain.HistoryFilter{
+		Category: r.URL.Query().Get("category"),
+		Limit:    limit,
+		Offset:   offset,
+	}
+
+	history, err := h.srv.GetUserHistory(r.Context(), username, filter)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrUnknownCategory) {
+			WriteHTTPError(w, err, http.StatusBadRequest, "handlers.GetUserHistoryHandler:")
+			return
+		}
+		logger.FromContext(r.Context()).Error("GetUserHistoryHandler: failed to get history", zap.Error(err))
+		WriteHTTPError(w, appErrors.ErrInternal, http.StatusInternalServerError, "handlers.GetUserHistoryHandler:")
+		return
+	}
+
+	SendJSONResponse(w, history, http.StatusOK)
+}
+
 func (h *MerchHandler) BuyMerchHandler(w http.ResponseWriter, r *http.Request) {
 	username, err := pkg.ExtractUsernameFromRequest(r, h.JWTKey)
 	if err != nil {
@@ -118,12 +175,39 @@ func (h *MerchHandler) BuyMerchHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.srv.BuyMerch(r.Context(), username, item)
+	quantity := 1
+	if raw := r.URL.Query().Get("quantity"); raw != "" {
+		quantity, err = strconv.Atoi(raw)
+		if err != nil {
+			http.Error(w, "Invalid quantity", http.StatusBadRequest)
+			return
+		}
+	}
+
+	purchase, err := h.srv.BuyMerch(r.Context(), domain.PurchaseRequest{
+		Username: username,
+		Item:     item,
+		Variant:  r.URL.Query().Get("variant"),
+		Quantity: quantity,
+		Promo:    r.URL.Query().Get("promo"),
+	})
 	if err != nil {
 		switch {
+		case errors.Is(err, appErrors.ErrInvalidPromoCode),
+			errors.Is(err, appErrors.ErrPromoCodeExhausted),
+			errors.Is(err, appErrors.ErrPromoUserLimit),
+			errors.Is(err, appErrors.ErrVariantNotFound):
+			WriteHTTPError(w, err, http.StatusBadRequest, "handlers.BuyMerchHandler:")
+		case errors.Is(err, appErrors.ErrOutOfStock):
+			WriteHTTPError(w, err, http.StatusConflict, "handlers.BuyMerchHandler:")
+		case errors.Is(err, appErrors.ErrItemLimitReached),
+			errors.Is(err, appErrors.ErrItemPeriodLimitReached),
+			errors.Is(err, appErrors.ErrNotEligible):
+			WriteHTTPError(w, err, http.StatusForbidden, "handlers.BuyMerchHandler:")
 		case errors.Is(err, appErrors.ErrInsufficientBalance),
 			errors.Is(err, appErrors.ErrItemNotFound),
-			errors.Is(err, appErrors.ErrUserNotFound):
+			errors.Is(err, appErrors.ErrUserNotFound),
+			errors.Is(err, appErrors.ErrInvalidQuantity):
 			http.Error(w, "Bad Request", http.StatusBadRequest)
 		default:
 			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
@@ -131,6 +215,49 @@ func (h *MerchHandler) BuyMerchHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	SendJSONResponse(w, purchase, http.StatusOK)
+}
+
+func (h *MerchHandler) GiftHandler(w http.ResponseWriter, r *http.Request) {
+	fromUser, err := pkg.ExtractUsernameFromRequest(r, h.JWTKey)
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrUnauthorized, http.StatusUnauthorized, "handlers.GiftHandler:")
+		return
+	}
+
+	var gift domain.Gift
+	if err := validator.ValidateJSONRequest(r, &gift); err != nil {
+		WriteHTTPError(w, err, ValidationErrorStatus(err), "handlers.GiftHandler:")
+		return
+	}
+	gift.FromUser = fromUser
+
+	err = h.srv.GiftMerch(r.Context(), gift)
+	if err != nil {
+		switch {
+		case errors.Is(err, appErrors.ErrUserNotFound),
+			errors.Is(err, appErrors.ErrItemNotFound),
+			errors.Is(err, appErrors.ErrInsufficientBalance),
+			errors.Is(err, appErrors.ErrInvalidQuantity),
+			errors.Is(err, appErrors.ErrSystemAccount),
+			errors.Is(err, appErrors.ErrSelfGift),
+			errors.Is(err, appErrors.ErrMessageTooLong),
+			errors.Is(err, appErrors.ErrInvalidMessage),
+			errors.Is(err, appErrors.ErrVariantNotFound):
+			WriteHTTPError(w, err, http.StatusBadRequest, "handlers.GiftHandler:")
+		case errors.Is(err, appErrors.ErrOutOfStock):
+			WriteHTTPError(w, err, http.StatusConflict, "handlers.GiftHandler:")
+		case errors.Is(err, appErrors.ErrItemLimitReached),
+			errors.Is(err, appErrors.ErrItemPeriodLimitReached),
+			errors.Is(err, appErrors.ErrNotEligible):
+			WriteHTTPError(w, err, http.StatusForbidden, "handlers.GiftHandler:")
+		default:
+			logger.FromContext(r.Context()).Error("GiftHandler: failed to send gift", zap.Error(err))
+			WriteHTTPError(w, appErrors.ErrInternal, http.StatusInternalServerError, "handlers.GiftHandler:")
+		}
+		return
+	}
+
 	w.WriteHeader(http.StatusOK)
 }
 
@@ -146,7 +273,7 @@ func (h *AuthorizationHandler) AuthHandler(w http.ResponseWriter, r *http.Reques
 	var authData domain.AuthorizationData
 
 	if err := validator.ValidateJSONRequest(r, &authData); err != nil {
-		WriteHTTPError(w, err, http.StatusBadRequest, "handlers.AuthHandler:")
+		WriteHTTPError(w, err, ValidationErrorStatus(err), "handlers.AuthHandler:")
 		return
 	}
 
@@ -155,12 +282,20 @@ func (h *AuthorizationHandler) AuthHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if info := pkg.RequestInfoFromContext(r.Context()); info != nil {
+		info.User = authData.Username
+	}
+
 	token, err := h.srv.RegisterOrAuthenticate(r.Context(), authData.Username, authData.Password)
 	if err != nil {
 		if errors.Is(err, appErrors.ErrWrongPassword) {
 			WriteHTTPError(w, appErrors.ErrWrongPassword, http.StatusUnauthorized, "handlers.AuthHandler:")
 			return
 		}
+		if errors.Is(err, appErrors.ErrSystemAccount) {
+			WriteHTTPError(w, appErrors.ErrSystemAccount, http.StatusBadRequest, "handlers.AuthHandler:")
+			return
+		}
 
 		WriteHTTPError(w, err, http.StatusInternalServerError, "handlers.AuthHandler:")
 		return
@@ -169,7 +304,7 @@ func (h *AuthorizationHandler) AuthHandler(w http.ResponseWriter, r *http.Reques
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(domain.Token{Token: token}); err != nil {
-		logger.Logger().Errorln("AuthHandler: failed to encode token:", err)
+		logger.FromContext(r.Context()).Error("AuthHandler: failed to encode token", zap.Error(err))
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -29,7 +30,7 @@ func TestSendCoinHandler_BusinessScenarios(t *testing.T) {
 	jwtKey := "test_jwt_key"
 
 	merchHandler := handler.NewMerchHandler(mockMerchSrv, jwtKey)
-	authService := service.NewAuthorization(mockAuthRepo, jwtKey)
+	authService := service.NewAuthorization(mockAuthRepo, jwtKey, 1000)
 	authHandler := handler.NewAuthorizationHandler(authService)
 
 	senderToken, err := jwt.CreateJWT("sender", []byte(jwtKey), time.Now().Add(24*time.Hour))
@@ -72,7 +73,7 @@ func TestSendCoinHandler_BusinessScenarios(t *testing.T) {
 	mockAuthRepo.EXPECT().GetUserByUsername(gomock.Any(), "unknown").Return(nil, appErrors.ErrUserNotFound).AnyTimes()
 
 	// Успешная отправка монет
-	mockMerchSrv.EXPECT().SendCoin(gomock.Any(), "sender", "receiver", 10).Return(nil)
+	mockMerchSrv.EXPECT().SendCoin(gomock.Any(), domain.Transfer{FromUser: "sender", ToUser: "receiver", Amount: 10}).Return(nil)
 	rr := httptest.NewRecorder()
 	reqBody := map[string]interface{}{
 		"toUser": "receiver",
@@ -86,7 +87,7 @@ func TestSendCoinHandler_BusinessScenarios(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rr.Code)
 
 	// Ошибка: отправка монет без регистрации получателя
-	mockMerchSrv.EXPECT().SendCoin(gomock.Any(), "sender", "unknown", 10).Return(appErrors.ErrUserNotFound)
+	mockMerchSrv.EXPECT().SendCoin(gomock.Any(), domain.Transfer{FromUser: "sender", ToUser: "unknown", Amount: 10}).Return(appErrors.ErrUserNotFound)
 	rr = httptest.NewRecorder()
 	reqBody = map[string]interface{}{
 		"toUser": "unknown",
@@ -113,7 +114,7 @@ func TestSendCoinHandler_BusinessScenarios(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, rr.Code)
 
 	// Ошибка: недостаточно средств
-	mockMerchSrv.EXPECT().SendCoin(gomock.Any(), "sender", "receiver", 9999).Return(appErrors.ErrInsufficientBalance)
+	mockMerchSrv.EXPECT().SendCoin(gomock.Any(), domain.Transfer{FromUser: "sender", ToUser: "receiver", Amount: 9999}).Return(appErrors.ErrInsufficientBalance)
 	rr = httptest.NewRecorder()
 	reqBody = map[string]interface{}{
 		"toUser": "receiver",
@@ -125,4 +126,149 @@ func TestSendCoinHandler_BusinessScenarios(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	merchHandler.SendCoinHandler(rr, req)
 	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	// Перевод с сообщением и категорией
+	mockMerchSrv.EXPECT().SendCoin(gomock.Any(), domain.Transfer{
+		FromUser: "sender",
+		ToUser:   "receiver",
+		Amount:   5,
+		Message:  "thanks for the code review",
+		Category: "thanks",
+	}).Return(nil)
+	rr = httptest.NewRecorder()
+	reqBody = map[string]interface{}{
+		"toUser":   "receiver",
+		"amount":   5,
+		"message":  "thanks for the code review",
+		"category": "thanks",
+	}
+	body, _ = json.Marshal(reqBody)
+	req, _ = http.NewRequest(http.MethodPost, "/api/send-coin", bytes.NewBuffer(body))
+	req.Header.Set("Authorization", "Bearer "+senderToken)
+	req.Header.Set("Content-Type", "application/json")
+	merchHandler.SendCoinHandler(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// Ошибка: слишком длинное сообщение
+	mockMerchSrv.EXPECT().SendCoin(gomock.Any(), gomock.Any()).Return(appErrors.ErrMessageTooLong)
+	rr = httptest.NewRecorder()
+	reqBody = map[string]interface{}{
+		"toUser":  "receiver",
+		"amount":  5,
+		"message": strings.Repeat("a", domain.MaxTransferMessageLength+1),
+	}
+	body, _ = json.Marshal(reqBody)
+	req, _ = http.NewRequest(http.MethodPost, "/api/send-coin", bytes.NewBuffer(body))
+	req.Header.Set("Authorization", "Bearer "+senderToken)
+	req.Header.Set("Content-Type", "application/json")
+	merchHandler.SendCoinHandler(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), appErrors.ErrMessageTooLong.Error())
+
+	// Ошибка: превышен дневной лимит, в ответе остаток
+	mockMerchSrv.EXPECT().SendCoin(gomock.Any(), gomock.Any()).
+		Return(&appErrors.LimitError{Err: appErrors.ErrDailyLimitExceeded, Remaining: 40})
+	rr = httptest.NewRecorder()
+	reqBody = map[string]interface{}{
+		"toUser": "receiver",
+		"amount": 50,
+	}
+	body, _ = json.Marshal(reqBody)
+	req, _ = http.NewRequest(http.MethodPost, "/api/send-coin", bytes.NewBuffer(body))
+	req.Header.Set("Authorization", "Bearer "+senderToken)
+	req.Header.Set("Content-Type", "application/json")
+	merchHandler.SendCoinHandler(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.JSONEq(t, `{"error":"transfer exceeds the daily outgoing limit","remaining":40}`, rr.Body.String())
+}
+
+func TestGetUserHistoryHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMerchSrv := mocks.NewMockMerchService(ctrl)
+	jwtKey := "test_jwt_key"
+	merchHandler := handler.NewMerchHandler(mockMerchSrv, jwtKey)
+
+	token, err := jwt.CreateJWT("alice", []byte(jwtKey), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	newRequest := func(target string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		return req
+	}
+
+	// История с фильтром по категории и пагинацией
+	mockMerchSrv.EXPECT().GetUserHistory(gomock.Any(), "alice", domain.HistoryFilter{Category: "thanks", Limit: 10, Offset: 20}).
+		Return([]domain.HistoryEntry{{ID: 1, Kind: domain.TransactionKindTransfer, Direction: domain.DirectionIncoming, FromUser: "bob", ToUser: "alice", Amount: 5, Message: "thanks", Category: "thanks"}}, nil)
+	rr := httptest.NewRecorder()
+	merchHandler.GetUserHistoryHandler(rr, newRequest("/api/history?category=thanks&limit=10&offset=20"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"message":"thanks"`)
+
+	// Лимит ограничивается максимальным значением
+	mockMerchSrv.EXPECT().GetUserHistory(gomock.Any(), "alice", domain.HistoryFilter{Limit: handler.MaxPageLimit}).Return([]domain.HistoryEntry{}, nil)
+	rr = httptest.NewRecorder()
+	merchHandler.GetUserHistoryHandler(rr, newRequest("/api/history?limit=1000"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// Некорректная пагинация
+	rr = httptest.NewRecorder()
+	merchHandler.GetUserHistoryHandler(rr, newRequest("/api/history?offset=-1"))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	// Неизвестная категория
+	mockMerchSrv.EXPECT().GetUserHistory(gomock.Any(), "alice", gomock.Any()).Return(nil, appErrors.ErrUnknownCategory)
+	rr = httptest.NewRecorder()
+	merchHandler.GetUserHistoryHandler(rr, newRequest("/api/history?category=bribes"))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestGiftHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMerchSrv := mocks.NewMockMerchService(ctrl)
+	jwtKey := "test_jwt_key"
+	merchHandler := handler.NewMerchHandler(mockMerchSrv, jwtKey)
+
+	token, err := jwt.CreateJWT("alice", []byte(jwtKey), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/api/gift", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	// Подарок с запиской, отправитель берётся из токена
+	mockMerchSrv.EXPECT().GiftMerch(gomock.Any(), domain.Gift{FromUser: "alice", ToUser: "bob", Item: "cup", Quantity: 2, Message: "congrats"}).Return(nil)
+	rr := httptest.NewRecorder()
+	merchHandler.GiftHandler(rr, newRequest(`{"toUser":"bob","item":"cup","quantity":2,"message":"congrats"}`))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// Ошибка: подарок самому себе
+	mockMerchSrv.EXPECT().GiftMerch(gomock.Any(), gomock.Any()).Return(appErrors.ErrSelfGift)
+	rr = httptest.NewRecorder()
+	merchHandler.GiftHandler(rr, newRequest(`{"toUser":"alice","item":"cup"}`))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), appErrors.ErrSelfGift.Error())
+
+	// Ошибка: нельзя подменить отправителя полем запроса
+	mockMerchSrv.EXPECT().GiftMerch(gomock.Any(), domain.Gift{FromUser: "alice", ToUser: "bob", Item: "cup"}).Return(nil)
+	rr = httptest.NewRecorder()
+	merchHandler.GiftHandler(rr, newRequest(`{"fromUser":"bob","toUser":"bob","item":"cup"}`))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// Ошибка: получатель уже исчерпал лимит по товару
+	mockMerchSrv.EXPECT().GiftMerch(gomock.Any(), gomock.Any()).Return(&appErrors.RuleError{
+		Err:  &appErrors.LimitError{Err: appErrors.ErrItemLimitReached},
+		Code: domain.RuleMaxPerUser,
+	})
+	rr = httptest.NewRecorder()
+	merchHandler.GiftHandler(rr, newRequest(`{"toUser":"bob","item":"pink-hoody"}`))
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.Contains(t, rr.Body.String(), domain.RuleMaxPerUser)
 }
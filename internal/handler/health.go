@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/pkg/logger"
+)
+
+const healthCheckTimeout = 3 * time.Second
+
+type HealthHandler struct {
+	srv domain.HealthService
+}
+
+func NewHealthHandler(srv domain.HealthService) *HealthHandler {
+	return &HealthHandler{srv: srv}
+}
+
+func (h *HealthHandler) LivenessHandler(w http.ResponseWriter, r *http.Request) {
+	SendJSONResponse(w, map[string]string{"status": domain.StatusUp}, http.StatusOK)
+}
+
+func (h *HealthHandler) ReadinessHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+	defer cancel()
+
+	// The probe is unauthenticated, so the cause is only logged; details are
+	// on the admin health report.
+	if err := h.srv.Ready(ctx); err != nil {
+		logger.FromContext(r.Context()).Warn("handlers.ReadinessHandler: not ready", zap.Error(err))
+		WriteHTTPError(w, appErrors.ErrNotReady, http.StatusServiceUnavailable, "handlers.ReadinessHandler:")
+		return
+	}
+
+	SendJSONResponse(w, map[string]string{"status": domain.StatusUp}, http.StatusOK)
+}
+
+func (h *HealthHandler) HealthReportHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+	defer cancel()
+
+	report := h.srv.Report(ctx)
+
+	statusCode := http.StatusOK
+	if report.Status != domain.StatusUp {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	SendJSONResponse(w, report, statusCode)
+}
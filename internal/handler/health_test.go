@@ -0,0 +1,48 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/handler"
+)
+
+func TestHealthHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHealthSrv := mocks.NewMockHealthService(ctrl)
+	healthHandler := handler.NewHealthHandler(mockHealthSrv)
+
+	// Liveness не зависит от внешних сервисов
+	rr := httptest.NewRecorder()
+	healthHandler.LivenessHandler(rr, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// Готовность
+	mockHealthSrv.EXPECT().Ready(gomock.Any()).Return(nil)
+	rr = httptest.NewRecorder()
+	healthHandler.ReadinessHandler(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// Во время остановки сервис не готов
+	mockHealthSrv.EXPECT().Ready(gomock.Any()).Return(appErrors.ErrDraining)
+	rr = httptest.NewRecorder()
+	healthHandler.ReadinessHandler(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	assert.Contains(t, rr.Body.String(), appErrors.ErrNotReady.Error())
+	assert.NotContains(t, rr.Body.String(), appErrors.ErrDraining.Error())
+
+	// Подробный отчёт
+	mockHealthSrv.EXPECT().Report(gomock.Any()).Return(domain.HealthReport{Status: domain.StatusDown})
+	rr = httptest.NewRecorder()
+	healthHandler.HealthReportHandler(rr, httptest.NewRequest(http.MethodGet, "/api/admin/health", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/pkg/logger"
+	"github.com/Te8va/MerchStore/pkg/validator"
+)
+
+type ItemRuleHandler struct {
+	srv domain.ItemRuleService
+}
+
+func NewItemRuleHandler(srv domain.ItemRuleService) *ItemRuleHandler {
+	return &ItemRuleHandler{srv: srv}
+}
+
+func (h *ItemRuleHandler) ListItemRulesHandler(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.srv.ListItemRules(r.Context())
+	if err != nil {
+		h.writeItemRuleError(w, r, err, "handlers.ListItemRulesHandler:")
+		return
+	}
+
+	SendJSONResponse(w, rules, http.StatusOK)
+}
+
+func (h *ItemRuleHandler) SaveItemRuleHandler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		MaxPerUser            int    `json:"maxPerUser"`
+		MaxPerPeriod          int    `json:"maxPerPeriod"`
+		Period                string `json:"period"`
+		MinAccountAgeDays     int    `json:"minAccountAgeDays"`
+		RequiredRole          string `json:"requiredRole"`
+		RequiresReceivedCoins bool   `json:"requiresReceivedCoins"`
+	}
+	if err := validator.ValidateJSONRequest(r, &req); err != nil {
+		WriteHTTPError(w, err, ValidationErrorStatus(err), "handlers.SaveItemRuleHandler:")
+		return
+	}
+
+	rule, err := h.srv.SaveItemRule(r.Context(), domain.ItemRule{
+		Item:                  r.PathValue("item"),
+		MaxPerUser:            req.MaxPerUser,
+		MaxPerPeriod:          req.MaxPerPeriod,
+		Period:                req.Period,
+		MinAccountAgeDays:     req.MinAccountAgeDays,
+		RequiredRole:          req.RequiredRole,
+		RequiresReceivedCoins: req.RequiresReceivedCoins,
+	})
+	if err != nil {
+		h.writeItemRuleError(w, r, err, "handlers.SaveItemRuleHandler:")
+		return
+	}
+
+	SendJSONResponse(w, rule, http.StatusOK)
+}
+
+func (h *ItemRuleHandler) DeleteItemRuleHandler(w http.ResponseWriter, r *http.Request) {
+	if err := h.srv.DeleteItemRule(r.Context(), r.PathValue("item")); err != nil {
+		h.writeItemRuleError(w, r, err, "handlers.DeleteItemRuleHandler:")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ItemRuleHandler) SetUserRoleHandler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Role string `json:"role"`
+	}
+	if err := validator.ValidateJSONRequest(r, &req); err != nil {
+		WriteHTTPError(w, err, ValidationErrorStatus(err), "handlers.SetUserRoleHandler:")
+		return
+	}
+
+	username := r.PathValue("username")
+	if err := h.srv.SetUserRole(r.Context(), username, req.Role); err != nil {
+		h.writeItemRuleError(w, r, err, "handlers.SetUserRoleHandler:")
+		return
+	}
+
+	SendJSONResponse(w, map[string]string{"username": username, "role": req.Role}, http.StatusOK)
+}
+
+func (h *ItemRuleHandler) writeItemRuleError(w http.ResponseWriter, r *http.Request, err error, prefix string) {
+	switch {
+	case errors.Is(err, appErrors.ErrItemNotFound):
+		WriteHTTPError(w, appErrors.ErrItemNotFound, http.StatusNotFound, prefix)
+	case errors.Is(err, appErrors.ErrItemRuleNotFound):
+		WriteHTTPError(w, appErrors.ErrItemRuleNotFound, http.StatusNotFound, prefix)
+	case errors.Is(err, appErrors.ErrUserNotFound):
+		WriteHTTPError(w, appErrors.ErrUserNotFound, http.StatusNotFound, prefix)
+	case errors.Is(err, appErrors.ErrInvalidItemRule),
+		errors.Is(err, appErrors.ErrInvalidRole),
+		errors.Is(err, appErrors.ErrSystemAccount):
+		WriteHTTPError(w, err, http.StatusBadRequest, prefix)
+	default:
+		logger.FromContext(r.Context()).Error(prefix+" item rule request failed", zap.Error(err))
+		WriteHTTPError(w, appErrors.ErrInternal, http.StatusInternalServerError, prefix)
+	}
+}
@@ -0,0 +1,118 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/handler"
+	"github.com/Te8va/MerchStore/pkg/jwt"
+)
+
+func TestItemRuleHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSrv := mocks.NewMockItemRuleService(ctrl)
+	itemRuleHandler := handler.NewItemRuleHandler(mockSrv)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/admin/item-rules", itemRuleHandler.ListItemRulesHandler)
+	mux.HandleFunc("PUT /api/admin/item-rules/{item}", itemRuleHandler.SaveItemRuleHandler)
+	mux.HandleFunc("DELETE /api/admin/item-rules/{item}", itemRuleHandler.DeleteItemRuleHandler)
+	mux.HandleFunc("PUT /api/admin/users/{username}/role", itemRuleHandler.SetUserRoleHandler)
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// Сохранение правила
+	mockSrv.EXPECT().SaveItemRule(gomock.Any(), domain.ItemRule{Item: "pink-hoody", MaxPerUser: 1, RequiresReceivedCoins: true}).
+		Return(domain.ItemRule{Item: "pink-hoody", MaxPerUser: 1, Period: domain.PeriodMonthly, RequiresReceivedCoins: true}, nil)
+	rr := do(http.MethodPut, "/api/admin/item-rules/pink-hoody", `{"maxPerUser":1,"requiresReceivedCoins":true}`)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"maxPerUser":1`)
+
+	// Неизвестный товар
+	mockSrv.EXPECT().SaveItemRule(gomock.Any(), gomock.Any()).Return(domain.ItemRule{}, appErrors.ErrItemNotFound)
+	rr = do(http.MethodPut, "/api/admin/item-rules/ghost", `{"maxPerUser":1}`)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	// Некорректное правило
+	mockSrv.EXPECT().SaveItemRule(gomock.Any(), gomock.Any()).Return(domain.ItemRule{}, appErrors.ErrInvalidItemRule)
+	rr = do(http.MethodPut, "/api/admin/item-rules/cup", `{"period":"yearly"}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	// Список правил
+	mockSrv.EXPECT().ListItemRules(gomock.Any()).Return([]domain.ItemRule{{Item: "pink-hoody"}}, nil)
+	rr = do(http.MethodGet, "/api/admin/item-rules", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// Удаление правила
+	mockSrv.EXPECT().DeleteItemRule(gomock.Any(), "pink-hoody").Return(nil)
+	rr = do(http.MethodDelete, "/api/admin/item-rules/pink-hoody", "")
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+
+	// Назначение роли
+	mockSrv.EXPECT().SetUserRole(gomock.Any(), "alice", "designer").Return(nil)
+	rr = do(http.MethodPut, "/api/admin/users/alice/role", `{"role":"designer"}`)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	mockSrv.EXPECT().SetUserRole(gomock.Any(), "ghost", "designer").Return(appErrors.ErrUserNotFound)
+	rr = do(http.MethodPut, "/api/admin/users/ghost/role", `{"role":"designer"}`)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestBuyMerchHandlerItemRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSrv := mocks.NewMockMerchService(ctrl)
+	jwtKey := "test_jwt_key"
+	merchHandler := handler.NewMerchHandler(mockSrv, jwtKey)
+
+	token, err := jwt.CreateJWT("alice", []byte(jwtKey), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/buy/{item}", merchHandler.BuyMerchHandler)
+
+	do := func(target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// Лимит на пользователя возвращает код правила и остаток
+	mockSrv.EXPECT().BuyMerch(gomock.Any(), domain.PurchaseRequest{Username: "alice", Item: "pink-hoody", Quantity: 1}).
+		Return(domain.Purchase{}, &appErrors.RuleError{
+			Code: domain.RuleMaxPerUser,
+			Err:  &appErrors.LimitError{Err: appErrors.ErrItemLimitReached, Remaining: 0},
+		})
+	rr := do("/api/buy/pink-hoody")
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.JSONEq(t, `{"error":"purchase limit for this item reached","code":"item_max_per_user","remaining":0}`, rr.Body.String())
+
+	// Пользователь не подходит под условия
+	mockSrv.EXPECT().BuyMerch(gomock.Any(), domain.PurchaseRequest{Username: "alice", Item: "pink-hoody", Quantity: 1}).
+		Return(domain.Purchase{}, &appErrors.RuleError{Code: domain.RuleReceivedCoinsRequired, Err: appErrors.ErrNotEligible})
+	rr = do("/api/buy/pink-hoody")
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"code":"item_received_coins_required"`)
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/pkg"
+	"github.com/Te8va/MerchStore/pkg/logger"
+	"github.com/Te8va/MerchStore/pkg/validator"
+)
+
+const defaultLeaderboardLimit = 10
+
+type LeaderboardHandler struct {
+	srv    domain.LeaderboardService
+	JWTKey string
+}
+
+func NewLeaderboardHandler(srv domain.LeaderboardService, jwtKey string) *LeaderboardHandler {
+	return &LeaderboardHandler{srv: srv, JWTKey: jwtKey}
+}
+
+func (h *LeaderboardHandler) GetLeaderboardHandler(w http.ResponseWriter, r *http.Request) {
+	if _, err := pkg.ExtractUsernameFromRequest(r, h.JWTKey); err != nil {
+		WriteHTTPError(w, appErrors.ErrUnauthorized, http.StatusUnauthorized, "handlers.GetLeaderboardHandler:")
+		return
+	}
+
+	query := r.URL.Query()
+	board, period := query.Get("board"), query.Get("period")
+	if board == "" {
+		board = domain.BoardReceived
+	}
+	if period == "" {
+		period = domain.LeaderboardWeek
+	}
+
+	limit := defaultLeaderboardLimit
+	if raw := query.Get("limit"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value <= 0 {
+			WriteHTTPError(w, appErrors.ErrInvalidPagination, http.StatusBadRequest, "handlers.GetLeaderboardHandler:")
+			return
+		}
+		limit = min(value, domain.LeaderboardSize)
+	}
+
+	leaderboard, err := h.srv.GetLeaderboard(r.Context(), board, period, limit)
+	if err != nil {
+		h.writeLeaderboardError(w, r, err, "handlers.GetLeaderboardHandler:")
+		return
+	}
+
+	SendJSONResponse(w, leaderboard, http.StatusOK)
+}
+
+func (h *LeaderboardHandler) SetOptOutHandler(w http.ResponseWriter, r *http.Request) {
+	username, err := pkg.ExtractUsernameFromRequest(r, h.JWTKey)
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrUnauthorized, http.StatusUnauthorized, "handlers.SetOptOutHandler:")
+		return
+	}
+
+	var req struct {
+		OptOut bool `json:"optOut"`
+	}
+	if err := validator.ValidateJSONRequest(r, &req); err != nil {
+		WriteHTTPError(w, err, ValidationErrorStatus(err), "handlers.SetOptOutHandler:")
+		return
+	}
+
+	if err := h.srv.SetOptOut(r.Context(), username, req.OptOut); err != nil {
+		h.writeLeaderboardError(w, r, err, "handlers.SetOptOutHandler:")
+		return
+	}
+
+	SendJSONResponse(w, map[string]bool{"optOut": req.OptOut}, http.StatusOK)
+}
+
+func (h *LeaderboardHandler) writeLeaderboardError(w http.ResponseWriter, r *http.Request, err error, prefix string) {
+	switch {
+	case errors.Is(err, appErrors.ErrInvalidLeaderboard):
+		WriteHTTPError(w, err, http.StatusBadRequest, prefix)
+	case errors.Is(err, appErrors.ErrUserNotFound):
+		WriteHTTPError(w, appErrors.ErrUserNotFound, http.StatusNotFound, prefix)
+	default:
+		logger.FromContext(r.Context()).Error(prefix+" leaderboard request failed", zap.Error(err))
+		WriteHTTPError(w, appErrors.ErrInternal, http.StatusInternalServerError, prefix)
+	}
+}
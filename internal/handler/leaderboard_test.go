@@ -0,0 +1,76 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/handler"
+	"github.com/Te8va/MerchStore/pkg/jwt"
+)
+
+func TestLeaderboardHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSrv := mocks.NewMockLeaderboardService(ctrl)
+	jwtKey := "test_jwt_key"
+	leaderboardHandler := handler.NewLeaderboardHandler(mockSrv, jwtKey)
+
+	token, err := jwt.CreateJWT("alice", []byte(jwtKey), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/leaderboard", leaderboardHandler.GetLeaderboardHandler)
+	mux.HandleFunc("PUT /api/leaderboard/opt-out", leaderboardHandler.SetOptOutHandler)
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// По умолчанию - получатели за текущую неделю
+	mockSrv.EXPECT().GetLeaderboard(gomock.Any(), domain.BoardReceived, domain.LeaderboardWeek, 10).
+		Return(domain.Leaderboard{Board: domain.BoardReceived, Period: domain.LeaderboardWeek, Entries: []domain.LeaderboardEntry{
+			{Rank: 1, Username: "bob", Amount: 500},
+		}}, nil)
+	rr := do(http.MethodGet, "/api/leaderboard", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `{"rank":1,"username":"bob","amount":500}`)
+
+	// Лимит ограничен размером доски
+	mockSrv.EXPECT().GetLeaderboard(gomock.Any(), domain.BoardSpent, domain.LeaderboardAllTime, domain.LeaderboardSize).
+		Return(domain.Leaderboard{Entries: []domain.LeaderboardEntry{}}, nil)
+	rr = do(http.MethodGet, "/api/leaderboard?board=spent&period=all&limit=1000", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// Неизвестная доска
+	mockSrv.EXPECT().GetLeaderboard(gomock.Any(), "richest", domain.LeaderboardWeek, 10).
+		Return(domain.Leaderboard{}, appErrors.ErrInvalidLeaderboard)
+	rr = do(http.MethodGet, "/api/leaderboard?board=richest", "")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	// Некорректный лимит
+	rr = do(http.MethodGet, "/api/leaderboard?limit=-1", "")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	// Отказ от участия в рейтингах
+	mockSrv.EXPECT().SetOptOut(gomock.Any(), "alice", true).Return(nil)
+	rr = do(http.MethodPut, "/api/leaderboard/opt-out", `{"optOut":true}`)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"optOut":true}`, rr.Body.String())
+}
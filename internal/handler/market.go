@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/pkg"
+	"github.com/Te8va/MerchStore/pkg/logger"
+	"github.com/Te8va/MerchStore/pkg/validator"
+)
+
+type MarketHandler struct {
+	srv    domain.MarketService
+	JWTKey string
+}
+
+func NewMarketHandler(srv domain.MarketService, jwtKey string) *MarketHandler {
+	return &MarketHandler{srv: srv, JWTKey: jwtKey}
+}
+
+func (h *MarketHandler) TransferItemHandler(w http.ResponseWriter, r *http.Request) {
+	fromUser, err := pkg.ExtractUsernameFromRequest(r, h.JWTKey)
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrUnauthorized, http.StatusUnauthorized, "handlers.TransferItemHandler:")
+		return
+	}
+
+	var transfer domain.ItemTransfer
+	if err := validator.ValidateJSONRequest(r, &transfer); err != nil {
+		WriteHTTPError(w, err, ValidationErrorStatus(err), "handlers.TransferItemHandler:")
+		return
+	}
+	transfer.FromUser = fromUser
+
+	if err := h.srv.TransferItem(r.Context(), transfer); err != nil {
+		h.writeMarketError(w, r, err, "handlers.TransferItemHandler:")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *MarketHandler) ListListingsHandler(w http.ResponseWriter, r *http.Request) {
+	if _, err := pkg.ExtractUsernameFromRequest(r, h.JWTKey); err != nil {
+		WriteHTTPError(w, appErrors.ErrUnauthorized, http.StatusUnauthorized, "handlers.ListListingsHandler:")
+		return
+	}
+
+	limit, offset, err := parsePagination(r)
+	if err != nil {
+		WriteHTTPError(w, err, http.StatusBadRequest, "handlers.ListListingsHandler:")
+		return
+	}
+
+	query := r.URL.Query()
+	listings, err := h.srv.ListListings(r.Context(), domain.ListingFilter{
+		Item:   query.Get("item"),
+		Seller: query.Get("seller"),
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		logger.FromContext(r.Context()).Error("ListListingsHandler: failed to list listings", zap.Error(err))
+		WriteHTTPError(w, appErrors.ErrInternal, http.StatusInternalServerError, "handlers.ListListingsHandler:")
+		return
+	}
+
+	SendJSONResponse(w, listings, http.StatusOK)
+}
+
+func (h *MarketHandler) CreateListingHandler(w http.ResponseWriter, r *http.Request) {
+	seller, err := pkg.ExtractUsernameFromRequest(r, h.JWTKey)
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrUnauthorized, http.StatusUnauthorized, "handlers.CreateListingHandler:")
+		return
+	}
+
+	var req struct {
+		Item     string `json:"item"`
+		Variant  string `json:"variant"`
+		Quantity int    `json:"quantity"`
+		Price    int    `json:"price"`
+	}
+	if err := validator.ValidateJSONRequest(r, &req); err != nil {
+		WriteHTTPError(w, err, ValidationErrorStatus(err), "handlers.CreateListingHandler:")
+		return
+	}
+
+	listing, err := h.srv.CreateListing(r.Context(), domain.Listing{
+		Seller:   seller,
+		Item:     req.Item,
+		Variant:  req.Variant,
+		Quantity: req.Quantity,
+		Price:    req.Price,
+	})
+	if err != nil {
+		h.writeMarketError(w, r, err, "handlers.CreateListingHandler:")
+		return
+	}
+
+	SendJSONResponse(w, listing, http.StatusCreated)
+}
+
+func (h *MarketHandler) CancelListingHandler(w http.ResponseWriter, r *http.Request) {
+	seller, err := pkg.ExtractUsernameFromRequest(r, h.JWTKey)
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrUnauthorized, http.StatusUnauthorized, "handlers.CancelListingHandler:")
+		return
+	}
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrListingNotFound, http.StatusNotFound, "handlers.CancelListingHandler:")
+		return
+	}
+
+	listing, err := h.srv.CancelListing(r.Context(), id, seller)
+	if err != nil {
+		h.writeMarketError(w, r, err, "handlers.CancelListingHandler:")
+		return
+	}
+
+	SendJSONResponse(w, listing, http.StatusOK)
+}
+
+func (h *MarketHandler) BuyListingHandler(w http.ResponseWriter, r *http.Request) {
+	buyer, err := pkg.ExtractUsernameFromRequest(r, h.JWTKey)
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrUnauthorized, http.StatusUnauthorized, "handlers.BuyListingHandler:")
+		return
+	}
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrListingNotFound, http.StatusNotFound, "handlers.BuyListingHandler:")
+		return
+	}
+
+	listing, err := h.srv.BuyListing(r.Context(), id, buyer)
+	if err != nil {
+		h.writeMarketError(w, r, err, "handlers.BuyListingHandler:")
+		return
+	}
+
+	SendJSONResponse(w, listing, http.StatusOK)
+}
+
+func (h *MarketHandler) writeMarketError(w http.ResponseWriter, r *http.Request, err error, prefix string) {
+	switch {
+	case errors.Is(err, appErrors.ErrListingNotFound):
+		WriteHTTPError(w, appErrors.ErrListingNotFound, http.StatusNotFound, prefix)
+	case errors.Is(err, appErrors.ErrListingClosed),
+		errors.Is(err, appErrors.ErrItemNotInInventory):
+		WriteHTTPError(w, err, http.StatusConflict, prefix)
+	case errors.Is(err, appErrors.ErrItemLimitReached),
+		errors.Is(err, appErrors.ErrItemPeriodLimitReached),
+		errors.Is(err, appErrors.ErrNotEligible):
+		WriteHTTPError(w, err, http.StatusForbidden, prefix)
+	case errors.Is(err, appErrors.ErrUserNotFound),
+		errors.Is(err, appErrors.ErrItemNotFound),
+		errors.Is(err, appErrors.ErrInsufficientBalance),
+		errors.Is(err, appErrors.ErrTransferLimitExceeded),
+		errors.Is(err, appErrors.ErrDailyLimitExceeded),
+		errors.Is(err, appErrors.ErrRecipientLimitExceeded),
+		errors.Is(err, appErrors.ErrInvalidQuantity),
+		errors.Is(err, appErrors.ErrInvalidPrice),
+		errors.Is(err, appErrors.ErrSystemAccount),
+		errors.Is(err, appErrors.ErrSelfTransfer),
+		errors.Is(err, appErrors.ErrOwnListing),
+		errors.Is(err, appErrors.ErrMessageTooLong),
+		errors.Is(err, appErrors.ErrInvalidMessage):
+		WriteHTTPError(w, err, http.StatusBadRequest, prefix)
+	default:
+		logger.FromContext(r.Context()).Error(prefix+" market operation failed", zap.Error(err))
+		WriteHTTPError(w, appErrors.ErrInternal, http.StatusInternalServerError, prefix)
+	}
+}
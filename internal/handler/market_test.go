@@ -0,0 +1,113 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/handler"
+	"github.com/Te8va/MerchStore/pkg/jwt"
+)
+
+func TestMarketHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSrv := mocks.NewMockMarketService(ctrl)
+	jwtKey := "test_jwt_key"
+	marketHandler := handler.NewMarketHandler(mockSrv, jwtKey)
+
+	token, err := jwt.CreateJWT("alice", []byte(jwtKey), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/inventory/transfer", marketHandler.TransferItemHandler)
+	mux.HandleFunc("GET /api/market/listings", marketHandler.ListListingsHandler)
+	mux.HandleFunc("POST /api/market/listings", marketHandler.CreateListingHandler)
+	mux.HandleFunc("DELETE /api/market/listings/{id}", marketHandler.CancelListingHandler)
+	mux.HandleFunc("POST /api/market/listings/{id}/buy", marketHandler.BuyListingHandler)
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// Передача товара другому пользователю
+	mockSrv.EXPECT().TransferItem(gomock.Any(), domain.ItemTransfer{FromUser: "alice", ToUser: "bob", Item: "cup", Quantity: 2}).Return(nil)
+	rr := do(http.MethodPost, "/api/inventory/transfer", `{"toUser":"bob","item":"cup","quantity":2}`)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// Товара нет в инвентаре
+	mockSrv.EXPECT().TransferItem(gomock.Any(), gomock.Any()).Return(appErrors.ErrItemNotInInventory)
+	rr = do(http.MethodPost, "/api/inventory/transfer", `{"toUser":"bob","item":"cup"}`)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	// Список лотов с фильтром
+	mockSrv.EXPECT().ListListings(gomock.Any(), domain.ListingFilter{Item: "cup", Limit: handler.DefaultPageLimit}).
+		Return([]domain.Listing{{ID: 3, Seller: "bob", Item: "cup", Quantity: 1, Price: 50, Status: domain.ListingStatusOpen}}, nil)
+	rr = do(http.MethodGet, "/api/market/listings?item=cup", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"seller":"bob"`)
+
+	// Выставление лота
+	mockSrv.EXPECT().CreateListing(gomock.Any(), domain.Listing{Seller: "alice", Item: "cup", Quantity: 1, Price: 50}).
+		Return(domain.Listing{ID: 4, Seller: "alice", Item: "cup", Quantity: 1, Price: 50, Status: domain.ListingStatusOpen}, nil)
+	rr = do(http.MethodPost, "/api/market/listings", `{"item":"cup","quantity":1,"price":50}`)
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	// Некорректная цена
+	mockSrv.EXPECT().CreateListing(gomock.Any(), domain.Listing{Seller: "alice", Item: "cup", Quantity: 1, Price: -5}).Return(domain.Listing{}, appErrors.ErrInvalidPrice)
+	rr = do(http.MethodPost, "/api/market/listings", `{"item":"cup","quantity":1,"price":-5}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	// Снятие чужого лота
+	mockSrv.EXPECT().CancelListing(gomock.Any(), 3, "alice").Return(domain.Listing{}, appErrors.ErrListingNotFound)
+	rr = do(http.MethodDelete, "/api/market/listings/3", "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	// Покупка лота
+	mockSrv.EXPECT().BuyListing(gomock.Any(), 3, "alice").
+		Return(domain.Listing{ID: 3, Seller: "bob", Item: "cup", Quantity: 1, Price: 50, Status: domain.ListingStatusSold, Buyer: "alice"}, nil)
+	rr = do(http.MethodPost, "/api/market/listings/3/buy", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"status":"sold"`)
+
+	// Лот уже продан
+	mockSrv.EXPECT().BuyListing(gomock.Any(), 3, "alice").Return(domain.Listing{}, appErrors.ErrListingClosed)
+	rr = do(http.MethodPost, "/api/market/listings/3/buy", "")
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	// Цена лота превышает дневной лимит переводов
+	mockSrv.EXPECT().BuyListing(gomock.Any(), 3, "alice").
+		Return(domain.Listing{}, &appErrors.LimitError{Err: appErrors.ErrDailyLimitExceeded, Remaining: 20})
+	rr = do(http.MethodPost, "/api/market/listings/3/buy", "")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"remaining":20`)
+
+	// Покупатель уже исчерпал лимит по товару
+	mockSrv.EXPECT().BuyListing(gomock.Any(), 3, "alice").Return(domain.Listing{}, &appErrors.RuleError{
+		Err:  &appErrors.LimitError{Err: appErrors.ErrItemLimitReached},
+		Code: domain.RuleMaxPerUser,
+	})
+	rr = do(http.MethodPost, "/api/market/listings/3/buy", "")
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.Contains(t, rr.Body.String(), domain.RuleMaxPerUser)
+
+	// Некорректный идентификатор
+	rr = do(http.MethodPost, "/api/market/listings/abc/buy", "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
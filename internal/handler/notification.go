@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/pkg"
+	"github.com/Te8va/MerchStore/pkg/logger"
+	"github.com/Te8va/MerchStore/pkg/validator"
+)
+
+type NotificationHandler struct {
+	srv    domain.NotificationService
+	JWTKey string
+}
+
+func NewNotificationHandler(srv domain.NotificationService, jwtKey string) *NotificationHandler {
+	return &NotificationHandler{srv: srv, JWTKey: jwtKey}
+}
+
+func (h *NotificationHandler) ListNotificationsHandler(w http.ResponseWriter, r *http.Request) {
+	username, err := pkg.ExtractUsernameFromRequest(r, h.JWTKey)
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrUnauthorized, http.StatusUnauthorized, "handlers.ListNotificationsHandler:")
+		return
+	}
+
+	limit, offset, err := parsePagination(r)
+	if err != nil {
+		WriteHTTPError(w, err, http.StatusBadRequest, "handlers.ListNotificationsHandler:")
+		return
+	}
+
+	unreadOnly, _ := strconv.ParseBool(r.URL.Query().Get("unread"))
+
+	inbox, err := h.srv.GetInbox(r.Context(), username, domain.NotificationFilter{UnreadOnly: unreadOnly, Limit: limit, Offset: offset})
+	if err != nil {
+		h.writeNotificationError(w, r, err, "handlers.ListNotificationsHandler:")
+		return
+	}
+
+	SendJSONResponse(w, inbox, http.StatusOK)
+}
+
+func (h *NotificationHandler) MarkReadHandler(w http.ResponseWriter, r *http.Request) {
+	username, err := pkg.ExtractUsernameFromRequest(r, h.JWTKey)
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrUnauthorized, http.StatusUnauthorized, "handlers.MarkReadHandler:")
+		return
+	}
+
+	var req struct {
+		IDs []int64 `json:"ids"`
+	}
+	if r.ContentLength != 0 {
+		if err := validator.ValidateJSONRequest(r, &req); err != nil {
+			WriteHTTPError(w, err, ValidationErrorStatus(err), "handlers.MarkReadHandler:")
+			return
+		}
+	}
+
+	unread, err := h.srv.MarkRead(r.Context(), username, req.IDs)
+	if err != nil {
+		h.writeNotificationError(w, r, err, "handlers.MarkReadHandler:")
+		return
+	}
+
+	SendJSONResponse(w, map[string]int{"unread": unread}, http.StatusOK)
+}
+
+func (h *NotificationHandler) writeNotificationError(w http.ResponseWriter, r *http.Request, err error, prefix string) {
+	switch {
+	case errors.Is(err, appErrors.ErrTooManyNotificationIDs):
+		WriteHTTPError(w, err, http.StatusBadRequest, prefix)
+	default:
+		logger.FromContext(r.Context()).Error(prefix+" notification request failed", zap.Error(err))
+		WriteHTTPError(w, appErrors.ErrInternal, http.StatusInternalServerError, prefix)
+	}
+}
@@ -0,0 +1,77 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/handler"
+	"github.com/Te8va/MerchStore/pkg/jwt"
+)
+
+func TestNotificationHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSrv := mocks.NewMockNotificationService(ctrl)
+	jwtKey := "test_jwt_key"
+	notificationHandler := handler.NewNotificationHandler(mockSrv, jwtKey)
+
+	token, err := jwt.CreateJWT("alice", []byte(jwtKey), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/notifications", notificationHandler.ListNotificationsHandler)
+	mux.HandleFunc("POST /api/notifications/read", notificationHandler.MarkReadHandler)
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// Непрочитанные уведомления
+	mockSrv.EXPECT().GetInbox(gomock.Any(), "alice", domain.NotificationFilter{UnreadOnly: true, Limit: handler.DefaultPageLimit}).
+		Return(domain.Inbox{Unread: 1, Notifications: []domain.Notification{
+			{ID: 7, Kind: domain.NotificationCoinsReceived, Message: "bob sent you 50 coins"},
+		}}, nil)
+	rr := do(http.MethodGet, "/api/notifications?unread=true", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"unread":1`)
+	assert.Contains(t, rr.Body.String(), `"kind":"coins_received"`)
+
+	// Отметить выбранные уведомления прочитанными
+	mockSrv.EXPECT().MarkRead(gomock.Any(), "alice", []int64{7}).Return(0, nil)
+	rr = do(http.MethodPost, "/api/notifications/read", `{"ids":[7]}`)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"unread":0}`, rr.Body.String())
+
+	// Без тела отмечаются все уведомления
+	mockSrv.EXPECT().MarkRead(gomock.Any(), "alice", []int64(nil)).Return(0, nil)
+	rr = do(http.MethodPost, "/api/notifications/read", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// Слишком много идентификаторов
+	mockSrv.EXPECT().MarkRead(gomock.Any(), "alice", gomock.Any()).Return(0, appErrors.ErrTooManyNotificationIDs)
+	rr = do(http.MethodPost, "/api/notifications/read", `{"ids":[1,2,3]}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	// Без токена
+	req := httptest.NewRequest(http.MethodGet, "/api/notifications", nil)
+	rr = httptest.NewRecorder()
+	mux.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
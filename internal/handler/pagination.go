@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 100
+)
+
+// parsePagination reads the limit and offset query parameters. A missing
+// limit falls back to DefaultPageLimit and larger limits are capped at
+// MaxPageLimit.
+func parsePagination(r *http.Request) (int, int, error) {
+	limit, offset := DefaultPageLimit, 0
+	query := r.URL.Query()
+
+	if raw := query.Get("limit"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value <= 0 {
+			return 0, 0, appErrors.ErrInvalidPagination
+		}
+		limit = min(value, MaxPageLimit)
+	}
+
+	if raw := query.Get("offset"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 0 {
+			return 0, 0, appErrors.ErrInvalidPagination
+		}
+		offset = value
+	}
+
+	return limit, offset, nil
+}
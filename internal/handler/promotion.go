@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/pkg/logger"
+	"github.com/Te8va/MerchStore/pkg/validator"
+)
+
+type PromotionHandler struct {
+	srv domain.PromotionService
+}
+
+func NewPromotionHandler(srv domain.PromotionService) *PromotionHandler {
+	return &PromotionHandler{srv: srv}
+}
+
+func (h *PromotionHandler) CreatePromotionHandler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name          string     `json:"name"`
+		Item          string     `json:"item"`
+		Category      string     `json:"category"`
+		DiscountType  string     `json:"discountType"`
+		DiscountValue int        `json:"discountValue"`
+		Code          string     `json:"code"`
+		MaxUses       int        `json:"maxUses"`
+		PerUserLimit  int        `json:"perUserLimit"`
+		StartsAt      time.Time  `json:"startsAt"`
+		EndsAt        *time.Time `json:"endsAt"`
+	}
+	if err := validator.ValidateJSONRequest(r, &req); err != nil {
+		WriteHTTPError(w, err, ValidationErrorStatus(err), "handlers.CreatePromotionHandler:")
+		return
+	}
+
+	promotion, err := h.srv.CreatePromotion(r.Context(), domain.Promotion{
+		Name:          req.Name,
+		Item:          req.Item,
+		Category:      req.Category,
+		DiscountType:  req.DiscountType,
+		DiscountValue: req.DiscountValue,
+		Code:          req.Code,
+		MaxUses:       req.MaxUses,
+		PerUserLimit:  req.PerUserLimit,
+		StartsAt:      req.StartsAt,
+		EndsAt:        req.EndsAt,
+	})
+	if err != nil {
+		h.writePromotionError(w, r, err, "handlers.CreatePromotionHandler:")
+		return
+	}
+
+	SendJSONResponse(w, promotion, http.StatusCreated)
+}
+
+func (h *PromotionHandler) ListPromotionsHandler(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := parsePagination(r)
+	if err != nil {
+		WriteHTTPError(w, err, http.StatusBadRequest, "handlers.ListPromotionsHandler:")
+		return
+	}
+
+	promotions, err := h.srv.ListPromotions(r.Context(), limit, offset)
+	if err != nil {
+		h.writePromotionError(w, r, err, "handlers.ListPromotionsHandler:")
+		return
+	}
+
+	SendJSONResponse(w, promotions, http.StatusOK)
+}
+
+func (h *PromotionHandler) EndPromotionHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrPromotionNotFound, http.StatusNotFound, "handlers.EndPromotionHandler:")
+		return
+	}
+
+	promotion, err := h.srv.EndPromotion(r.Context(), id)
+	if err != nil {
+		h.writePromotionError(w, r, err, "handlers.EndPromotionHandler:")
+		return
+	}
+
+	SendJSONResponse(w, promotion, http.StatusOK)
+}
+
+func (h *PromotionHandler) writePromotionError(w http.ResponseWriter, r *http.Request, err error, prefix string) {
+	switch {
+	case errors.Is(err, appErrors.ErrPromotionNotFound):
+		WriteHTTPError(w, appErrors.ErrPromotionNotFound, http.StatusNotFound, prefix)
+	case errors.Is(err, appErrors.ErrPromoCodeTaken):
+		WriteHTTPError(w, err, http.StatusConflict, prefix)
+	case errors.Is(err, appErrors.ErrInvalidPromotion):
+		WriteHTTPError(w, err, http.StatusBadRequest, prefix)
+	default:
+		logger.FromContext(r.Context()).Error(prefix+" promotion request failed", zap.Error(err))
+		WriteHTTPError(w, appErrors.ErrInternal, http.StatusInternalServerError, prefix)
+	}
+}
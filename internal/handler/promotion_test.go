@@ -0,0 +1,107 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/handler"
+	"github.com/Te8va/MerchStore/pkg/jwt"
+)
+
+func TestPromotionHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSrv := mocks.NewMockPromotionService(ctrl)
+	promotionHandler := handler.NewPromotionHandler(mockSrv)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/admin/promotions", promotionHandler.ListPromotionsHandler)
+	mux.HandleFunc("POST /api/admin/promotions", promotionHandler.CreatePromotionHandler)
+	mux.HandleFunc("DELETE /api/admin/promotions/{id}", promotionHandler.EndPromotionHandler)
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// Создание промокода
+	mockSrv.EXPECT().CreatePromotion(gomock.Any(), domain.Promotion{
+		Name: "welcome", Item: "hoody", DiscountType: domain.DiscountFixed, DiscountValue: 50, Code: "hello", PerUserLimit: 1,
+	}).Return(domain.Promotion{ID: 2, Name: "welcome", Code: "HELLO"}, nil)
+	rr := do(http.MethodPost, "/api/admin/promotions",
+		`{"name":"welcome","item":"hoody","discountType":"fixed","discountValue":50,"code":"hello","perUserLimit":1}`)
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"code":"HELLO"`)
+
+	// Код уже занят
+	mockSrv.EXPECT().CreatePromotion(gomock.Any(), gomock.Any()).Return(domain.Promotion{}, appErrors.ErrPromoCodeTaken)
+	rr = do(http.MethodPost, "/api/admin/promotions", `{"name":"again","item":"hoody","discountType":"fixed","discountValue":5,"code":"hello"}`)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	// Некорректная акция
+	mockSrv.EXPECT().CreatePromotion(gomock.Any(), gomock.Any()).Return(domain.Promotion{}, appErrors.ErrInvalidPromotion)
+	rr = do(http.MethodPost, "/api/admin/promotions", `{"name":"bad","discountType":"fixed","discountValue":5}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	// Список акций
+	mockSrv.EXPECT().ListPromotions(gomock.Any(), handler.DefaultPageLimit, 0).Return([]domain.Promotion{{ID: 2}}, nil)
+	rr = do(http.MethodGet, "/api/admin/promotions", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// Досрочное завершение
+	mockSrv.EXPECT().EndPromotion(gomock.Any(), 9).Return(domain.Promotion{}, appErrors.ErrPromotionNotFound)
+	rr = do(http.MethodDelete, "/api/admin/promotions/9", "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestBuyMerchHandlerPromo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSrv := mocks.NewMockMerchService(ctrl)
+	jwtKey := "test_jwt_key"
+	merchHandler := handler.NewMerchHandler(mockSrv, jwtKey)
+
+	token, err := jwt.CreateJWT("alice", []byte(jwtKey), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/buy/{item}", merchHandler.BuyMerchHandler)
+
+	do := func(target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// Покупка с промокодом возвращает уплаченную цену и скидку
+	mockSrv.EXPECT().BuyMerch(gomock.Any(), domain.PurchaseRequest{Username: "alice", Item: "hoody", Quantity: 1, Promo: "HELLO"}).
+		Return(domain.Purchase{ID: 5, Item: "hoody", UnitPrice: 300, PaidPrice: 250, Discount: 50, PromoCode: "HELLO", Quantity: 1}, nil)
+	rr := do("/api/buy/hoody?promo=HELLO")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"paidPrice":250`)
+	assert.Contains(t, rr.Body.String(), `"discount":50`)
+
+	// Промокод исчерпан
+	mockSrv.EXPECT().BuyMerch(gomock.Any(), domain.PurchaseRequest{Username: "alice", Item: "hoody", Quantity: 1, Promo: "HELLO"}).Return(domain.Purchase{}, appErrors.ErrPromoCodeExhausted)
+	rr = do("/api/buy/hoody?promo=HELLO")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), appErrors.ErrPromoCodeExhausted.Error())
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/pkg"
+	"github.com/Te8va/MerchStore/pkg/logger"
+	"github.com/Te8va/MerchStore/pkg/validator"
+)
+
+type PurchaseHandler struct {
+	srv    domain.PurchaseService
+	JWTKey string
+}
+
+func NewPurchaseHandler(srv domain.PurchaseService, jwtKey string) *PurchaseHandler {
+	return &PurchaseHandler{srv: srv, JWTKey: jwtKey}
+}
+
+func (h *PurchaseHandler) ListPurchasesHandler(w http.ResponseWriter, r *http.Request) {
+	username, err := pkg.ExtractUsernameFromRequest(r, h.JWTKey)
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrUnauthorized, http.StatusUnauthorized, "handlers.ListPurchasesHandler:")
+		return
+	}
+
+	limit, offset, err := parsePagination(r)
+	if err != nil {
+		WriteHTTPError(w, err, http.StatusBadRequest, "handlers.ListPurchasesHandler:")
+		return
+	}
+
+	purchases, err := h.srv.ListPurchases(r.Context(), username, limit, offset)
+	if err != nil {
+		logger.FromContext(r.Context()).Error("ListPurchasesHandler: failed to list purchases", zap.Error(err))
+		WriteHTTPError(w, appErrors.ErrInternal, http.StatusInternalServerError, "handlers.ListPurchasesHandler:")
+		return
+	}
+
+	SendJSONResponse(w, purchases, http.StatusOK)
+}
+
+func (h *PurchaseHandler) RefundHandler(w http.ResponseWriter, r *http.Request) {
+	username, err := pkg.ExtractUsernameFromRequest(r, h.JWTKey)
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrUnauthorized, http.StatusUnauthorized, "handlers.RefundHandler:")
+		return
+	}
+
+	purchaseID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrPurchaseNotFound, http.StatusNotFound, "handlers.RefundHandler:")
+		return
+	}
+
+	var req struct {
+		Quantity int `json:"quantity"`
+	}
+	if r.ContentLength != 0 {
+		if err := validator.ValidateJSONRequest(r, &req); err != nil {
+			WriteHTTPError(w, err, ValidationErrorStatus(err), "handlers.RefundHandler:")
+			return
+		}
+	}
+
+	refund, err := h.srv.Refund(r.Context(), username, purchaseID, req.Quantity)
+	if err != nil {
+		h.writeRefundError(w, r, err, "handlers.RefundHandler:")
+		return
+	}
+
+	SendJSONResponse(w, refund, http.StatusOK)
+}
+
+func (h *PurchaseHandler) AdminRefundHandler(w http.ResponseWriter, r *http.Request) {
+	purchaseID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrPurchaseNotFound, http.StatusNotFound, "handlers.AdminRefundHandler:")
+		return
+	}
+
+	var req struct {
+		Quantity int    `json:"quantity"`
+		Reason   string `json:"reason"`
+	}
+	if err := validator.ValidateJSONRequest(r, &req); err != nil {
+		WriteHTTPError(w, err, ValidationErrorStatus(err), "handlers.AdminRefundHandler:")
+		return
+	}
+
+	refund, err := h.srv.AdminRefund(r.Context(), purchaseID, req.Quantity, req.Reason)
+	if err != nil {
+		h.writeRefundError(w, r, err, "handlers.AdminRefundHandler:")
+		return
+	}
+
+	SendJSONResponse(w, refund, http.StatusOK)
+}
+
+func (h *PurchaseHandler) writeRefundError(w http.ResponseWriter, r *http.Request, err error, prefix string) {
+	switch {
+	case errors.Is(err, appErrors.ErrPurchaseNotFound):
+		WriteHTTPError(w, appErrors.ErrPurchaseNotFound, http.StatusNotFound, prefix)
+	case errors.Is(err, appErrors.ErrRefundsDisabled),
+		errors.Is(err, appErrors.ErrRefundWindowExpired):
+		WriteHTTPError(w, err, http.StatusForbidden, prefix)
+	case errors.Is(err, appErrors.ErrAlreadyRefunded),
+		errors.Is(err, appErrors.ErrItemNotInInventory):
+		WriteHTTPError(w, err, http.StatusConflict, prefix)
+	case errors.Is(err, appErrors.ErrRefundQuantityExceeded),
+		errors.Is(err, appErrors.ErrInvalidQuantity),
+		errors.Is(err, appErrors.ErrReasonRequired):
+		WriteHTTPError(w, err, http.StatusBadRequest, prefix)
+	default:
+		logger.FromContext(r.Context()).Error(prefix+" refund failed", zap.Error(err))
+		WriteHTTPError(w, appErrors.ErrInternal, http.StatusInternalServerError, prefix)
+	}
+}
@@ -0,0 +1,83 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/handler"
+	"github.com/Te8va/MerchStore/pkg/jwt"
+)
+
+func TestPurchaseHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSrv := mocks.NewMockPurchaseService(ctrl)
+	jwtKey := "test_jwt_key"
+	purchaseHandler := handler.NewPurchaseHandler(mockSrv, jwtKey)
+
+	token, err := jwt.CreateJWT("alice", []byte(jwtKey), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/purchases", purchaseHandler.ListPurchasesHandler)
+	mux.HandleFunc("POST /api/purchases/{id}/refund", purchaseHandler.RefundHandler)
+	mux.HandleFunc("POST /api/admin/purchases/{id}/refund", purchaseHandler.AdminRefundHandler)
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// Список покупок
+	mockSrv.EXPECT().ListPurchases(gomock.Any(), "alice", handler.DefaultPageLimit, 0).
+		Return([]domain.Purchase{{ID: 7, Username: "alice", Item: "cup", UnitPrice: 20, Quantity: 3}}, nil)
+	rr := do(http.MethodGet, "/api/purchases", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"item":"cup"`)
+
+	// Частичный возврат
+	mockSrv.EXPECT().Refund(gomock.Any(), "alice", 7, 2).Return(domain.Refund{PurchaseID: 7, Item: "cup", Quantity: 2, Amount: 40}, nil)
+	rr = do(http.MethodPost, "/api/purchases/7/refund", `{"quantity":2}`)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"purchaseId":7,"item":"cup","quantity":2,"amount":40}`, rr.Body.String())
+
+	// Полный возврат без тела запроса
+	mockSrv.EXPECT().Refund(gomock.Any(), "alice", 7, 0).Return(domain.Refund{}, appErrors.ErrRefundWindowExpired)
+	rr = do(http.MethodPost, "/api/purchases/7/refund", "")
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	// Чужая или несуществующая покупка
+	mockSrv.EXPECT().Refund(gomock.Any(), "alice", 8, 0).Return(domain.Refund{}, appErrors.ErrPurchaseNotFound)
+	rr = do(http.MethodPost, "/api/purchases/8/refund", "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	// Предмет уже передан или продан
+	mockSrv.EXPECT().Refund(gomock.Any(), "alice", 7, 1).Return(domain.Refund{}, appErrors.ErrItemNotInInventory)
+	rr = do(http.MethodPost, "/api/purchases/7/refund", `{"quantity":1}`)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	// Возврат администратором
+	mockSrv.EXPECT().AdminRefund(gomock.Any(), 7, 1, "damaged").Return(domain.Refund{PurchaseID: 7, Item: "cup", Quantity: 1, Amount: 20}, nil)
+	rr = do(http.MethodPost, "/api/admin/purchases/7/refund", `{"quantity":1,"reason":"damaged"}`)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// Некорректный идентификатор
+	rr = do(http.MethodPost, "/api/purchases/abc/refund", "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/pkg"
+	"github.com/Te8va/MerchStore/pkg/logger"
+	"github.com/Te8va/MerchStore/pkg/validator"
+)
+
+type RedemptionHandler struct {
+	srv    domain.RedemptionService
+	JWTKey string
+}
+
+func NewRedemptionHandler(srv domain.RedemptionService, jwtKey string) *RedemptionHandler {
+	return &RedemptionHandler{srv: srv, JWTKey: jwtKey}
+}
+
+func (h *RedemptionHandler) RedeemHandler(w http.ResponseWriter, r *http.Request) {
+	username, err := pkg.ExtractUsernameFromRequest(r, h.JWTKey)
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrUnauthorized, http.StatusUnauthorized, "handlers.RedeemHandler:")
+		return
+	}
+
+	var req struct {
+		Item     string `json:"item"`
+		Variant  string `json:"variant"`
+		Quantity int    `json:"quantity"`
+		Office   string `json:"office"`
+		Note     string `json:"note"`
+	}
+	if err := validator.ValidateJSONRequest(r, &req); err != nil {
+		WriteHTTPError(w, err, ValidationErrorStatus(err), "handlers.RedeemHandler:")
+		return
+	}
+
+	redemption, err := h.srv.Redeem(r.Context(), domain.Redemption{
+		Username: username,
+		Item:     req.Item,
+		Variant:  req.Variant,
+		Quantity: req.Quantity,
+		Office:   req.Office,
+		Note:     req.Note,
+	})
+	if err != nil {
+		h.writeRedemptionError(w, r, err, "handlers.RedeemHandler:")
+		return
+	}
+
+	SendJSONResponse(w, redemption, http.StatusCreated)
+}
+
+func (h *RedemptionHandler) ListRedemptionsHandler(w http.ResponseWriter, r *http.Request) {
+	username, err := pkg.ExtractUsernameFromRequest(r, h.JWTKey)
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrUnauthorized, http.StatusUnauthorized, "handlers.ListRedemptionsHandler:")
+		return
+	}
+
+	h.listRedemptions(w, r, username, "handlers.ListRedemptionsHandler:")
+}
+
+func (h *RedemptionHandler) GetRedemptionHandler(w http.ResponseWriter, r *http.Request) {
+	username, err := pkg.ExtractUsernameFromRequest(r, h.JWTKey)
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrUnauthorized, http.StatusUnauthorized, "handlers.GetRedemptionHandler:")
+		return
+	}
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrRedemptionNotFound, http.StatusNotFound, "handlers.GetRedemptionHandler:")
+		return
+	}
+
+	redemption, err := h.srv.GetRedemption(r.Context(), username, id)
+	if err != nil {
+		h.writeRedemptionError(w, r, err, "handlers.GetRedemptionHandler:")
+		return
+	}
+
+	SendJSONResponse(w, redemption, http.StatusOK)
+}
+
+func (h *RedemptionHandler) AdminListRedemptionsHandler(w http.ResponseWriter, r *http.Request) {
+	h.listRedemptions(w, r, r.URL.Query().Get("username"), "handlers.AdminListRedemptionsHandler:")
+}
+
+func (h *RedemptionHandler) AdminUpdateStatusHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrRedemptionNotFound, http.StatusNotFound, "handlers.AdminUpdateStatusHandler:")
+		return
+	}
+
+	var req struct {
+		Status string `json:"status"`
+	}
+	if err := validator.ValidateJSONRequest(r, &req); err != nil {
+		WriteHTTPError(w, err, ValidationErrorStatus(err), "handlers.AdminUpdateStatusHandler:")
+		return
+	}
+
+	redemption, err := h.srv.UpdateStatus(r.Context(), id, req.Status)
+	if err != nil {
+		h.writeRedemptionError(w, r, err, "handlers.AdminUpdateStatusHandler:")
+		return
+	}
+
+	SendJSONResponse(w, redemption, http.StatusOK)
+}
+
+func (h *RedemptionHandler) listRedemptions(w http.ResponseWriter, r *http.Request, username, prefix string) {
+	limit, offset, err := parsePagination(r)
+	if err != nil {
+		WriteHTTPError(w, err, http.StatusBadRequest, prefix)
+		return
+	}
+
+	redemptions, err := h.srv.ListRedemptions(r.Context(), domain.RedemptionFilter{
+		Username: username,
+		Status:   r.URL.Query().Get("status"),
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		h.writeRedemptionError(w, r, err, prefix)
+		return
+	}
+
+	SendJSONResponse(w, redemptions, http.StatusOK)
+}
+
+func (h *RedemptionHandler) writeRedemptionError(w http.ResponseWriter, r *http.Request, err error, prefix string) {
+	switch {
+	case errors.Is(err, appErrors.ErrRedemptionNotFound):
+		WriteHTTPError(w, appErrors.ErrRedemptionNotFound, http.StatusNotFound, prefix)
+	case errors.Is(err, appErrors.ErrStatusTransition),
+		errors.Is(err, appErrors.ErrItemNotInInventory):
+		WriteHTTPError(w, err, http.StatusConflict, prefix)
+	case errors.Is(err, appErrors.ErrItemNotFound),
+		errors.Is(err, appErrors.ErrInvalidQuantity),
+		errors.Is(err, appErrors.ErrInvalidStatus),
+		errors.Is(err, appErrors.ErrDeliveryRequired),
+		errors.Is(err, appErrors.ErrMessageTooLong),
+		errors.Is(err, appErrors.ErrInvalidMessage):
+		WriteHTTPError(w, err, http.StatusBadRequest, prefix)
+	default:
+		logger.FromContext(r.Context()).Error(prefix+" redemption failed", zap.Error(err))
+		WriteHTTPError(w, appErrors.ErrInternal, http.StatusInternalServerError, prefix)
+	}
+}
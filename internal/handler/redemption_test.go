@@ -0,0 +1,88 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/handler"
+	"github.com/Te8va/MerchStore/pkg/jwt"
+)
+
+func TestRedemptionHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSrv := mocks.NewMockRedemptionService(ctrl)
+	jwtKey := "test_jwt_key"
+	redemptionHandler := handler.NewRedemptionHandler(mockSrv, jwtKey)
+
+	token, err := jwt.CreateJWT("alice", []byte(jwtKey), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/redemptions", redemptionHandler.RedeemHandler)
+	mux.HandleFunc("GET /api/redemptions", redemptionHandler.ListRedemptionsHandler)
+	mux.HandleFunc("GET /api/redemptions/{id}", redemptionHandler.GetRedemptionHandler)
+	mux.HandleFunc("GET /api/admin/redemptions", redemptionHandler.AdminListRedemptionsHandler)
+	mux.HandleFunc("PUT /api/admin/redemptions/{id}/status", redemptionHandler.AdminUpdateStatusHandler)
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// Создание заявки на выдачу
+	mockSrv.EXPECT().Redeem(gomock.Any(), domain.Redemption{Username: "alice", Item: "hoody", Quantity: 1, Office: "Moscow"}).
+		Return(domain.Redemption{ID: 1, Username: "alice", Item: "hoody", Quantity: 1, Office: "Moscow", Status: domain.RedemptionStatusPending}, nil)
+	rr := do(http.MethodPost, "/api/redemptions", `{"item":"hoody","quantity":1,"office":"Moscow"}`)
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"status":"pending"`)
+
+	// Без офиса и комментария
+	mockSrv.EXPECT().Redeem(gomock.Any(), gomock.Any()).Return(domain.Redemption{}, appErrors.ErrDeliveryRequired)
+	rr = do(http.MethodPost, "/api/redemptions", `{"item":"hoody"}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	// Список своих заявок
+	mockSrv.EXPECT().ListRedemptions(gomock.Any(), domain.RedemptionFilter{Username: "alice", Status: "pending", Limit: handler.DefaultPageLimit}).
+		Return([]domain.Redemption{{ID: 1, Username: "alice", Status: domain.RedemptionStatusPending}}, nil)
+	rr = do(http.MethodGet, "/api/redemptions?status=pending", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// Чужая заявка
+	mockSrv.EXPECT().GetRedemption(gomock.Any(), "alice", 2).Return(domain.Redemption{}, appErrors.ErrRedemptionNotFound)
+	rr = do(http.MethodGet, "/api/redemptions/2", "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	// Администратор видит заявки всех пользователей
+	mockSrv.EXPECT().ListRedemptions(gomock.Any(), domain.RedemptionFilter{Username: "bob", Limit: handler.DefaultPageLimit}).
+		Return([]domain.Redemption{}, nil)
+	rr = do(http.MethodGet, "/api/admin/redemptions?username=bob", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// Перевод в следующий статус
+	mockSrv.EXPECT().UpdateStatus(gomock.Any(), 1, domain.RedemptionStatusApproved).
+		Return(domain.Redemption{ID: 1, Status: domain.RedemptionStatusApproved}, nil)
+	rr = do(http.MethodPut, "/api/admin/redemptions/1/status", `{"status":"approved"}`)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// Недопустимый переход
+	mockSrv.EXPECT().UpdateStatus(gomock.Any(), 1, domain.RedemptionStatusDelivered).Return(domain.Redemption{}, appErrors.ErrStatusTransition)
+	rr = do(http.MethodPut, "/api/admin/redemptions/1/status", `{"status":"delivered"}`)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/pkg/logger"
+	"github.com/Te8va/MerchStore/pkg/validator"
+)
+
+type WebhookHandler struct {
+	srv domain.WebhookService
+}
+
+func NewWebhookHandler(srv domain.WebhookService) *WebhookHandler {
+	return &WebhookHandler{srv: srv}
+}
+
+func (h *WebhookHandler) ListWebhooksHandler(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := h.srv.ListWebhooks(r.Context())
+	if err != nil {
+		h.writeWebhookError(w, r, err, "handlers.ListWebhooksHandler:")
+		return
+	}
+
+	SendJSONResponse(w, webhooks, http.StatusOK)
+}
+
+func (h *WebhookHandler) CreateWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		URL        string   `json:"url"`
+		Secret     string   `json:"secret"`
+		EventTypes []string `json:"eventTypes"`
+	}
+	if err := validator.ValidateJSONRequest(r, &req); err != nil {
+		WriteHTTPError(w, err, ValidationErrorStatus(err), "handlers.CreateWebhookHandler:")
+		return
+	}
+
+	webhook, err := h.srv.CreateWebhook(r.Context(), domain.Webhook{URL: req.URL, Secret: req.Secret, EventTypes: req.EventTypes})
+	if err != nil {
+		h.writeWebhookError(w, r, err, "handlers.CreateWebhookHandler:")
+		return
+	}
+
+	SendJSONResponse(w, webhook, http.StatusCreated)
+}
+
+func (h *WebhookHandler) UpdateWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrWebhookNotFound, http.StatusNotFound, "handlers.UpdateWebhookHandler:")
+		return
+	}
+
+	var req struct {
+		URL        string   `json:"url"`
+		EventTypes []string `json:"eventTypes"`
+		Active     *bool    `json:"active"`
+	}
+	if err := validator.ValidateJSONRequest(r, &req); err != nil {
+		WriteHTTPError(w, err, ValidationErrorStatus(err), "handlers.UpdateWebhookHandler:")
+		return
+	}
+
+	webhook := domain.Webhook{ID: id, URL: req.URL, EventTypes: req.EventTypes, Active: true}
+	if req.Active != nil {
+		webhook.Active = *req.Active
+	}
+
+	webhook, err = h.srv.UpdateWebhook(r.Context(), webhook)
+	if err != nil {
+		h.writeWebhookError(w, r, err, "handlers.UpdateWebhookHandler:")
+		return
+	}
+
+	SendJSONResponse(w, webhook, http.StatusOK)
+}
+
+func (h *WebhookHandler) DeleteWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrWebhookNotFound, http.StatusNotFound, "handlers.DeleteWebhookHandler:")
+		return
+	}
+
+	if err := h.srv.DeleteWebhook(r.Context(), id); err != nil {
+		h.writeWebhookError(w, r, err, "handlers.DeleteWebhookHandler:")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WebhookHandler) ListDeliveriesHandler(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := parsePagination(r)
+	if err != nil {
+		WriteHTTPError(w, err, http.StatusBadRequest, "handlers.ListDeliveriesHandler:")
+		return
+	}
+
+	filter := domain.DeliveryFilter{Status: r.URL.Query().Get("status"), Limit: limit, Offset: offset}
+	if raw := r.URL.Query().Get("webhookId"); raw != "" {
+		filter.WebhookID, err = strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			WriteHTTPError(w, appErrors.ErrWebhookNotFound, http.StatusNotFound, "handlers.ListDeliveriesHandler:")
+			return
+		}
+	}
+
+	deliveries, err := h.srv.ListDeliveries(r.Context(), filter)
+	if err != nil {
+		h.writeWebhookError(w, r, err, "handlers.ListDeliveriesHandler:")
+		return
+	}
+
+	SendJSONResponse(w, deliveries, http.StatusOK)
+}
+
+func (h *WebhookHandler) ReplayEventHandler(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrOutboxEventNotFound, http.StatusNotFound, "handlers.ReplayEventHandler:")
+		return
+	}
+
+	var req struct {
+		WebhookID int64 `json:"webhookId"`
+	}
+	if r.ContentLength != 0 {
+		if err := validator.ValidateJSONRequest(r, &req); err != nil {
+			WriteHTTPError(w, err, ValidationErrorStatus(err), "handlers.ReplayEventHandler:")
+			return
+		}
+	}
+
+	queued, err := h.srv.ReplayEvent(r.Context(), eventID, req.WebhookID)
+	if err != nil {
+		h.writeWebhookError(w, r, err, "handlers.ReplayEventHandler:")
+		return
+	}
+
+	SendJSONResponse(w, map[string]int{"deliveries": queued}, http.StatusAccepted)
+}
+
+func (h *WebhookHandler) writeWebhookError(w http.ResponseWriter, r *http.Request, err error, prefix string) {
+	switch {
+	case errors.Is(err, appErrors.ErrWebhookNotFound):
+		WriteHTTPError(w, appErrors.ErrWebhookNotFound, http.StatusNotFound, prefix)
+	case errors.Is(err, appErrors.ErrOutboxEventNotFound):
+		WriteHTTPError(w, appErrors.ErrOutboxEventNotFound, http.StatusNotFound, prefix)
+	case errors.Is(err, appErrors.ErrInvalidWebhook),
+		errors.Is(err, appErrors.ErrInvalidDeliveryStatus):
+		WriteHTTPError(w, err, http.StatusBadRequest, prefix)
+	default:
+		logger.FromContext(r.Context()).Error(prefix+" webhook request failed", zap.Error(err))
+		WriteHTTPError(w, appErrors.ErrInternal, http.StatusInternalServerError, prefix)
+	}
+}
@@ -0,0 +1,82 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/handler"
+)
+
+func TestWebhookHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSrv := mocks.NewMockWebhookService(ctrl)
+	webhookHandler := handler.NewWebhookHandler(mockSrv)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/admin/webhooks", webhookHandler.CreateWebhookHandler)
+	mux.HandleFunc("PUT /api/admin/webhooks/{id}", webhookHandler.UpdateWebhookHandler)
+	mux.HandleFunc("DELETE /api/admin/webhooks/{id}", webhookHandler.DeleteWebhookHandler)
+	mux.HandleFunc("GET /api/admin/webhooks/deliveries", webhookHandler.ListDeliveriesHandler)
+	mux.HandleFunc("POST /api/admin/outbox/{id}/replay", webhookHandler.ReplayEventHandler)
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// Создание подписки возвращает секрет
+	mockSrv.EXPECT().CreateWebhook(gomock.Any(), domain.Webhook{URL: "https://hooks.example.com", EventTypes: []string{"coins.transferred"}}).
+		Return(domain.Webhook{ID: 1, URL: "https://hooks.example.com", Secret: "generated", EventTypes: []string{"coins.transferred"}, Active: true}, nil)
+	rr := do(http.MethodPost, "/api/admin/webhooks", `{"url":"https://hooks.example.com","eventTypes":["coins.transferred"]}`)
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"secret":"generated"`)
+
+	// Некорректная подписка
+	mockSrv.EXPECT().CreateWebhook(gomock.Any(), gomock.Any()).Return(domain.Webhook{}, appErrors.ErrInvalidWebhook)
+	rr = do(http.MethodPost, "/api/admin/webhooks", `{"url":"nope"}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	// Отключение подписки
+	mockSrv.EXPECT().UpdateWebhook(gomock.Any(), domain.Webhook{ID: 1, URL: "https://hooks.example.com", Active: false}).
+		Return(domain.Webhook{ID: 1, URL: "https://hooks.example.com", EventTypes: []string{}}, nil)
+	rr = do(http.MethodPut, "/api/admin/webhooks/1", `{"url":"https://hooks.example.com","active":false}`)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// Удаление несуществующей подписки
+	mockSrv.EXPECT().DeleteWebhook(gomock.Any(), int64(9)).Return(appErrors.ErrWebhookNotFound)
+	rr = do(http.MethodDelete, "/api/admin/webhooks/9", "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	// Недоставленные события
+	mockSrv.EXPECT().ListDeliveries(gomock.Any(), domain.DeliveryFilter{WebhookID: 1, Status: domain.DeliveryDead, Limit: handler.DefaultPageLimit}).
+		Return([]domain.WebhookDelivery{{ID: 3, WebhookID: 1, EventID: 10, Status: domain.DeliveryDead, Attempts: 10, LastError: "unexpected status 500"}}, nil)
+	rr = do(http.MethodGet, "/api/admin/webhooks/deliveries?webhookId=1&status=dead", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"status":"dead"`)
+
+	// Повторная отправка события
+	mockSrv.EXPECT().ReplayEvent(gomock.Any(), int64(10), int64(0)).Return(2, nil)
+	rr = do(http.MethodPost, "/api/admin/outbox/10/replay", "")
+	assert.Equal(t, http.StatusAccepted, rr.Code)
+	assert.JSONEq(t, `{"deliveries":2}`, rr.Body.String())
+
+	// Повторная отправка неизвестного события
+	mockSrv.EXPECT().ReplayEvent(gomock.Any(), int64(11), int64(1)).Return(0, appErrors.ErrOutboxEventNotFound)
+	rr = do(http.MethodPost, "/api/admin/outbox/11/replay", `{"webhookId":1}`)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/pkg"
+	"github.com/Te8va/MerchStore/pkg/logger"
+	"github.com/Te8va/MerchStore/pkg/validator"
+)
+
+type WishlistHandler struct {
+	srv    domain.WishlistService
+	JWTKey string
+}
+
+func NewWishlistHandler(srv domain.WishlistService, jwtKey string) *WishlistHandler {
+	return &WishlistHandler{srv: srv, JWTKey: jwtKey}
+}
+
+func (h *WishlistHandler) GetWishlistHandler(w http.ResponseWriter, r *http.Request) {
+	username, err := pkg.ExtractUsernameFromRequest(r, h.JWTKey)
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrUnauthorized, http.StatusUnauthorized, "handlers.GetWishlistHandler:")
+		return
+	}
+
+	wishlist, err := h.srv.GetWishlist(r.Context(), username)
+	if err != nil {
+		h.writeWishlistError(w, r, err, "handlers.GetWishlistHandler:")
+		return
+	}
+
+	SendJSONResponse(w, wishlist, http.StatusOK)
+}
+
+func (h *WishlistHandler) AddToWishlistHandler(w http.ResponseWriter, r *http.Request) {
+	username, err := pkg.ExtractUsernameFromRequest(r, h.JWTKey)
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrUnauthorized, http.StatusUnauthorized, "handlers.AddToWishlistHandler:")
+		return
+	}
+
+	var req struct {
+		Item string `json:"item"`
+	}
+	if err := validator.ValidateJSONRequest(r, &req); err != nil {
+		WriteHTTPError(w, err, ValidationErrorStatus(err), "handlers.AddToWishlistHandler:")
+		return
+	}
+
+	if err := h.srv.AddToWishlist(r.Context(), username, req.Item); err != nil {
+		h.writeWishlistError(w, r, err, "handlers.AddToWishlistHandler:")
+		return
+	}
+
+	wishlist, err := h.srv.GetWishlist(r.Context(), username)
+	if err != nil {
+		h.writeWishlistError(w, r, err, "handlers.AddToWishlistHandler:")
+		return
+	}
+
+	SendJSONResponse(w, wishlist, http.StatusCreated)
+}
+
+func (h *WishlistHandler) RemoveFromWishlistHandler(w http.ResponseWriter, r *http.Request) {
+	username, err := pkg.ExtractUsernameFromRequest(r, h.JWTKey)
+	if err != nil {
+		WriteHTTPError(w, appErrors.ErrUnauthorized, http.StatusUnauthorized, "handlers.RemoveFromWishlistHandler:")
+		return
+	}
+
+	if err := h.srv.RemoveFromWishlist(r.Context(), username, r.PathValue("item")); err != nil {
+		h.writeWishlistError(w, r, err, "handlers.RemoveFromWishlistHandler:")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WishlistHandler) writeWishlistError(w http.ResponseWriter, r *http.Request, err error, prefix string) {
+	switch {
+	case errors.Is(err, appErrors.ErrItemNotFound):
+		WriteHTTPError(w, appErrors.ErrItemNotFound, http.StatusNotFound, prefix)
+	case errors.Is(err, appErrors.ErrNotInWishlist):
+		WriteHTTPError(w, appErrors.ErrNotInWishlist, http.StatusNotFound, prefix)
+	case errors.Is(err, appErrors.ErrUserNotFound):
+		WriteHTTPError(w, appErrors.ErrUserNotFound, http.StatusNotFound, prefix)
+	case errors.Is(err, appErrors.ErrWishlistFull):
+		WriteHTTPError(w, err, http.StatusConflict, prefix)
+	default:
+		logger.FromContext(r.Context()).Error(prefix+" wishlist request failed", zap.Error(err))
+		WriteHTTPError(w, appErrors.ErrInternal, http.StatusInternalServerError, prefix)
+	}
+}
@@ -0,0 +1,80 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/handler"
+	"github.com/Te8va/MerchStore/pkg/jwt"
+)
+
+func TestWishlistHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSrv := mocks.NewMockWishlistService(ctrl)
+	jwtKey := "test_jwt_key"
+	wishlistHandler := handler.NewWishlistHandler(mockSrv, jwtKey)
+
+	token, err := jwt.CreateJWT("alice", []byte(jwtKey), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/wishlist", wishlistHandler.GetWishlistHandler)
+	mux.HandleFunc("POST /api/wishlist", wishlistHandler.AddToWishlistHandler)
+	mux.HandleFunc("DELETE /api/wishlist/{item}", wishlistHandler.RemoveFromWishlistHandler)
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	wishlist := domain.Wishlist{Balance: 200, Items: []domain.WishlistItem{{Item: "pink-hoody", Price: 500, Category: "clothing"}}}
+
+	// Добавление товара
+	mockSrv.EXPECT().AddToWishlist(gomock.Any(), "alice", "pink-hoody").Return(nil)
+	mockSrv.EXPECT().GetWishlist(gomock.Any(), "alice").Return(wishlist, nil)
+	rr := do(http.MethodPost, "/api/wishlist", `{"item":"pink-hoody"}`)
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"affordable":false`)
+
+	// Неизвестный товар
+	mockSrv.EXPECT().AddToWishlist(gomock.Any(), "alice", "ghost").Return(appErrors.ErrItemNotFound)
+	rr = do(http.MethodPost, "/api/wishlist", `{"item":"ghost"}`)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	// Список желаний переполнен
+	mockSrv.EXPECT().AddToWishlist(gomock.Any(), "alice", "cup").Return(appErrors.ErrWishlistFull)
+	rr = do(http.MethodPost, "/api/wishlist", `{"item":"cup"}`)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	// Просмотр списка
+	mockSrv.EXPECT().GetWishlist(gomock.Any(), "alice").Return(wishlist, nil)
+	rr = do(http.MethodGet, "/api/wishlist", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"balance":200`)
+
+	// Удаление товара
+	mockSrv.EXPECT().RemoveFromWishlist(gomock.Any(), "alice", "pink-hoody").Return(nil)
+	rr = do(http.MethodDelete, "/api/wishlist/pink-hoody", "")
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+
+	mockSrv.EXPECT().RemoveFromWishlist(gomock.Any(), "alice", "cup").Return(appErrors.ErrNotInWishlist)
+	rr = do(http.MethodDelete, "/api/wishlist/cup", "")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
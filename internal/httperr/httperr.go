@@ -0,0 +1,32 @@
+package httperr
+
+import (
+	"encoding/json"
+	stdErrors "errors"
+	"net/http"
+
+	"github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/pkg/logger"
+)
+
+// Write sends err as an errors.JSONError body. It is shared by handlers and
+// middleware so that middleware does not depend on the handler package.
+func Write(w http.ResponseWriter, err error, statusCode int, prefix string) {
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	body := errors.JSONError{Err: err.Error()}
+
+	var limitErr *errors.LimitError
+	if stdErrors.As(err, &limitErr) {
+		body.Remaining = &limitErr.Remaining
+	}
+
+	var ruleErr *errors.RuleError
+	if stdErrors.As(err, &ruleErr) {
+		body.Code = ruleErr.Code
+	}
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.Logger().Errorln(prefix, err.Error())
+	}
+}
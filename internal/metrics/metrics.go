@@ -0,0 +1,133 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "merch"
+
+var (
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of HTTP requests by route pattern, method and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	HTTPRequestsInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "Number of HTTP requests currently being served.",
+	})
+
+	CoinsTransferred = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "coins_transferred_total",
+		Help:      "Total amount of coins transferred between users.",
+	})
+
+	Transfers = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfers_total",
+		Help:      "Total number of successful coin transfers.",
+	})
+
+	Purchases = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "purchases_total",
+		Help:      "Total number of merch purchases by item.",
+	}, []string{"item"})
+
+	FailedLogins = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "failed_logins_total",
+		Help:      "Total number of authentication attempts with a wrong password.",
+	})
+
+	InsufficientBalance = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "insufficient_balance_total",
+		Help:      "Total number of operations rejected due to insufficient balance.",
+	}, []string{"operation"})
+
+	CoinsIssued = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "coins_issued_total",
+		Help:      "Total amount of coins credited or debited by the system account by operation.",
+	}, []string{"operation"})
+
+	TransferPolicyRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfer_policy_rejections_total",
+		Help:      "Total number of transfers rejected by the transfer policy by rule.",
+	}, []string{"rule"})
+
+	Refunds = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "refunded_items_total",
+		Help:      "Total number of refunded merch units by item and initiator.",
+	}, []string{"item", "initiator"})
+
+	Gifts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "gifts_total",
+		Help:      "Total number of merch units gifted to other users by item.",
+	}, []string{"item"})
+
+	ItemTransfers = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "item_transfers_total",
+		Help:      "Total number of merch units handed to other users by item.",
+	}, []string{"item"})
+
+	MarketListings = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "market_listings_total",
+		Help:      "Total number of marketplace listings by outcome.",
+	}, []string{"outcome"})
+
+	MarketSales = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "market_sold_items_total",
+		Help:      "Total number of merch units sold on the marketplace by item.",
+	}, []string{"item"})
+
+	Redemptions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "redemptions_total",
+		Help:      "Total number of redemption status changes by new status.",
+	}, []string{"status"})
+
+	Discounts = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "discounted_coins_total",
+		Help:      "Total number of coins taken off purchases by promotions.",
+	})
+
+	ItemRuleRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "item_rule_rejections_total",
+		Help:      "Total number of purchases rejected by per-item rules by rule.",
+	}, []string{"rule"})
+
+	NotificationsRead = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "notifications_read_total",
+		Help:      "Total number of notifications marked as read.",
+	})
+
+	EventStreams = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "event_streams",
+		Help:      "Number of open event streams.",
+	})
+
+	WebhookDeliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_deliveries_total",
+		Help:      "Total number of webhook delivery attempts by outcome.",
+	}, []string{"outcome"})
+)
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/httperr"
+)
+
+const AdminTokenHeader = "X-Admin-Token"
+
+func Admin(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			provided := r.Header.Get(AdminTokenHeader)
+			if token == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				httperr.Write(w, appErrors.ErrWrongAdminHeader, http.StatusForbidden, "middleware.Admin:")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmin(t *testing.T) {
+	var testTable = []struct {
+		name   string
+		token  string
+		header string
+		code   int
+	}{
+		{"valid token", "secret", "secret", http.StatusOK},
+		{"wrong token", "secret", "guess", http.StatusForbidden},
+		{"missing header", "secret", "", http.StatusForbidden},
+		{"admin disabled", "", "", http.StatusForbidden},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			h := Admin(testCase.token)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+			if testCase.header != "" {
+				req.Header.Set(AdminTokenHeader, testCase.header)
+			}
+
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, req)
+			require.Equal(t, testCase.code, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"net/http"
+
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/httperr"
+)
+
+func BodyLimit(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > limit {
+				httperr.Write(w, appErrors.ErrRequestTooLarge, http.StatusRequestEntityTooLarge, "middleware.BodyLimit:")
+				return
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
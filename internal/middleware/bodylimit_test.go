@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBodyLimit(t *testing.T) {
+	h := BodyLimit(8)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+		}
+	}))
+
+	var testTable = []struct {
+		name          string
+		body          string
+		contentLength int64
+		code          int
+	}{
+		{"within limit", "12345678", 8, http.StatusOK},
+		{"declared too large", "123456789", 9, http.StatusRequestEntityTooLarge},
+		{"chunked too large", "123456789", -1, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(testCase.body))
+			req.ContentLength = testCase.contentLength
+
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, req)
+			require.Equal(t, testCase.code, rr.Code)
+		})
+	}
+}
@@ -1,12 +1,24 @@
 package middleware
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"net/http"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/pkg"
 	"github.com/Te8va/MerchStore/pkg/logger"
 )
 
+const (
+	RequestIDHeader = "X-Request-ID"
+
+	maxRequestIDLength = 128
+)
+
 type informativeResponseWriter struct {
 	http.ResponseWriter
 	statusCode    int
@@ -29,16 +41,67 @@ func (irw *informativeResponseWriter) Write(b []byte) (int, error) {
 	return count, err
 }
 
+func (irw *informativeResponseWriter) Unwrap() http.ResponseWriter {
+	return irw.ResponseWriter
+}
+
 func Log(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		logger.Logger().Info("Request HTTP method: ", r.Method, ", request route: ", r.URL.String(), ", length of content in request: ", r.ContentLength)
+		requestID := r.Header.Get(RequestIDHeader)
+		if !isValidRequestID(requestID) {
+			requestID = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+
+		reqLogger := logger.Logger().Desugar().With(zap.String("request_id", requestID))
+		if spanCtx := trace.SpanContextFromContext(r.Context()); spanCtx.HasTraceID() {
+			reqLogger = reqLogger.With(zap.String("trace_id", spanCtx.TraceID().String()))
+		}
+
+		info := &pkg.RequestInfo{ID: requestID}
+		ctx := pkg.WithRequestInfo(r.Context(), info)
+		ctx = logger.WithContext(ctx, reqLogger)
 
 		irw := NewInformativeResponseWriter(w)
 
 		start := time.Now()
-		next.ServeHTTP(irw, r)
+		next.ServeHTTP(irw, r.WithContext(ctx))
 		duration := time.Since(start)
 
-		logger.Logger().Info("Response status for ", r.Method, " ", r.URL.String(), ": ", irw.statusCode, ", length of content in response: ", irw.contentLength, ", processing duration: ", duration)
+		reqLogger.Info("request completed",
+			zap.String("method", r.Method),
+			zap.String("route", r.Pattern),
+			zap.String("path", r.URL.Path),
+			zap.Int("status", irw.statusCode),
+			zap.Int64("request_bytes", r.ContentLength),
+			zap.Int64("bytes", irw.contentLength),
+			zap.Duration("duration", duration),
+			zap.String("user", info.User),
+		)
 	})
 }
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/Te8va/MerchStore/internal/pkg"
 )
 
 func testLogRouter(t *testing.T) *http.ServeMux {
@@ -71,3 +74,51 @@ func TestLog(t *testing.T) {
 		resp.Body.Close()
 	}
 }
+
+func TestLogRequestID(t *testing.T) {
+	var ctxRequestID string
+
+	mux := http.NewServeMux()
+	mux.Handle("/request-id", Log(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxRequestID = pkg.RequestInfoFromContext(r.Context()).ID
+	})))
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	resp := request(t, ts, http.MethodGet, "", "/request-id", "")
+	resp.Body.Close()
+	generated := resp.Header.Get(RequestIDHeader)
+	require.Len(t, generated, 32)
+	require.Equal(t, generated, ctxRequestID)
+
+	var testTable = []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{"propagated", "abc-123_def.4:5", true},
+		{"invalid characters", "abc 123;<script>", false},
+		{"too long", strings.Repeat("a", maxRequestIDLength+1), false},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, ts.URL+"/request-id", nil)
+			require.NoError(t, err)
+			req.Header.Set(RequestIDHeader, testCase.incoming)
+
+			resp, err := ts.Client().Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			if testCase.keep {
+				require.Equal(t, testCase.incoming, resp.Header.Get(RequestIDHeader))
+			} else {
+				require.NotEqual(t, testCase.incoming, resp.Header.Get(RequestIDHeader))
+				require.NotEmpty(t, resp.Header.Get(RequestIDHeader))
+			}
+			require.Equal(t, resp.Header.Get(RequestIDHeader), ctxRequestID)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Te8va/MerchStore/internal/metrics"
+)
+
+func Metrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		metrics.HTTPRequestsInFlight.Inc()
+		defer metrics.HTTPRequestsInFlight.Dec()
+
+		irw := NewInformativeResponseWriter(w)
+
+		start := time.Now()
+		next.ServeHTTP(irw, r)
+		duration := time.Since(start)
+
+		route := r.Pattern
+		if route == "" {
+			route = "unmatched"
+		}
+
+		metrics.HTTPRequestDuration.
+			WithLabelValues(route, r.Method, strconv.Itoa(irw.statusCode)).
+			Observe(duration.Seconds())
+	})
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Te8va/MerchStore/internal/metrics"
+)
+
+func TestMetrics(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics-test/{id}", Metrics(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})))
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	before := testutil.CollectAndCount(metrics.HTTPRequestDuration)
+
+	for _, id := range []string{"1", "2"} {
+		resp := request(t, ts, http.MethodGet, "", "/metrics-test/"+id, "")
+		require.Equal(t, http.StatusTeapot, resp.StatusCode)
+		resp.Body.Close()
+	}
+
+	require.Equal(t, before+1, testutil.CollectAndCount(metrics.HTTPRequestDuration))
+}
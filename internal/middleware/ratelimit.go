@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/httperr"
+	"github.com/Te8va/MerchStore/internal/pkg"
+	"github.com/Te8va/MerchStore/pkg/logger"
+	"github.com/Te8va/MerchStore/pkg/ratelimit"
+)
+
+type KeyFunc func(r *http.Request) string
+
+// UserOrIPKey keys requests by the JWT username, falling back to the client IP.
+// trustedHops is the number of proxies in front of the service whose
+// X-Forwarded-For entries can be trusted; 0 ignores proxy headers.
+func UserOrIPKey(jwtKey string, trustedHops int) KeyFunc {
+	return func(r *http.Request) string {
+		if r.Header.Get("Authorization") != "" {
+			if username, err := pkg.ExtractUsernameFromRequest(r, jwtKey); err == nil {
+				return "user:" + username
+			}
+		}
+		return "ip:" + clientIP(r, trustedHops)
+	}
+}
+
+func RateLimit(store ratelimit.Store, policy ratelimit.Policy, keyFunc KeyFunc) func(http.Handler) http.Handler {
+	policyHeader := fmt.Sprintf("%d;w=%d", policy.Burst, int(math.Ceil(policy.Window().Seconds())))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := policy.Name + ":" + keyFunc(r)
+
+			res, err := store.Allow(r.Context(), key, policy)
+			if err != nil {
+				logger.FromContext(r.Context()).Error("middleware.RateLimit: limiter store failed", zap.Error(err))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("RateLimit-Policy", policyHeader)
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.ResetAfter)))
+
+			if !res.Allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(res.RetryAfter)))
+				httperr.Write(w, appErrors.ErrTooManyRequests, http.StatusTooManyRequests, "middleware.RateLimit:")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientIP returns the address the first trusted proxy saw. Each trusted hop
+// appends one X-Forwarded-For entry, so the client address is trustedHops
+// entries from the right; anything further left was sent by the client and
+// is ignored.
+func clientIP(r *http.Request, trustedHops int) string {
+	if trustedHops > 0 {
+		var entries []string
+		for _, header := range r.Header.Values("X-Forwarded-For") {
+			for _, entry := range strings.Split(header, ",") {
+				if entry = strings.TrimSpace(entry); entry != "" {
+					entries = append(entries, entry)
+				}
+			}
+		}
+		if len(entries) > 0 {
+			return entries[max(len(entries)-trustedHops, 0)]
+		}
+		if realIP := r.Header.Get("X-Real-IP"); realIP != "" {
+			return realIP
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
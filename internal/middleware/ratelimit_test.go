@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Te8va/MerchStore/pkg/jwt"
+	"github.com/Te8va/MerchStore/pkg/ratelimit"
+)
+
+func TestRateLimit(t *testing.T) {
+	jwtKey := "test_jwt_key"
+	policy := ratelimit.Policy{Name: "auth", Rate: 0.1, Burst: 2}
+
+	h := RateLimit(ratelimit.NewMemoryStore(), policy, UserOrIPKey(jwtKey, 1))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+	)
+
+	token, err := jwt.CreateJWT("alice", []byte(jwtKey), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	do := func(forwardedFor, authorization string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/auth", nil)
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		if authorization != "" {
+			req.Header.Set("Authorization", "Bearer "+authorization)
+		}
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := do("10.0.0.1", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "2", rr.Header().Get("RateLimit-Limit"))
+	require.Equal(t, "1", rr.Header().Get("RateLimit-Remaining"))
+	require.Equal(t, "2;w=20", rr.Header().Get("RateLimit-Policy"))
+
+	// Подмена левой части X-Forwarded-For не меняет ключ лимита
+	require.Equal(t, http.StatusOK, do("192.168.0.1, 10.0.0.1", "").Code)
+
+	rr = do("10.0.0.1", "")
+	require.Equal(t, http.StatusTooManyRequests, rr.Code)
+	require.Equal(t, "0", rr.Header().Get("RateLimit-Remaining"))
+	require.Equal(t, "10", rr.Header().Get("Retry-After"))
+	require.Contains(t, rr.Body.String(), "too many requests")
+
+	// Другой IP и аутентифицированный пользователь имеют собственные лимиты
+	require.Equal(t, http.StatusOK, do("10.0.0.2", "").Code)
+	require.Equal(t, http.StatusOK, do("10.0.0.1", token).Code)
+	require.Equal(t, http.StatusOK, do("10.0.0.3", token).Code)
+	require.Equal(t, http.StatusTooManyRequests, do("10.0.0.4", token).Code)
+}
+
+func TestClientIP(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "172.16.0.5:54321"
+	req.Header.Set("X-Forwarded-For", "10.0.0.1")
+
+	require.Equal(t, "172.16.0.5", clientIP(req, 0))
+	require.Equal(t, "10.0.0.1", clientIP(req, 1))
+
+	// Клиент дописал произвольный адрес, прокси добавил реальный справа
+	req.Header.Set("X-Forwarded-For", "1.2.3.4, 10.0.0.1")
+	require.Equal(t, "10.0.0.1", clientIP(req, 1))
+
+	// Два доверенных прокси: адрес клиента второй справа
+	req.Header.Set("X-Forwarded-For", "1.2.3.4, 10.0.0.1, 10.0.0.254")
+	require.Equal(t, "10.0.0.1", clientIP(req, 2))
+
+	// Записей меньше, чем доверенных прокси
+	req.Header.Set("X-Forwarded-For", "10.0.0.1")
+	require.Equal(t, "10.0.0.1", clientIP(req, 2))
+}
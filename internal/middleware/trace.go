@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/Te8va/MerchStore/internal/middleware"
+
+func Trace(next http.Handler) http.Handler {
+	tracer := otel.Tracer(tracerName)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+		spanName := r.Pattern
+		if spanName == "" {
+			spanName = r.Method
+		}
+
+		ctx, span := tracer.Start(ctx, spanName,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.HTTPRoute(r.Pattern),
+				semconv.URLPath(r.URL.Path),
+			),
+		)
+		defer span.End()
+
+		irw := NewInformativeResponseWriter(w)
+		next.ServeHTTP(irw, r.WithContext(ctx))
+
+		span.SetAttributes(semconv.HTTPResponseStatusCode(irw.statusCode))
+		if irw.statusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(irw.statusCode))
+		}
+	})
+}
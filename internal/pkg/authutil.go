@@ -20,5 +20,9 @@ func ExtractUsernameFromRequest(r *http.Request, jwtKey string) (string, error)
 		return "", errors.New("unauthorized user")
 	}
 
+	if info := RequestInfoFromContext(r.Context()); info != nil {
+		info.User = username
+	}
+
 	return username, nil
 }
@@ -9,8 +9,10 @@ func FilterCoinHistory(history domain.CoinHistory) domain.CoinHistory {
 		for _, tx := range history.Sent {
 			if tx.ToUser != "" && tx.Amount > 0 {
 				filteredSent = append(filteredSent, domain.SentTransaction{
-					ToUser: tx.ToUser,
-					Amount: tx.Amount,
+					ToUser:   tx.ToUser,
+					Amount:   tx.Amount,
+					Message:  tx.Message,
+					Category: tx.Category,
 				})
 			}
 		}
@@ -26,6 +28,8 @@ func FilterCoinHistory(history domain.CoinHistory) domain.CoinHistory {
 				filteredReceived = append(filteredReceived, domain.ReceivedTransaction{
 					FromUser: tx.FromUser,
 					Amount:   tx.Amount,
+					Message:  tx.Message,
+					Category: tx.Category,
 				})
 			}
 		}
@@ -0,0 +1,19 @@
+package pkg
+
+import "context"
+
+type RequestInfo struct {
+	ID   string
+	User string
+}
+
+type requestInfoKey struct{}
+
+func WithRequestInfo(ctx context.Context, info *RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+func RequestInfoFromContext(ctx context.Context) *RequestInfo {
+	info, _ := ctx.Value(requestInfoKey{}).(*RequestInfo)
+	return info
+}
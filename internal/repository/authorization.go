@@ -9,6 +9,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
 
 	"github.com/Te8va/MerchStore/internal/domain"
 	appErrors "github.com/Te8va/MerchStore/internal/errors"
@@ -24,17 +25,21 @@ func NewAuthorizationService(pool *pgxpool.Pool) *AuthorizationService {
 }
 
 func (r *AuthorizationService) CreateUser(ctx context.Context, user domain.User) error {
+	ctx, span := tracer.Start(ctx, "repository.CreateUser")
+	defer span.End()
+
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("repository.CreateUser: failed to begin transaction: %w", err)
 	}
 	defer func() {
 		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
-			logger.Logger().Errorln("CreateUser: failed to rollback transaction:", err)
+			logger.FromContext(ctx).Error("repository.CreateUser: failed to rollback transaction", zap.Error(err))
 		}
 	}()
 
-	_, err = tx.Exec(ctx, "INSERT INTO users(username, password, token) VALUES($1, $2, $3)", user.Username, user.Password, user.Token)
+	_, err = tx.Exec(ctx, "INSERT INTO users(username, password, token, balance) VALUES($1, $2, $3, $4)",
+		user.Username, user.Password, user.Token, user.Balance)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
@@ -43,6 +48,16 @@ func (r *AuthorizationService) CreateUser(ctx context.Context, user domain.User)
 		return fmt.Errorf("repository.CreateUser: %w", err)
 	}
 
+	if user.Balance > 0 {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO transactions (from_user, to_user, amount, kind, reason)
+			VALUES ($1, $2, $3, $4, 'welcome bonus')`,
+			domain.SystemAccount, user.Username, user.Balance, domain.TransactionKindWelcome)
+		if err != nil {
+			return fmt.Errorf("repository.CreateUser: could not record welcome bonus: %w", err)
+		}
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return fmt.Errorf("repository.CreateUser: failed to commit transaction: %w", err)
 	}
@@ -51,6 +66,9 @@ func (r *AuthorizationService) CreateUser(ctx context.Context, user domain.User)
 }
 
 func (r *AuthorizationService) GetUserByUsername(ctx context.Context, username string) (*domain.User, error) {
+	ctx, span := tracer.Start(ctx, "repository.GetUserByUsername")
+	defer span.End()
+
 	var user domain.User
 
 	err := r.pool.QueryRow(ctx, "SELECT username, password, token FROM users WHERE username = $1", username).
@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/pkg/logger"
+)
+
+type CatalogService struct {
+	pool *pgxpool.Pool
+}
+
+func NewCatalogService(pool *pgxpool.Pool) *CatalogService {
+	return &CatalogService{pool: pool}
+}
+
+func (r *CatalogService) ListCatalog(ctx context.Context, category string) ([]domain.MerchItem, error) {
+	ctx, span := tracer.Start(ctx, "repository.ListCatalog")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, item_name, price, category, description, image_url
+		FROM merch
+		WHERE $1::text = '' OR category = $1
+		ORDER BY category, item_name`, category)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListCatalog: could not retrieve catalog: %w", err)
+	}
+	defer rows.Close()
+
+	items := []domain.MerchItem{}
+	index := make(map[string]int)
+	for rows.Next() {
+		var item domain.MerchItem
+		if err := rows.Scan(&item.ID, &item.Name, &item.Price, &item.Category, &item.Description, &item.ImageURL); err != nil {
+			return nil, fmt.Errorf("repository.ListCatalog: could not scan item: %w", err)
+		}
+		index[item.Name] = len(items)
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ListCatalog: error reading rows: %w", err)
+	}
+
+	variants, err := r.pool.Query(ctx, "SELECT item_name, name, kind, stock FROM merch_variants ORDER BY item_name, id")
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListCatalog: could not retrieve variants: %w", err)
+	}
+	defer variants.Close()
+
+	for variants.Next() {
+		var (
+			itemName string
+			variant  domain.Variant
+		)
+		if err := variants.Scan(&itemName, &variant.Name, &variant.Kind, &variant.Stock); err != nil {
+			return nil, fmt.Errorf("repository.ListCatalog: could not scan variant: %w", err)
+		}
+		if i, ok := index[itemName]; ok {
+			items[i].Variants = append(items[i].Variants, variant)
+		}
+	}
+
+	if err := variants.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ListCatalog: error reading rows: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *CatalogService) GetCatalogItem(ctx context.Context, name string) (domain.MerchItem, error) {
+	ctx, span := tracer.Start(ctx, "repository.GetCatalogItem")
+	defer span.End()
+
+	var item domain.MerchItem
+	err := r.pool.QueryRow(ctx, `
+		SELECT id, item_name, price, category, description, image_url
+		FROM merch
+		WHERE item_name = $1`, name).
+		Scan(&item.ID, &item.Name, &item.Price, &item.Category, &item.Description, &item.ImageURL)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return item, appErrors.ErrItemNotFound
+		}
+		return item, fmt.Errorf("repository.GetCatalogItem: could not get item: %w", err)
+	}
+
+	item.Variants, err = r.itemVariants(ctx, name)
+	if err != nil {
+		return item, fmt.Errorf("repository.GetCatalogItem: %w", err)
+	}
+
+	return item, nil
+}
+
+func (r *CatalogService) UpdateCatalogItem(ctx context.Context, item domain.MerchItem) (domain.MerchItem, error) {
+	ctx, span := tracer.Start(ctx, "repository.UpdateCatalogItem")
+	defer span.End()
+
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE merch SET category = $2, description = $3, image_url = $4
+		WHERE item_name = $1`, item.Name, item.Category, item.Description, item.ImageURL)
+	if err != nil {
+		return item, fmt.Errorf("repository.UpdateCatalogItem: could not update item: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return item, appErrors.ErrItemNotFound
+	}
+
+	return r.GetCatalogItem(ctx, item.Name)
+}
+
+func (r *CatalogService) UpsertVariant(ctx context.Context, item string, variant domain.Variant) (domain.Variant, error) {
+	ctx, span := tracer.Start(ctx, "repository.UpsertVariant")
+	defer span.End()
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO merch_variants (item_name, name, kind, stock)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (item_name, name) DO UPDATE SET kind = EXCLUDED.kind, stock = EXCLUDED.stock`,
+		item, variant.Name, variant.Kind, variant.Stock)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			return variant, appErrors.ErrItemNotFound
+		}
+		return variant, fmt.Errorf("repository.UpsertVariant: could not save variant: %w", err)
+	}
+
+	return variant, nil
+}
+
+func (r *CatalogService) itemVariants(ctx context.Context, item string) ([]domain.Variant, error) {
+	rows, err := r.pool.Query(ctx, "SELECT name, kind, stock FROM merch_variants WHERE item_name = $1 ORDER BY id", item)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve variants: %w", err)
+	}
+	defer rows.Close()
+
+	var variants []domain.Variant
+	for rows.Next() {
+		var variant domain.Variant
+		if err := rows.Scan(&variant.Name, &variant.Kind, &variant.Stock); err != nil {
+			return nil, fmt.Errorf("could not scan variant: %w", err)
+		}
+		variants = append(variants, variant)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading rows: %w", err)
+	}
+
+	return variants, nil
+}
+
+// UpdatePrice changes the price of item. When the price goes down, users
+// who have the item wishlisted are notified in the same transaction.
+func (r *CatalogService) UpdatePrice(ctx context.Context, item string, price int) (domain.MerchItem, error) {
+	ctx, span := tracer.Start(ctx, "repository.UpdatePrice")
+	defer span.End()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.MerchItem{}, fmt.Errorf("repository.UpdatePrice: could not begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			logger.FromContext(ctx).Error("repository.UpdatePrice: failed to rollback transaction", zap.Error(err))
+		}
+	}()
+
+	var oldPrice int
+	err = tx.QueryRow(ctx, "SELECT price FROM merch WHERE item_name = $1 FOR UPDATE", item).Scan(&oldPrice)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.MerchItem{}, appErrors.ErrItemNotFound
+		}
+		return domain.MerchItem{}, fmt.Errorf("repository.UpdatePrice: could not get price: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE merch SET price = $2 WHERE item_name = $1", item, price)
+	if err != nil {
+		return domain.MerchItem{}, fmt.Errorf("repository.UpdatePrice: could not update price: %w", err)
+	}
+
+	if price < oldPrice {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO notifications (username, kind, message, item)
+			SELECT username, $2, format('%s is now %s coins instead of %s', $1::text, $3::int, $4::int), $1
+			FROM wishlist
+			WHERE item_name = $1`,
+			item, domain.NotificationPriceDrop, price, oldPrice)
+		if err != nil {
+			return domain.MerchItem{}, fmt.Errorf("repository.UpdatePrice: could not notify wishlists: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("repository.UpdatePrice: failed to commit transaction", zap.Error(err))
+		return domain.MerchItem{}, fmt.Errorf("repository.UpdatePrice: could not commit transaction: %w", err)
+	}
+
+	return r.GetCatalogItem(ctx, item)
+}
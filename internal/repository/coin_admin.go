@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/pkg/logger"
+)
+
+type CoinAdminService struct {
+	pool *pgxpool.Pool
+}
+
+func NewCoinAdminService(pool *pgxpool.Pool) *CoinAdminService {
+	return &CoinAdminService{pool: pool}
+}
+
+func (r *CoinAdminService) GrantCoins(ctx context.Context, kind string, adjustments []domain.CoinAdjustment) error {
+	ctx, span := tracer.Start(ctx, "repository.GrantCoins")
+	defer span.End()
+
+	usernames := make([]string, len(adjustments))
+	amounts := make([]int32, len(adjustments))
+	reasons := make([]string, len(adjustments))
+	for i, adjustment := range adjustments {
+		usernames[i] = adjustment.Username
+		amounts[i] = int32(adjustment.Amount)
+		reasons[i] = adjustment.Reason
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("repository.GrantCoins: could not begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			logger.FromContext(ctx).Error("repository.GrantCoins: failed to rollback transaction", zap.Error(err))
+		}
+	}()
+
+	rows, err := tx.Query(ctx, `
+		SELECT DISTINCT i.username
+		FROM unnest($1::text[]) AS i(username)
+		WHERE NOT EXISTS (SELECT 1 FROM users WHERE users.username = i.username AND users.username <> $2)
+		ORDER BY i.username`, usernames, domain.SystemAccount)
+	if err != nil {
+		return fmt.Errorf("repository.GrantCoins: could not check recipients: %w", err)
+	}
+	missing, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return fmt.Errorf("repository.GrantCoins: could not read recipients: %w", err)
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", appErrors.ErrUserNotFound, strings.Join(missing, ", "))
+	}
+
+	// Lock the recipients in username order, the same order TransferCoins
+	// uses, so a large airdrop cannot deadlock with concurrent transfers.
+	_, err = tx.Exec(ctx, "SELECT 1 FROM users WHERE username = ANY($1) ORDER BY username FOR UPDATE", usernames)
+	if err != nil {
+		return fmt.Errorf("repository.GrantCoins: could not lock recipients: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE users SET balance = balance + t.total
+		FROM (
+			SELECT username, SUM(amount::bigint) AS total
+			FROM unnest($1::text[], $2::int[]) AS i(username, amount)
+			GROUP BY username
+		) AS t
+		WHERE users.username = t.username`, usernames, amounts)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.NumericValueOutOfRange {
+			return fmt.Errorf("%w: balance would overflow", appErrors.ErrInvalidAmount)
+		}
+		return fmt.Errorf("repository.GrantCoins: could not update balances: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO transactions (from_user, to_user, amount, kind, reason)
+		SELECT $4, username, amount, $5, reason
+		FROM unnest($1::text[], $2::int[], $3::text[]) AS i(username, amount, reason)`,
+		usernames, amounts, reasons, domain.SystemAccount, kind)
+	if err != nil {
+		return fmt.Errorf("repository.GrantCoins: could not insert transactions: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO notifications (username, kind, message)
+		SELECT username, $3, format('You received %s coins: %s', amount, reason)
+		FROM unnest($1::text[], $2::int[], $4::text[]) AS i(username, amount, reason)`,
+		usernames, amounts, domain.NotificationCoinsGranted, reasons)
+	if err != nil {
+		return fmt.Errorf("repository.GrantCoins: could not insert notifications: %w", err)
+	}
+
+	if err := publishBalances(ctx, tx, usernames...); err != nil {
+		return fmt.Errorf("repository.GrantCoins: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		WITH events AS (
+			INSERT INTO outbox_events (event_type, payload)
+			SELECT $4, jsonb_build_object('username', username, 'amount', amount, 'reason', reason, 'kind', $5::text)
+			FROM unnest($1::text[], $2::int[], $3::text[]) AS i(username, amount, reason)
+			RETURNING id, event_type
+		)`+outboxDeliveries, usernames, amounts, reasons, domain.OutboxCoinsGranted, kind)
+	if err != nil {
+		return fmt.Errorf("repository.GrantCoins: could not insert outbox events: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("repository.GrantCoins: failed to commit transaction", zap.Error(err))
+		return fmt.Errorf("repository.GrantCoins: could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CoinAdminService) DeductCoins(ctx context.Context, adjustment domain.CoinAdjustment) error {
+	ctx, span := tracer.Start(ctx, "repository.DeductCoins")
+	defer span.End()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("repository.DeductCoins: could not begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			logger.FromContext(ctx).Error("repository.DeductCoins: failed to rollback transaction", zap.Error(err))
+		}
+	}()
+
+	var balance int
+	err = tx.QueryRow(ctx, "SELECT balance FROM users WHERE username = $1 AND username <> $2 FOR UPDATE",
+		adjustment.Username, domain.SystemAccount).Scan(&balance)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return appErrors.ErrUserNotFound
+		}
+		return fmt.Errorf("repository.DeductCoins: could not get balance: %w", err)
+	}
+	if balance < adjustment.Amount {
+		return appErrors.ErrInsufficientBalance
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE users SET balance = balance - $1 WHERE username = $2", adjustment.Amount, adjustment.Username)
+	if err != nil {
+		return fmt.Errorf("repository.DeductCoins: could not update balance: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO transactions (from_user, to_user, amount, kind, reason)
+		VALUES ($1, $2, $3, $4, $5)`,
+		adjustment.Username, domain.SystemAccount, adjustment.Amount, domain.TransactionKindDeduction, adjustment.Reason)
+	if err != nil {
+		return fmt.Errorf("repository.DeductCoins: could not insert transaction: %w", err)
+	}
+
+	message := fmt.Sprintf("%d coins were deducted: %s", adjustment.Amount, adjustment.Reason)
+	if err := notify(ctx, tx, adjustment.Username, domain.NotificationCoinsDeducted, message, ""); err != nil {
+		return fmt.Errorf("repository.DeductCoins: %w", err)
+	}
+	if err := publishBalances(ctx, tx, adjustment.Username); err != nil {
+		return fmt.Errorf("repository.DeductCoins: %w", err)
+	}
+
+	err = enqueueOutbox(ctx, tx, domain.OutboxCoinsDeducted, map[string]any{
+		"username": adjustment.Username, "amount": adjustment.Amount, "reason": adjustment.Reason,
+	})
+	if err != nil {
+		return fmt.Errorf("repository.DeductCoins: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("repository.DeductCoins: failed to commit transaction", zap.Error(err))
+		return fmt.Errorf("repository.DeductCoins: could not commit transaction: %w", err)
+	}
+
+	return nil
+}
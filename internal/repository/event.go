@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+)
+
+type EventService struct {
+	pool *pgxpool.Pool
+}
+
+func NewEventService(pool *pgxpool.Pool) *EventService {
+	return &EventService{pool: pool}
+}
+
+func (r *EventService) ListEvents(ctx context.Context, username string, afterID int64, limit int) ([]domain.UserEvent, error) {
+	ctx, span := tracer.Start(ctx, "repository.ListEvents")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT seq, kind, payload, created_at
+		FROM user_events
+		WHERE username = $1 AND seq > $2
+		ORDER BY seq
+		LIMIT $3`, username, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListEvents: could not retrieve events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []domain.UserEvent{}
+	for rows.Next() {
+		var event domain.UserEvent
+		if err := rows.Scan(&event.ID, &event.Kind, &event.Data, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("repository.ListEvents: could not scan event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ListEvents: error reading rows: %w", err)
+	}
+
+	return events, nil
+}
+
+func (r *EventService) LatestEventID(ctx context.Context, username string) (int64, error) {
+	ctx, span := tracer.Start(ctx, "repository.LatestEventID")
+	defer span.End()
+
+	var id int64
+	err := r.pool.QueryRow(ctx, "SELECT event_seq FROM users WHERE username = $1", username).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("repository.LatestEventID: %w", err)
+	}
+	return id, nil
+}
+
+func (r *EventService) DeleteEventsBefore(ctx context.Context, before time.Time) (int, error) {
+	ctx, span := tracer.Start(ctx, "repository.DeleteEventsBefore")
+	defer span.End()
+
+	tag, err := r.pool.Exec(ctx, "DELETE FROM user_events WHERE created_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("repository.DeleteEventsBefore: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// Listen takes a connection out of the pool, subscribes it to
+// domain.EventsChannel and calls onNotify with the payload of every
+// notification until ctx is done or the connection fails.
+func (r *EventService) Listen(ctx context.Context, onNotify func(username string)) error {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("repository.Listen: could not acquire connection: %w", err)
+	}
+	pgConn := conn.Hijack()
+	defer pgConn.Close(context.Background())
+
+	if _, err := pgConn.Exec(ctx, "LISTEN "+pgx.Identifier{domain.EventsChannel}.Sanitize()); err != nil {
+		return fmt.Errorf("repository.Listen: could not listen: %w", err)
+	}
+
+	for {
+		notification, err := pgConn.WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("repository.Listen: %w", err)
+		}
+		onNotify(notification.Payload)
+	}
+}
+
+// publishEvent stores an event for username as part of tx and wakes up its
+// streams once tx commits. The event number comes from users.event_seq,
+// whose row lock keeps the user's events in commit order.
+func publishEvent(ctx context.Context, tx pgx.Tx, username, kind string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("could not encode event: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		WITH numbered AS (
+			UPDATE users SET event_seq = event_seq + 1
+			WHERE username = $1
+			RETURNING username, event_seq
+		), inserted AS (
+			INSERT INTO user_events (username, seq, kind, payload)
+			SELECT username, event_seq, $2, $3 FROM numbered
+			RETURNING username
+		)
+		SELECT pg_notify($4, username) FROM inserted`,
+		username, kind, data, domain.EventsChannel)
+	if err != nil {
+		return fmt.Errorf("could not publish event: %w", err)
+	}
+	return nil
+}
+
+// publishBalances stores the current balance of each of usernames as a
+// balance event. It must run after the balances were updated in tx.
+func publishBalances(ctx context.Context, tx pgx.Tx, usernames ...string) error {
+	_, err := tx.Exec(ctx, `
+		WITH numbered AS (
+			UPDATE users SET event_seq = event_seq + 1
+			WHERE username = ANY($1) AND username <> $3
+			RETURNING username, event_seq, balance
+		), inserted AS (
+			INSERT INTO user_events (username, seq, kind, payload)
+			SELECT username, event_seq, $2, jsonb_build_object('balance', balance) FROM numbered
+			RETURNING username
+		)
+		SELECT pg_notify($4, username) FROM inserted`,
+		usernames, domain.EventBalance, domain.SystemAccount, domain.EventsChannel)
+	if err != nil {
+		return fmt.Errorf("could not publish balances: %w", err)
+	}
+	return nil
+}
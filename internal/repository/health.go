@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type HealthService struct {
+	pool *pgxpool.Pool
+}
+
+func NewHealthService(pool *pgxpool.Pool) *HealthService {
+	return &HealthService{pool: pool}
+}
+
+func (r *HealthService) Ping(ctx context.Context) error {
+	if err := r.pool.Ping(ctx); err != nil {
+		return fmt.Errorf("repository.Ping: %w", err)
+	}
+	return nil
+}
+
+func (r *HealthService) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	var (
+		version int64
+		dirty   bool
+	)
+
+	err := r.pool.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if err != nil {
+		return 0, false, fmt.Errorf("repository.MigrationVersion: %w", err)
+	}
+
+	return uint(version), dirty, nil
+}
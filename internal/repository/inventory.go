@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+)
+
+func addToInventory(ctx context.Context, tx pgx.Tx, username, item, variant string, quantity int) error {
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO inventory (user_id, item_name, variant, quantity)
+		SELECT id, $2, $3, $4 FROM users WHERE username = $1
+		ON CONFLICT (user_id, item_name, variant)
+		DO UPDATE SET quantity = inventory.quantity + EXCLUDED.quantity`, username, item, variant, quantity)
+	if err != nil {
+		return fmt.Errorf("addToInventory: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return appErrors.ErrUserNotFound
+	}
+	return nil
+}
+
+// removeFromInventory takes quantity units of item from the user's
+// inventory and drops the row once it is empty. It fails with
+// ErrItemNotInInventory when the user holds fewer units.
+func removeFromInventory(ctx context.Context, tx pgx.Tx, username, item, variant string, quantity int) error {
+	tag, err := tx.Exec(ctx, `
+		UPDATE inventory SET quantity = quantity - $4
+		FROM users
+		WHERE inventory.user_id = users.id AND users.username = $1
+			AND inventory.item_name = $2 AND inventory.variant = $3 AND inventory.quantity >= $4`,
+		username, item, variant, quantity)
+	if err != nil {
+		return fmt.Errorf("removeFromInventory: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return appErrors.ErrItemNotInInventory
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM inventory
+		USING users
+		WHERE inventory.user_id = users.id AND users.username = $1
+			AND inventory.item_name = $2 AND inventory.variant = $3 AND inventory.quantity = 0`, username, item, variant)
+	if err != nil {
+		return fmt.Errorf("removeFromInventory: %w", err)
+	}
+
+	return nil
+}
+
+// takeStock reserves quantity units of the item variant from the store's
+// stock and returns the variant it took them from. Items without variants
+// are not stock-limited. When no variant is given for an item that has
+// them, the variant with the most stock is used, so callers that predate
+// variants keep working.
+func takeStock(ctx context.Context, tx pgx.Tx, item, variant string, quantity int) (string, error) {
+	var hasVariants bool
+	err := tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM merch_variants WHERE item_name = $1)", item).Scan(&hasVariants)
+	if err != nil {
+		return "", fmt.Errorf("takeStock: %w", err)
+	}
+
+	switch {
+	case !hasVariants && variant == "":
+		return "", nil
+	case !hasVariants:
+		return "", appErrors.ErrVariantNotFound
+	}
+
+	var stock int
+	if variant == "" {
+		err = tx.QueryRow(ctx, `
+			SELECT name, stock FROM merch_variants
+			WHERE item_name = $1
+			ORDER BY stock DESC, id
+			LIMIT 1
+			FOR UPDATE`, item).Scan(&variant, &stock)
+	} else {
+		err = tx.QueryRow(ctx, "SELECT name, stock FROM merch_variants WHERE item_name = $1 AND name = $2 FOR UPDATE", item, variant).
+			Scan(&variant, &stock)
+	}
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", appErrors.ErrVariantNotFound
+		}
+		return "", fmt.Errorf("takeStock: %w", err)
+	}
+	if stock < quantity {
+		return "", appErrors.ErrOutOfStock
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE merch_variants SET stock = stock - $3 WHERE item_name = $1 AND name = $2", item, variant, quantity)
+	if err != nil {
+		return "", fmt.Errorf("takeStock: %w", err)
+	}
+
+	return variant, nil
+}
+
+// returnStock puts refunded units of a variant back on sale.
+func returnStock(ctx context.Context, tx pgx.Tx, item, variant string, quantity int) error {
+	if variant == "" {
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, "UPDATE merch_variants SET stock = stock + $3 WHERE item_name = $1 AND name = $2", item, variant, quantity)
+	if err != nil {
+		return fmt.Errorf("returnStock: %w", err)
+	}
+
+	return nil
+}
+
+func isStockError(err error) bool {
+	return errors.Is(err, appErrors.ErrVariantNotFound) ||
+		errors.Is(err, appErrors.ErrOutOfStock)
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/pkg/logger"
+)
+
+type IssuanceService struct {
+	pool *pgxpool.Pool
+}
+
+func NewIssuanceService(pool *pgxpool.Pool) *IssuanceService {
+	return &IssuanceService{pool: pool}
+}
+
+// RunIssuance pays the policy amount to every user once per period. The run
+// is claimed and paid in a single transaction, so a crash or a concurrent
+// replica never pays the same period twice.
+func (r *IssuanceService) RunIssuance(ctx context.Context, policy domain.IssuancePolicy, periodKey string) (int, bool, error) {
+	ctx, span := tracer.Start(ctx, "repository.RunIssuance")
+	defer span.End()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, false, fmt.Errorf("repository.RunIssuance: could not begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			logger.FromContext(ctx).Error("repository.RunIssuance: failed to rollback transaction", zap.Error(err))
+		}
+	}()
+
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO issuance_runs (policy, period_key, amount)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (policy, period_key) DO NOTHING`, policy.Name, periodKey, policy.Amount)
+	if err != nil {
+		return 0, false, fmt.Errorf("repository.RunIssuance: could not claim period: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return 0, false, nil
+	}
+
+	rows, err := tx.Query(ctx, "UPDATE users SET balance = balance + $2 WHERE username <> $1 RETURNING username",
+		domain.SystemAccount, policy.Amount)
+	if err != nil {
+		return 0, false, fmt.Errorf("repository.RunIssuance: could not pay users: %w", err)
+	}
+	paid, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return 0, false, fmt.Errorf("repository.RunIssuance: could not read paid users: %w", err)
+	}
+
+	// Every follow-up row is driven by the paid set, so a user registered
+	// while the run is in progress gets neither the grant nor its records.
+	reason := policy.Name + " " + periodKey
+	_, err = tx.Exec(ctx, `
+		WITH paid AS (
+			SELECT username FROM unnest($1::text[]) AS p(username)
+		), recorded AS (
+			INSERT INTO transactions (from_user, to_user, amount, kind, reason)
+			SELECT $2, username, $3, $4, $5 FROM paid
+		), notified AS (
+			INSERT INTO notifications (username, kind, message)
+			SELECT username, $6, format('You received %s coins: %s', $3::int, $5::text) FROM paid
+		), events AS (
+			INSERT INTO outbox_events (event_type, payload)
+			SELECT $7, jsonb_build_object('username', username, 'amount', $3::int, 'reason', $5::text, 'kind', $4::text)
+			FROM paid
+			RETURNING id, event_type
+		)`+outboxDeliveries,
+		paid, domain.SystemAccount, policy.Amount, domain.TransactionKindAllowance, reason,
+		domain.NotificationCoinsGranted, domain.OutboxCoinsGranted)
+	if err != nil {
+		return 0, false, fmt.Errorf("repository.RunIssuance: could not record grants: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE issuance_runs SET recipients = $3 WHERE policy = $1 AND period_key = $2",
+		policy.Name, periodKey, len(paid))
+	if err != nil {
+		return 0, false, fmt.Errorf("repository.RunIssuance: could not update run: %w", err)
+	}
+
+	if err := publishBalances(ctx, tx, paid...); err != nil {
+		return 0, false, fmt.Errorf("repository.RunIssuance: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("repository.RunIssuance: failed to commit transaction", zap.Error(err))
+		return 0, false, fmt.Errorf("repository.RunIssuance: could not commit transaction: %w", err)
+	}
+
+	return len(paid), true, nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+)
+
+const itemRuleColumns = "item_name, max_per_user, max_per_period, period, min_account_age_days, required_role, requires_received_coins, updated_at"
+
+type ItemRuleService struct {
+	pool *pgxpool.Pool
+}
+
+func NewItemRuleService(pool *pgxpool.Pool) *ItemRuleService {
+	return &ItemRuleService{pool: pool}
+}
+
+func (r *ItemRuleService) ListItemRules(ctx context.Context) ([]domain.ItemRule, error) {
+	ctx, span := tracer.Start(ctx, "repository.ListItemRules")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, "SELECT "+itemRuleColumns+" FROM item_rules ORDER BY item_name")
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListItemRules: could not retrieve rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []domain.ItemRule{}
+	for rows.Next() {
+		rule, err := scanItemRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListItemRules: could not scan rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ListItemRules: error reading rows: %w", err)
+	}
+
+	return rules, nil
+}
+
+func (r *ItemRuleService) SaveItemRule(ctx context.Context, rule domain.ItemRule) (domain.ItemRule, error) {
+	ctx, span := tracer.Start(ctx, "repository.SaveItemRule")
+	defer span.End()
+
+	saved, err := scanItemRule(r.pool.QueryRow(ctx, `
+		INSERT INTO item_rules (item_name, max_per_user, max_per_period, period, min_account_age_days, required_role, requires_received_coins)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (item_name) DO UPDATE SET
+			max_per_user = EXCLUDED.max_per_user,
+			max_per_period = EXCLUDED.max_per_period,
+			period = EXCLUDED.period,
+			min_account_age_days = EXCLUDED.min_account_age_days,
+			required_role = EXCLUDED.required_role,
+			requires_received_coins = EXCLUDED.requires_received_coins,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING `+itemRuleColumns,
+		rule.Item, rule.MaxPerUser, rule.MaxPerPeriod, rule.Period, rule.MinAccountAgeDays,
+		rule.RequiredRole, rule.RequiresReceivedCoins))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			return domain.ItemRule{}, appErrors.ErrItemNotFound
+		}
+		return domain.ItemRule{}, fmt.Errorf("repository.SaveItemRule: could not save rule: %w", err)
+	}
+
+	return saved, nil
+}
+
+func (r *ItemRuleService) DeleteItemRule(ctx context.Context, item string) error {
+	ctx, span := tracer.Start(ctx, "repository.DeleteItemRule")
+	defer span.End()
+
+	tag, err := r.pool.Exec(ctx, "DELETE FROM item_rules WHERE item_name = $1", item)
+	if err != nil {
+		return fmt.Errorf("repository.DeleteItemRule: could not delete rule: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return appErrors.ErrItemRuleNotFound
+	}
+
+	return nil
+}
+
+func (r *ItemRuleService) SetUserRole(ctx context.Context, username, role string) error {
+	ctx, span := tracer.Start(ctx, "repository.SetUserRole")
+	defer span.End()
+
+	tag, err := r.pool.Exec(ctx, "UPDATE users SET role = $2 WHERE username = $1 AND username <> $3", username, role, domain.SystemAccount)
+	if err != nil {
+		return fmt.Errorf("repository.SetUserRole: could not update role: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return appErrors.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func scanItemRule(row pgx.Row) (domain.ItemRule, error) {
+	var rule domain.ItemRule
+	err := row.Scan(&rule.Item, &rule.MaxPerUser, &rule.MaxPerPeriod, &rule.Period, &rule.MinAccountAgeDays,
+		&rule.RequiredRole, &rule.RequiresReceivedCoins, &rule.UpdatedAt)
+	return rule, err
+}
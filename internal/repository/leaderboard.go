@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+)
+
+// leaderboardQuery selects (username, amount) rows since $1 for one board.
+// The transaction kinds it counts are bound to $4.
+type leaderboardQuery struct {
+	sql   string
+	kinds []string
+}
+
+// Peer transfers and gifts count as appreciation. Spending is net of refunds
+// and includes gifts and marketplace purchases.
+var leaderboardQueries = map[string]leaderboardQuery{
+	domain.BoardReceived: {
+		sql: `
+		SELECT to_user AS username, amount
+		FROM transactions
+		WHERE kind = ANY($4) AND from_user <> $3 AND created_at >= $1`,
+		kinds: []string{domain.TransactionKindTransfer, domain.TransactionKindGift},
+	},
+	domain.BoardGiven: {
+		sql: `
+		SELECT from_user AS username, amount
+		FROM transactions
+		WHERE kind = ANY($4) AND created_at >= $1`,
+		kinds: []string{domain.TransactionKindTransfer, domain.TransactionKindGift},
+	},
+	domain.BoardSpent: {
+		sql: `
+		SELECT username, paid_price * (quantity - refunded_quantity) AS amount
+		FROM purchases
+		WHERE purchase_date >= $1
+		UNION ALL
+		SELECT from_user, amount
+		FROM transactions
+		WHERE kind = ANY($4) AND created_at >= $1`,
+		kinds: []string{domain.TransactionKindGift, domain.TransactionKindSale},
+	},
+}
+
+type LeaderboardService struct {
+	pool *pgxpool.Pool
+}
+
+func NewLeaderboardService(pool *pgxpool.Pool) *LeaderboardService {
+	return &LeaderboardService{pool: pool}
+}
+
+func (r *LeaderboardService) GetLeaderboard(ctx context.Context, board string, since time.Time, limit int) ([]domain.LeaderboardEntry, error) {
+	ctx, span := tracer.Start(ctx, "repository.GetLeaderboard")
+	defer span.End()
+
+	query, ok := leaderboardQueries[board]
+	if !ok {
+		return nil, appErrors.ErrInvalidLeaderboard
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT RANK() OVER (ORDER BY SUM(s.amount) DESC)::int, s.username, SUM(s.amount)::int
+		FROM (`+query.sql+`) AS s
+		JOIN users u ON u.username = s.username
+		WHERE NOT u.leaderboard_opt_out AND u.username <> $3
+		GROUP BY s.username
+		HAVING SUM(s.amount) > 0
+		ORDER BY 1, 2
+		LIMIT $2`, since, limit, domain.SystemAccount, query.kinds)
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetLeaderboard: could not retrieve leaderboard: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []domain.LeaderboardEntry{}
+	for rows.Next() {
+		var entry domain.LeaderboardEntry
+		if err := rows.Scan(&entry.Rank, &entry.Username, &entry.Amount); err != nil {
+			return nil, fmt.Errorf("repository.GetLeaderboard: could not scan entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.GetLeaderboard: error reading rows: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (r *LeaderboardService) SetLeaderboardOptOut(ctx context.Context, username string, optOut bool) error {
+	ctx, span := tracer.Start(ctx, "repository.SetLeaderboardOptOut")
+	defer span.End()
+
+	tag, err := r.pool.Exec(ctx, "UPDATE users SET leaderboard_opt_out = $2 WHERE username = $1", username, optOut)
+	if err != nil {
+		return fmt.Errorf("repository.SetLeaderboardOptOut: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return appErrors.ErrUserNotFound
+	}
+
+	return nil
+}
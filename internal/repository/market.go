@@ -0,0 +1,351 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/pkg/logger"
+)
+
+const listingColumns = "id, seller, item, variant, quantity, price, status, COALESCE(buyer, ''), created_at, closed_at"
+
+type MarketService struct {
+	pool *pgxpool.Pool
+}
+
+func NewMarketService(pool *pgxpool.Pool) *MarketService {
+	return &MarketService{pool: pool}
+}
+
+// TransferItem moves units between inventories. The recipient is checked
+// against the item rules under their row lock, like a gift recipient.
+func (r *MarketService) TransferItem(ctx context.Context, transfer domain.ItemTransfer, now time.Time) error {
+	ctx, span := tracer.Start(ctx, "repository.TransferItem")
+	defer span.End()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("repository.TransferItem: could not begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			logger.FromContext(ctx).Error("repository.TransferItem: failed to rollback transaction", zap.Error(err))
+		}
+	}()
+
+	_, err = tx.Exec(ctx, "SELECT 1 FROM users WHERE username IN ($1, $2) ORDER BY username FOR UPDATE", transfer.FromUser, transfer.ToUser)
+	if err != nil {
+		return fmt.Errorf("repository.TransferItem: could not lock users: %w", err)
+	}
+
+	if err := checkItemRules(ctx, tx, transfer.ToUser, transfer.Item, transfer.Quantity, now); err != nil {
+		var ruleErr *appErrors.RuleError
+		if errors.As(err, &ruleErr) || errors.Is(err, appErrors.ErrUserNotFound) {
+			return err
+		}
+		return fmt.Errorf("repository.TransferItem: %w", err)
+	}
+
+	if err := removeFromInventory(ctx, tx, transfer.FromUser, transfer.Item, transfer.Variant, transfer.Quantity); err != nil {
+		if errors.Is(err, appErrors.ErrItemNotInInventory) {
+			return err
+		}
+		return fmt.Errorf("repository.TransferItem: %w", err)
+	}
+
+	if err := addToInventory(ctx, tx, transfer.ToUser, transfer.Item, transfer.Variant, transfer.Quantity); err != nil {
+		if errors.Is(err, appErrors.ErrUserNotFound) {
+			return err
+		}
+		return fmt.Errorf("repository.TransferItem: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO transactions (from_user, to_user, amount, kind, message, item, variant, quantity)
+		VALUES ($1, $2, 0, $3, $4, $5, $6, $7)`,
+		transfer.FromUser, transfer.ToUser, domain.TransactionKindItem, transfer.Message, transfer.Item, transfer.Variant, transfer.Quantity)
+	if err != nil {
+		return fmt.Errorf("repository.TransferItem: could not insert transaction: %w", err)
+	}
+
+	message := fmt.Sprintf("%s passed you %s", transfer.FromUser, itemLabel(transfer.Item, transfer.Variant, transfer.Quantity))
+	if transfer.Message != "" {
+		message += ": " + transfer.Message
+	}
+	if err := notify(ctx, tx, transfer.ToUser, domain.NotificationItemReceived, message, transfer.Item); err != nil {
+		return fmt.Errorf("repository.TransferItem: %w", err)
+	}
+
+	err = publishEvent(ctx, tx, transfer.ToUser, domain.EventItemTransfer, map[string]any{
+		"from": transfer.FromUser, "item": transfer.Item, "variant": transfer.Variant,
+		"quantity": transfer.Quantity, "message": transfer.Message,
+	})
+	if err != nil {
+		return fmt.Errorf("repository.TransferItem: %w", err)
+	}
+
+	err = enqueueOutbox(ctx, tx, domain.OutboxMerchTransferred, map[string]any{
+		"from": transfer.FromUser, "to": transfer.ToUser, "item": transfer.Item, "variant": transfer.Variant,
+		"quantity": transfer.Quantity, "message": transfer.Message,
+	})
+	if err != nil {
+		return fmt.Errorf("repository.TransferItem: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("repository.TransferItem: failed to commit transaction", zap.Error(err))
+		return fmt.Errorf("repository.TransferItem: could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// CreateListing moves the listed units from the seller's inventory into
+// escrow and opens the listing.
+func (r *MarketService) CreateListing(ctx context.Context, listing domain.Listing) (domain.Listing, error) {
+	ctx, span := tracer.Start(ctx, "repository.CreateListing")
+	defer span.End()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.Listing{}, fmt.Errorf("repository.CreateListing: could not begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			logger.FromContext(ctx).Error("repository.CreateListing: failed to rollback transaction", zap.Error(err))
+		}
+	}()
+
+	if err := removeFromInventory(ctx, tx, listing.Seller, listing.Item, listing.Variant, listing.Quantity); err != nil {
+		if errors.Is(err, appErrors.ErrItemNotInInventory) {
+			return domain.Listing{}, err
+		}
+		return domain.Listing{}, fmt.Errorf("repository.CreateListing: %w", err)
+	}
+
+	created, err := scanListing(tx.QueryRow(ctx, `
+		INSERT INTO market_listings (seller, item, variant, quantity, price)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+listingColumns, listing.Seller, listing.Item, listing.Variant, listing.Quantity, listing.Price))
+	if err != nil {
+		return domain.Listing{}, fmt.Errorf("repository.CreateListing: could not insert listing: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("repository.CreateListing: failed to commit transaction", zap.Error(err))
+		return domain.Listing{}, fmt.Errorf("repository.CreateListing: could not commit transaction: %w", err)
+	}
+
+	return created, nil
+}
+
+// CancelListing closes an open listing and returns the escrowed units to the
+// seller.
+func (r *MarketService) CancelListing(ctx context.Context, id int, seller string) (domain.Listing, error) {
+	ctx, span := tracer.Start(ctx, "repository.CancelListing")
+	defer span.End()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.Listing{}, fmt.Errorf("repository.CancelListing: could not begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			logger.FromContext(ctx).Error("repository.CancelListing: failed to rollback transaction", zap.Error(err))
+		}
+	}()
+
+	listing, err := lockListing(ctx, tx, id)
+	if err != nil {
+		return domain.Listing{}, fmt.Errorf("repository.CancelListing: %w", err)
+	}
+	if listing.Seller != seller {
+		return domain.Listing{}, appErrors.ErrListingNotFound
+	}
+	if listing.Status != domain.ListingStatusOpen {
+		return domain.Listing{}, appErrors.ErrListingClosed
+	}
+
+	if err := addToInventory(ctx, tx, listing.Seller, listing.Item, listing.Variant, listing.Quantity); err != nil {
+		return domain.Listing{}, fmt.Errorf("repository.CancelListing: %w", err)
+	}
+
+	listing, err = scanListing(tx.QueryRow(ctx, `
+		UPDATE market_listings SET status = $2, closed_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING `+listingColumns, id, domain.ListingStatusCancelled))
+	if err != nil {
+		return domain.Listing{}, fmt.Errorf("repository.CancelListing: could not update listing: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("repository.CancelListing: failed to commit transaction", zap.Error(err))
+		return domain.Listing{}, fmt.Errorf("repository.CancelListing: could not commit transaction: %w", err)
+	}
+
+	return listing, nil
+}
+
+// BuyListing swaps the buyer's coins for the escrowed units in one
+// transaction. The price is a coin movement from buyer to seller, so the
+// amount limits of policy apply to it like to a transfer, and the buyer is
+// checked against the item rules like any other recipient.
+func (r *MarketService) BuyListing(ctx context.Context, id int, buyer string, policy domain.TransferPolicy, now time.Time) (domain.Listing, error) {
+	ctx, span := tracer.Start(ctx, "repository.BuyListing")
+	defer span.End()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.Listing{}, fmt.Errorf("repository.BuyListing: could not begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			logger.FromContext(ctx).Error("repository.BuyListing: failed to rollback transaction", zap.Error(err))
+		}
+	}()
+
+	listing, err := lockListing(ctx, tx, id)
+	if err != nil {
+		return domain.Listing{}, fmt.Errorf("repository.BuyListing: %w", err)
+	}
+	if listing.Status != domain.ListingStatusOpen {
+		return domain.Listing{}, appErrors.ErrListingClosed
+	}
+	if listing.Seller == buyer {
+		return domain.Listing{}, appErrors.ErrOwnListing
+	}
+
+	var balance int
+	err = tx.QueryRow(ctx, "SELECT balance FROM users WHERE username = $1 FOR UPDATE", buyer).Scan(&balance)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Listing{}, appErrors.ErrUserNotFound
+		}
+		return domain.Listing{}, fmt.Errorf("repository.BuyListing: could not get balance: %w", err)
+	}
+	if balance < listing.Price {
+		return domain.Listing{}, appErrors.ErrInsufficientBalance
+	}
+
+	since := domain.PeriodStart(domain.PeriodDaily, now)
+	if err := checkTransferCaps(ctx, tx, policy, buyer, listing.Seller, listing.Price, since); err != nil {
+		var limitErr *appErrors.LimitError
+		if errors.As(err, &limitErr) {
+			return domain.Listing{}, err
+		}
+		return domain.Listing{}, fmt.Errorf("repository.BuyListing: %w", err)
+	}
+
+	if err := checkItemRules(ctx, tx, buyer, listing.Item, listing.Quantity, now); err != nil {
+		var ruleErr *appErrors.RuleError
+		if errors.As(err, &ruleErr) {
+			return domain.Listing{}, err
+		}
+		return domain.Listing{}, fmt.Errorf("repository.BuyListing: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE users SET balance = balance - $1 WHERE username = $2", listing.Price, buyer)
+	if err != nil {
+		return domain.Listing{}, fmt.Errorf("repository.BuyListing: could not charge buyer: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE users SET balance = balance + $1 WHERE username = $2", listing.Price, listing.Seller)
+	if err != nil {
+		return domain.Listing{}, fmt.Errorf("repository.BuyListing: could not pay seller: %w", err)
+	}
+
+	if err := addToInventory(ctx, tx, buyer, listing.Item, listing.Variant, listing.Quantity); err != nil {
+		return domain.Listing{}, fmt.Errorf("repository.BuyListing: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO transactions (from_user, to_user, amount, kind, item, variant, quantity)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		buyer, listing.Seller, listing.Price, domain.TransactionKindSale, listing.Item, listing.Variant, listing.Quantity)
+	if err != nil {
+		return domain.Listing{}, fmt.Errorf("repository.BuyListing: could not insert transaction: %w", err)
+	}
+
+	listing, err = scanListing(tx.QueryRow(ctx, `
+		UPDATE market_listings SET status = $2, buyer = $3, closed_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING `+listingColumns, id, domain.ListingStatusSold, buyer))
+	if err != nil {
+		return domain.Listing{}, fmt.Errorf("repository.BuyListing: could not update listing: %w", err)
+	}
+
+	if err := publishBalances(ctx, tx, buyer, listing.Seller); err != nil {
+		return domain.Listing{}, fmt.Errorf("repository.BuyListing: %w", err)
+	}
+	if err := enqueueOutbox(ctx, tx, domain.OutboxMarketSale, listing); err != nil {
+		return domain.Listing{}, fmt.Errorf("repository.BuyListing: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("repository.BuyListing: failed to commit transaction", zap.Error(err))
+		return domain.Listing{}, fmt.Errorf("repository.BuyListing: could not commit transaction: %w", err)
+	}
+
+	return listing, nil
+}
+
+func (r *MarketService) ListListings(ctx context.Context, filter domain.ListingFilter) ([]domain.Listing, error) {
+	ctx, span := tracer.Start(ctx, "repository.ListListings")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+listingColumns+`
+		FROM market_listings
+		WHERE status = $1
+			AND ($2::text = '' OR item = $2)
+			AND ($3::text = '' OR seller = $3)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4 OFFSET $5`,
+		domain.ListingStatusOpen, filter.Item, filter.Seller, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListListings: could not retrieve listings: %w", err)
+	}
+	defer rows.Close()
+
+	listings := []domain.Listing{}
+	for rows.Next() {
+		listing, err := scanListing(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListListings: could not scan listing: %w", err)
+		}
+		listings = append(listings, listing)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ListListings: error reading rows: %w", err)
+	}
+
+	return listings, nil
+}
+
+func lockListing(ctx context.Context, tx pgx.Tx, id int) (domain.Listing, error) {
+	listing, err := scanListing(tx.QueryRow(ctx,
+		"SELECT "+listingColumns+" FROM market_listings WHERE id = $1 FOR UPDATE", id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Listing{}, appErrors.ErrListingNotFound
+		}
+		return domain.Listing{}, fmt.Errorf("could not get listing: %w", err)
+	}
+	return listing, nil
+}
+
+func scanListing(row pgx.Row) (domain.Listing, error) {
+	var listing domain.Listing
+	err := row.Scan(&listing.ID, &listing.Seller, &listing.Item, &listing.Variant, &listing.Quantity, &listing.Price,
+		&listing.Status, &listing.Buyer, &listing.CreatedAt, &listing.ClosedAt)
+	return listing, err
+}
@@ -2,12 +2,16 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
 
 	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
 	"github.com/Te8va/MerchStore/pkg/logger"
 )
 
@@ -20,6 +24,9 @@ func NewMerchService(pool *pgxpool.Pool) *MerchService {
 }
 
 func (r *MerchService) GetUserBalance(ctx context.Context, username string) (int, error) {
+	ctx, span := tracer.Start(ctx, "repository.GetUserBalance")
+	defer span.End()
+
 	var balance int
 	err := r.pool.QueryRow(ctx, "SELECT balance FROM users WHERE username = $1", username).Scan(&balance)
 	if err != nil {
@@ -28,36 +35,93 @@ func (r *MerchService) GetUserBalance(ctx context.Context, username string) (int
 	return balance, nil
 }
 
-func (r *MerchService) TransferCoins(ctx context.Context, fromUser, toUser string, amount int) error {
+// TransferCoins moves coins under a lock on both users. The balance and the
+// amount limits of policy are checked again after locking, since the
+// service checks run outside the transaction.
+func (r *MerchService) TransferCoins(ctx context.Context, transfer domain.Transfer, policy domain.TransferPolicy, since time.Time) error {
+	ctx, span := tracer.Start(ctx, "repository.TransferCoins")
+	defer span.End()
+
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("repository.TransferCoins: could not begin transaction: %w", err)
 	}
 	defer func() {
 		if err := tx.Rollback(ctx); err != nil && err != pgx.ErrTxClosed {
-			logger.Logger().Errorln("TransferCoins: failed to rollback transaction:", err)
+			logger.FromContext(ctx).Error("repository.TransferCoins: failed to rollback transaction", zap.Error(err))
 		}
 	}()
 
-	_, err = tx.Exec(ctx, "UPDATE users SET balance = balance - $1 WHERE username=$2", amount, fromUser)
+	_, err = tx.Exec(ctx, "SELECT 1 FROM users WHERE username IN ($1, $2) ORDER BY username FOR UPDATE", transfer.FromUser, transfer.ToUser)
+	if err != nil {
+		return fmt.Errorf("repository.TransferCoins: could not lock users: %w", err)
+	}
+
+	var balance int
+	err = tx.QueryRow(ctx, "SELECT balance FROM users WHERE username = $1", transfer.FromUser).Scan(&balance)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return appErrors.ErrUserNotFound
+		}
+		return fmt.Errorf("repository.TransferCoins: could not get balance: %w", err)
+	}
+	if balance < transfer.Amount {
+		return appErrors.ErrInsufficientBalance
+	}
+
+	if err := checkTransferCaps(ctx, tx, policy, transfer.FromUser, transfer.ToUser, transfer.Amount, since); err != nil {
+		var limitErr *appErrors.LimitError
+		if errors.As(err, &limitErr) {
+			return err
+		}
+		return fmt.Errorf("repository.TransferCoins: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE users SET balance = balance - $1 WHERE username=$2", transfer.Amount, transfer.FromUser)
 	if err != nil {
 		return fmt.Errorf("repository.TransferCoins: could not update sender balance: %w", err)
 	}
 
-	_, err = tx.Exec(ctx, "UPDATE users SET balance = balance + $1 WHERE username=$2", amount, toUser)
+	_, err = tx.Exec(ctx, "UPDATE users SET balance = balance + $1 WHERE username=$2", transfer.Amount, transfer.ToUser)
 	if err != nil {
 		return fmt.Errorf("repository.TransferCoins: could not update receiver balance: %w", err)
 	}
 
 	_, err = tx.Exec(ctx, `
-		INSERT INTO transactions (from_user, to_user, amount) 
-		VALUES ($1, $2, $3)`, fromUser, toUser, amount)
+		INSERT INTO transactions (from_user, to_user, amount, message, category)
+		VALUES ($1, $2, $3, $4, $5)`, transfer.FromUser, transfer.ToUser, transfer.Amount, transfer.Message, transfer.Category)
 	if err != nil {
 		return fmt.Errorf("repository.TransferCoins: could not insert transaction: %w", err)
 	}
 
+	message := fmt.Sprintf("%s sent you %d coins", transfer.FromUser, transfer.Amount)
+	if transfer.Message != "" {
+		message += ": " + transfer.Message
+	}
+	if err := notify(ctx, tx, transfer.ToUser, domain.NotificationCoinsReceived, message, ""); err != nil {
+		return fmt.Errorf("repository.TransferCoins: %w", err)
+	}
+
+	err = publishEvent(ctx, tx, transfer.ToUser, domain.EventTransfer, map[string]any{
+		"from": transfer.FromUser, "amount": transfer.Amount, "message": transfer.Message,
+	})
+	if err != nil {
+		return fmt.Errorf("repository.TransferCoins: %w", err)
+	}
+	if err := publishBalances(ctx, tx, transfer.FromUser, transfer.ToUser); err != nil {
+		return fmt.Errorf("repository.TransferCoins: %w", err)
+	}
+
+	err = enqueueOutbox(ctx, tx, domain.OutboxCoinsTransferred, map[string]any{
+		"from": transfer.FromUser, "to": transfer.ToUser, "amount": transfer.Amount,
+		"message": transfer.Message, "category": transfer.Category,
+	})
+	if err != nil {
+		return fmt.Errorf("repository.TransferCoins: %w", err)
+	}
+
 	if err := tx.Commit(ctx); err != nil {
-		logger.Logger().Errorln("TransferCoins: failed to commit transaction:", err)
+		logger.FromContext(ctx).Error("repository.TransferCoins: failed to commit transaction", zap.Error(err))
 		return fmt.Errorf("repository.TransferCoins: could not commit transaction: %w", err)
 	}
 
@@ -65,6 +129,9 @@ func (r *MerchService) TransferCoins(ctx context.Context, fromUser, toUser strin
 }
 
 func (r *MerchService) UpdateUserBalance(ctx context.Context, username string, newBalance int) error {
+	ctx, span := tracer.Start(ctx, "repository.UpdateUserBalance")
+	defer span.End()
+
 	_, err := r.pool.Exec(ctx, "UPDATE users SET balance = $1 WHERE username = $2", newBalance, username)
 	if err != nil {
 		return fmt.Errorf("repository.UpdateUserBalance: %w", err)
@@ -73,6 +140,9 @@ func (r *MerchService) UpdateUserBalance(ctx context.Context, username string, n
 }
 
 func (r *MerchService) GetMerchPrice(ctx context.Context, item string) (int, error) {
+	ctx, span := tracer.Start(ctx, "repository.GetMerchPrice")
+	defer span.End()
+
 	var price int
 	err := r.pool.QueryRow(ctx, "SELECT price FROM merch WHERE item_name = $1", item).Scan(&price)
 	if err != nil {
@@ -84,26 +154,247 @@ func (r *MerchService) GetMerchPrice(ctx context.Context, item string) (int, err
 	return price, nil
 }
 
-func (r *MerchService) SavePurchase(ctx context.Context, username, item string, price int) error {
-	var userId int
-	err := r.pool.QueryRow(ctx, "SELECT id FROM users WHERE username = $1", username).Scan(&userId)
+// GetPromotions returns the running promotions that apply to item: the
+// ones without a code and the one matching code, if any. Exhausted
+// promotions are returned too so the caller can tell them apart from
+// unknown codes.
+func (r *MerchService) GetPromotions(ctx context.Context, item, code string, at time.Time) ([]domain.Promotion, error) {
+	ctx, span := tracer.Start(ctx, "repository.GetPromotions")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+promotionColumns+`
+		FROM promotions
+		WHERE (item = $1 OR category = (SELECT category FROM merch WHERE item_name = $1))
+			AND (code IS NULL OR code = $2)
+			AND starts_at <= $3 AND (ends_at IS NULL OR ends_at > $3)`, item, code, at)
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetPromotions: could not retrieve promotions: %w", err)
+	}
+	defer rows.Close()
+
+	var promotions []domain.Promotion
+	for rows.Next() {
+		promotion, err := scanPromotion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.GetPromotions: could not scan promotion: %w", err)
+		}
+		promotions = append(promotions, promotion)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.GetPromotions: error reading rows: %w", err)
+	}
+
+	return promotions, nil
+}
+
+// SavePurchase charges the user and records the purchase in one
+// transaction. The balance and the item rules are re-checked under a row
+// lock so concurrent purchases cannot overdraw it or exceed a limit, and
+// the applied promotion is locked so its usage limits hold too.
+func (r *MerchService) SavePurchase(ctx context.Context, purchase domain.Purchase, now time.Time) (domain.Purchase, error) {
+	ctx, span := tracer.Start(ctx, "repository.SavePurchase")
+	defer span.End()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return purchase, fmt.Errorf("repository.SavePurchase: could not begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			logger.FromContext(ctx).Error("repository.SavePurchase: failed to rollback transaction", zap.Error(err))
+		}
+	}()
+
+	var balance int
+	err = tx.QueryRow(ctx, "SELECT balance FROM users WHERE username = $1 FOR UPDATE", purchase.Username).Scan(&balance)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return purchase, appErrors.ErrUserNotFound
+		}
+		return purchase, fmt.Errorf("repository.SavePurchase: could not get balance: %w", err)
+	}
+
+	total := purchase.PaidPrice * purchase.Quantity
+	if balance < total {
+		return purchase, appErrors.ErrInsufficientBalance
+	}
+
+	if err := checkItemRules(ctx, tx, purchase.Username, purchase.Item, purchase.Quantity, now); err != nil {
+		var ruleErr *appErrors.RuleError
+		if errors.As(err, &ruleErr) || errors.Is(err, appErrors.ErrUserNotFound) {
+			return purchase, err
+		}
+		return purchase, fmt.Errorf("repository.SavePurchase: %w", err)
+	}
+
+	if purchase.PromotionID != 0 {
+		if err := usePromotion(ctx, tx, purchase.PromotionID, purchase.Username); err != nil {
+			if errors.Is(err, appErrors.ErrInvalidPromoCode) ||
+				errors.Is(err, appErrors.ErrPromoCodeExhausted) ||
+				errors.Is(err, appErrors.ErrPromoUserLimit) {
+				return purchase, err
+			}
+			return purchase, fmt.Errorf("repository.SavePurchase: %w", err)
+		}
+	}
+
+	purchase.Variant, err = takeStock(ctx, tx, purchase.Item, purchase.Variant, purchase.Quantity)
+	if err != nil {
+		if isStockError(err) {
+			return purchase, err
+		}
+		return purchase, fmt.Errorf("repository.SavePurchase: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE users SET balance = balance - $1 WHERE username = $2", total, purchase.Username)
+	if err != nil {
+		return purchase, fmt.Errorf("repository.SavePurchase: could not update balance: %w", err)
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO purchases (username, item, variant, price, paid_price, discount, promotion_id, promo_code, quantity)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, 0), $8, $9)
+		RETURNING id, purchase_date`,
+		purchase.Username, purchase.Item, purchase.Variant, purchase.UnitPrice, purchase.PaidPrice, purchase.Discount,
+		purchase.PromotionID, purchase.PromoCode, purchase.Quantity).
+		Scan(&purchase.ID, &purchase.PurchasedAt)
+	if err != nil {
+		return purchase, fmt.Errorf("repository.SavePurchase: could not insert purchase: %w", err)
+	}
+
+	if err := addToInventory(ctx, tx, purchase.Username, purchase.Item, purchase.Variant, purchase.Quantity); err != nil {
+		return purchase, fmt.Errorf("repository.SavePurchase: could not update inventory: %w", err)
+	}
+
+	if err := publishEvent(ctx, tx, purchase.Username, domain.EventPurchase, purchase); err != nil {
+		return purchase, fmt.Errorf("repository.SavePurchase: %w", err)
+	}
+	if err := publishBalances(ctx, tx, purchase.Username); err != nil {
+		return purchase, fmt.Errorf("repository.SavePurchase: %w", err)
+	}
+	if err := enqueueOutbox(ctx, tx, domain.OutboxMerchPurchased, purchase); err != nil {
+		return purchase, fmt.Errorf("repository.SavePurchase: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("repository.SavePurchase: failed to commit transaction", zap.Error(err))
+		return purchase, fmt.Errorf("repository.SavePurchase: could not commit transaction: %w", err)
+	}
+
+	return purchase, nil
+}
+
+// SaveGift charges the sender and puts the item into the recipient's
+// inventory in one transaction.
+func (r *MerchService) SaveGift(ctx context.Context, gift domain.Gift, price int, now time.Time) error {
+	ctx, span := tracer.Start(ctx, "repository.SaveGift")
+	defer span.End()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("repository.SaveGift: could not begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			logger.FromContext(ctx).Error("repository.SaveGift: failed to rollback transaction", zap.Error(err))
+		}
+	}()
+
+	_, err = tx.Exec(ctx, "SELECT 1 FROM users WHERE username IN ($1, $2) ORDER BY username FOR UPDATE", gift.FromUser, gift.ToUser)
+	if err != nil {
+		return fmt.Errorf("repository.SaveGift: could not lock users: %w", err)
+	}
+
+	var balance int
+	err = tx.QueryRow(ctx, "SELECT balance FROM users WHERE username = $1", gift.FromUser).Scan(&balance)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return appErrors.ErrUserNotFound
+		}
+		return fmt.Errorf("repository.SaveGift: could not get balance: %w", err)
+	}
+
+	total := price * gift.Quantity
+	if balance < total {
+		return appErrors.ErrInsufficientBalance
+	}
+
+	if err := checkItemRules(ctx, tx, gift.ToUser, gift.Item, gift.Quantity, now); err != nil {
+		var ruleErr *appErrors.RuleError
+		if errors.As(err, &ruleErr) || errors.Is(err, appErrors.ErrUserNotFound) {
+			return err
+		}
+		return fmt.Errorf("repository.SaveGift: %w", err)
+	}
+
+	gift.Variant, err = takeStock(ctx, tx, gift.Item, gift.Variant, gift.Quantity)
+	if err != nil {
+		if isStockError(err) {
+			return err
+		}
+		return fmt.Errorf("repository.SaveGift: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE users SET balance = balance - $1 WHERE username = $2", total, gift.FromUser)
+	if err != nil {
+		return fmt.Errorf("repository.SaveGift: could not update balance: %w", err)
+	}
+
+	if err := addToInventory(ctx, tx, gift.ToUser, gift.Item, gift.Variant, gift.Quantity); err != nil {
+		if errors.Is(err, appErrors.ErrUserNotFound) {
+			return err
+		}
+		return fmt.Errorf("repository.SaveGift: could not update inventory: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO transactions (from_user, to_user, amount, kind, message, item, variant, quantity)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		gift.FromUser, gift.ToUser, total, domain.TransactionKindGift, gift.Message, gift.Item, gift.Variant, gift.Quantity)
 	if err != nil {
-		return fmt.Errorf("repository.SavePurchase: could not find user ID: %w", err)
+		return fmt.Errorf("repository.SaveGift: could not insert transaction: %w", err)
 	}
 
-	_, err = r.pool.Exec(ctx, `
-		INSERT INTO inventory (user_id, item_name, quantity) 
-		VALUES ($1, $2, 1) 
-		ON CONFLICT (user_id, item_name) 
-		DO UPDATE SET quantity = inventory.quantity + 1`, userId, item)
+	message := fmt.Sprintf("%s gave you %s", gift.FromUser, itemLabel(gift.Item, gift.Variant, gift.Quantity))
+	if gift.Message != "" {
+		message += ": " + gift.Message
+	}
+	if err := notify(ctx, tx, gift.ToUser, domain.NotificationGiftReceived, message, gift.Item); err != nil {
+		return fmt.Errorf("repository.SaveGift: %w", err)
+	}
+
+	err = publishEvent(ctx, tx, gift.ToUser, domain.EventGift, map[string]any{
+		"from": gift.FromUser, "item": gift.Item, "variant": gift.Variant, "quantity": gift.Quantity, "message": gift.Message,
+	})
 	if err != nil {
-		return fmt.Errorf("repository.SavePurchase: could not update inventory: %w", err)
+		return fmt.Errorf("repository.SaveGift: %w", err)
+	}
+	if err := publishBalances(ctx, tx, gift.FromUser); err != nil {
+		return fmt.Errorf("repository.SaveGift: %w", err)
+	}
+
+	err = enqueueOutbox(ctx, tx, domain.OutboxMerchGifted, map[string]any{
+		"from": gift.FromUser, "to": gift.ToUser, "item": gift.Item, "variant": gift.Variant,
+		"quantity": gift.Quantity, "amount": total, "message": gift.Message,
+	})
+	if err != nil {
+		return fmt.Errorf("repository.SaveGift: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("repository.SaveGift: failed to commit transaction", zap.Error(err))
+		return fmt.Errorf("repository.SaveGift: could not commit transaction: %w", err)
 	}
 
 	return nil
 }
 
 func (r *MerchService) UserExists(ctx context.Context, username string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "repository.UserExists")
+	defer span.End()
+
 	var exists bool
 	err := r.pool.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE username = $1)", username).Scan(&exists)
 	if err != nil {
@@ -113,6 +404,9 @@ func (r *MerchService) UserExists(ctx context.Context, username string) (bool, e
 }
 
 func (r *MerchService) GetUserInventory(ctx context.Context, username string) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "repository.GetUserInventory")
+	defer span.End()
+
 	var inventory []string
 
 	var userId int
@@ -142,11 +436,18 @@ func (r *MerchService) GetUserInventory(ctx context.Context, username string) ([
 	return inventory, nil
 }
 
+// GetUserTransactionHistory returns the user-to-user transfers shown in
+// /api/info. Grants, purchases, gifts and the other kinds are only exposed
+// through GetUserHistory.
 func (r *MerchService) GetUserTransactionHistory(ctx context.Context, username string) (domain.CoinHistory, error) {
+	ctx, span := tracer.Start(ctx, "repository.GetUserTransactionHistory")
+	defer span.End()
+
 	var history domain.CoinHistory
 
 	rows, err := r.pool.Query(ctx, `
-		SELECT from_user, amount FROM transactions WHERE to_user = $1`, username)
+		SELECT from_user, amount, message, category FROM transactions WHERE to_user = $1 AND kind = $2`,
+		username, domain.TransactionKindTransfer)
 	if err != nil {
 		return history, fmt.Errorf("repository.GetUserTransactionHistory: could not find user ID: %w", err)
 	}
@@ -154,7 +455,7 @@ func (r *MerchService) GetUserTransactionHistory(ctx context.Context, username s
 
 	for rows.Next() {
 		var received domain.ReceivedTransaction
-		err := rows.Scan(&received.FromUser, &received.Amount)
+		err := rows.Scan(&received.FromUser, &received.Amount, &received.Message, &received.Category)
 		if err != nil {
 			return history, fmt.Errorf("repository.GetUserTransactionHistory: could not scan received transaction: %w", err)
 		}
@@ -165,10 +466,10 @@ func (r *MerchService) GetUserTransactionHistory(ctx context.Context, username s
 	}
 
 	rows, err = r.pool.Query(ctx, `
-		SELECT to_user, amount
+		SELECT to_user, amount, message, category
 		FROM transactions
-		WHERE from_user = $1
-	`, username)
+		WHERE from_user = $1 AND kind = $2
+	`, username, domain.TransactionKindTransfer)
 	if err != nil {
 		return history, fmt.Errorf("repository.GetUserTransactionHistory: could not get sent transactions: %w", err)
 	}
@@ -176,7 +477,7 @@ func (r *MerchService) GetUserTransactionHistory(ctx context.Context, username s
 
 	for rows.Next() {
 		var sent domain.SentTransaction
-		err := rows.Scan(&sent.ToUser, &sent.Amount)
+		err := rows.Scan(&sent.ToUser, &sent.Amount, &sent.Message, &sent.Category)
 		if err != nil {
 			return history, fmt.Errorf("repository.GetUserTransactionHistory: could not scan sent transaction: %w", err)
 		}
@@ -190,13 +491,17 @@ func (r *MerchService) GetUserTransactionHistory(ctx context.Context, username s
 }
 
 func (r *MerchService) GetUserPurchases(ctx context.Context, username string) ([]domain.InventoryItem, error) {
+	ctx, span := tracer.Start(ctx, "repository.GetUserPurchases")
+	defer span.End()
+
 	var inventory []domain.InventoryItem
 
 	rows, err := r.pool.Query(ctx, `
-		SELECT item_name, quantity 
-		FROM inventory 
+		SELECT item_name, variant, quantity
+		FROM inventory
 		INNER JOIN users ON inventory.user_id = users.id
 		WHERE users.username = $1
+		ORDER BY item_name, variant
 	`, username)
 	if err != nil {
 		return nil, fmt.Errorf("repository.GetUserPurchases: could not retrieve purchases: %w", err)
@@ -205,7 +510,7 @@ func (r *MerchService) GetUserPurchases(ctx context.Context, username string) ([
 
 	for rows.Next() {
 		var item domain.InventoryItem
-		if err := rows.Scan(&item.Name, &item.Quantity); err != nil {
+		if err := rows.Scan(&item.Name, &item.Variant, &item.Quantity); err != nil {
 			return nil, fmt.Errorf("repository.GetUserPurchases: could not scan purchase: %w", err)
 		}
 		inventory = append(inventory, item)
@@ -217,3 +522,200 @@ func (r *MerchService) GetUserPurchases(ctx context.Context, username string) ([
 
 	return inventory, nil
 }
+
+func (r *MerchService) GetUserHistory(ctx context.Context, username string, filter domain.HistoryFilter) ([]domain.HistoryEntry, error) {
+	ctx, span := tracer.Start(ctx, "repository.GetUserHistory")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, kind, COALESCE(from_user, ''), to_user, amount, message, category, reason, item, variant, quantity, created_at
+		FROM transactions
+		WHERE (from_user = $1 OR to_user = $1)
+			AND ($2::text = '' OR category = $2)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4`, username, filter.Category, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("repository.GetUserHistory: could not retrieve history: %w", err)
+	}
+	defer rows.Close()
+
+	history := []domain.HistoryEntry{}
+	for rows.Next() {
+		var entry domain.HistoryEntry
+		err := rows.Scan(&entry.ID, &entry.Kind, &entry.FromUser, &entry.ToUser, &entry.Amount,
+			&entry.Message, &entry.Category, &entry.Reason, &entry.Item, &entry.Variant, &entry.Quantity, &entry.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("repository.GetUserHistory: could not scan entry: %w", err)
+		}
+
+		entry.Direction = domain.DirectionIncoming
+		if entry.FromUser == username {
+			entry.Direction = domain.DirectionOutgoing
+		}
+
+		history = append(history, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.GetUserHistory: error reading rows: %w", err)
+	}
+
+	return history, nil
+}
+
+func (r *MerchService) GetUserCreatedAt(ctx context.Context, username string) (time.Time, error) {
+	ctx, span := tracer.Start(ctx, "repository.GetUserCreatedAt")
+	defer span.End()
+
+	var createdAt time.Time
+	err := r.pool.QueryRow(ctx, "SELECT created_at FROM users WHERE username = $1", username).Scan(&createdAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("repository.GetUserCreatedAt: %w", err)
+	}
+	return createdAt, nil
+}
+
+func (r *MerchService) GetTransferTotals(ctx context.Context, fromUser, toUser string, since time.Time) (domain.TransferTotals, error) {
+	ctx, span := tracer.Start(ctx, "repository.GetTransferTotals")
+	defer span.End()
+
+	var totals domain.TransferTotals
+	err := r.pool.QueryRow(ctx, transferTotalsQuery, fromUser, toUser, since, domain.TransactionKindTransfer, domain.TransactionKindSale).
+		Scan(&totals.Outgoing, &totals.ToRecipient)
+	if err != nil {
+		return totals, fmt.Errorf("repository.GetTransferTotals: %w", err)
+	}
+	return totals, nil
+}
+
+// transferTotalsQuery sums the coins $1 moved to other users since $3, in
+// total and to $2. Market purchases count as well, otherwise an overpriced
+// listing would move coins past the daily caps.
+const transferTotalsQuery = `
+	SELECT COALESCE(SUM(amount), 0), COALESCE(SUM(amount) FILTER (WHERE to_user = $2), 0)
+	FROM transactions
+	WHERE from_user = $1 AND kind IN ($4, $5) AND created_at >= $3`
+
+// checkTransferCaps applies the amount limits of policy to amount coins
+// moving from fromUser to toUser. The caller must hold the lock on the
+// fromUser row, so concurrent transfers cannot both pass the daily caps.
+func checkTransferCaps(ctx context.Context, tx pgx.Tx, policy domain.TransferPolicy, fromUser, toUser string, amount int, since time.Time) error {
+	if policy.MaxPerTransfer > 0 && amount > policy.MaxPerTransfer {
+		return &appErrors.LimitError{Err: appErrors.ErrTransferLimitExceeded, Remaining: policy.MaxPerTransfer}
+	}
+
+	if policy.DailyOutgoingCap <= 0 && policy.DailyPerRecipientCap <= 0 {
+		return nil
+	}
+
+	var totals domain.TransferTotals
+	err := tx.QueryRow(ctx, transferTotalsQuery, fromUser, toUser, since, domain.TransactionKindTransfer, domain.TransactionKindSale).
+		Scan(&totals.Outgoing, &totals.ToRecipient)
+	if err != nil {
+		return fmt.Errorf("could not get transfer totals: %w", err)
+	}
+
+	if policy.DailyOutgoingCap > 0 && totals.Outgoing+amount > policy.DailyOutgoingCap {
+		return &appErrors.LimitError{Err: appErrors.ErrDailyLimitExceeded, Remaining: max(policy.DailyOutgoingCap-totals.Outgoing, 0)}
+	}
+
+	if policy.DailyPerRecipientCap > 0 && totals.ToRecipient+amount > policy.DailyPerRecipientCap {
+		return &appErrors.LimitError{Err: appErrors.ErrRecipientLimitExceeded, Remaining: max(policy.DailyPerRecipientCap-totals.ToRecipient, 0)}
+	}
+
+	return nil
+}
+
+// GetItemRule returns the purchase rule for item. Items without a rule get
+// an empty rule, which allows every purchase.
+func (r *MerchService) GetItemRule(ctx context.Context, item string) (domain.ItemRule, error) {
+	ctx, span := tracer.Start(ctx, "repository.GetItemRule")
+	defer span.End()
+
+	rule, err := scanItemRule(r.pool.QueryRow(ctx, "SELECT "+itemRuleColumns+" FROM item_rules WHERE item_name = $1", item))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ItemRule{Item: item}, nil
+		}
+		return domain.ItemRule{}, fmt.Errorf("repository.GetItemRule: %w", err)
+	}
+	return rule, nil
+}
+
+// purchaseStatsQuery loads the item rule inputs for $1 and item $2. Units
+// received as gifts, through item transfers or bought on the market count
+// as bought, so none of those paths can bypass a limit.
+const purchaseStatsQuery = `
+	SELECT u.role, u.created_at,
+		COALESCE(p.bought, 0) + COALESCE(r.bought, 0), COALESCE(p.bought_since, 0) + COALESCE(r.bought_since, 0),
+		EXISTS (SELECT 1 FROM transactions t WHERE t.to_user = u.username AND t.kind = $4 AND t.from_user <> u.username)
+	FROM users u
+	LEFT JOIN LATERAL (
+		SELECT SUM(quantity - refunded_quantity) AS bought,
+			SUM(quantity - refunded_quantity) FILTER (WHERE purchase_date >= $3) AS bought_since
+		FROM purchases
+		WHERE username = u.username AND item = $2
+	) p ON TRUE
+	LEFT JOIN LATERAL (
+		SELECT SUM(quantity) AS bought,
+			SUM(quantity) FILTER (WHERE created_at >= $3) AS bought_since
+		FROM transactions
+		WHERE item = $2 AND (
+			(to_user = u.username AND kind IN ($5, $6)) OR
+			(from_user = u.username AND kind = $7))
+	) r ON TRUE
+	WHERE u.username = $1`
+
+// purchaseStatsArgs returns the arguments of purchaseStatsQuery.
+func purchaseStatsArgs(username, item string, since time.Time) []any {
+	return []any{username, item, since, domain.TransactionKindTransfer,
+		domain.TransactionKindGift, domain.TransactionKindItem, domain.TransactionKindSale}
+}
+
+func (r *MerchService) GetPurchaseStats(ctx context.Context, username, item string, since time.Time) (domain.PurchaseStats, error) {
+	ctx, span := tracer.Start(ctx, "repository.GetPurchaseStats")
+	defer span.End()
+
+	stats, err := scanPurchaseStats(r.pool.QueryRow(ctx, purchaseStatsQuery, purchaseStatsArgs(username, item, since)...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return stats, appErrors.ErrUserNotFound
+		}
+		return stats, fmt.Errorf("repository.GetPurchaseStats: %w", err)
+	}
+	return stats, nil
+}
+
+func scanPurchaseStats(row pgx.Row) (domain.PurchaseStats, error) {
+	var stats domain.PurchaseStats
+	err := row.Scan(&stats.Role, &stats.CreatedAt, &stats.Bought, &stats.BoughtSince, &stats.ReceivedCoins)
+	return stats, err
+}
+
+// checkItemRules applies the item rule for item to username, who is about
+// to get quantity more units. Callers run it after locking the username
+// row, so purchases and gifts for the same user are checked one at a time
+// and each sees the units the previous one added.
+func checkItemRules(ctx context.Context, tx pgx.Tx, username, item string, quantity int, now time.Time) error {
+	rule, err := scanItemRule(tx.QueryRow(ctx, "SELECT "+itemRuleColumns+" FROM item_rules WHERE item_name = $1", item))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("could not get item rule: %w", err)
+	}
+	if !rule.Active() {
+		return nil
+	}
+
+	stats, err := scanPurchaseStats(tx.QueryRow(ctx, purchaseStatsQuery,
+		purchaseStatsArgs(username, item, domain.PeriodStart(rule.Period, now))...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return appErrors.ErrUserNotFound
+		}
+		return fmt.Errorf("could not get purchase stats: %w", err)
+	}
+
+	return rule.Check(stats, quantity, now)
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -35,3 +37,21 @@ func TestMigrations(t *testing.T) {
 	err = ApplyMigrations(m)
 	require.Error(t, err)
 }
+
+func TestLatestMigrationVersion(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"1_init.up.sql", "12_add_column.up.sql", "3_other.up.sql", "13_broken.down.sql", "README.md"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o644))
+	}
+
+	version, err := LatestMigrationVersion(dir)
+	require.NoError(t, err)
+	require.Equal(t, uint(12), version)
+
+	_, err = LatestMigrationVersion(t.TempDir())
+	require.Error(t, err)
+
+	version, err = LatestMigrationVersion("../../migrations")
+	require.NoError(t, err)
+	require.Positive(t, version)
+}
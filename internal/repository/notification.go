@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+)
+
+const notificationColumns = "id, kind, message, item, created_at, read_at"
+
+type NotificationService struct {
+	pool *pgxpool.Pool
+}
+
+func NewNotificationService(pool *pgxpool.Pool) *NotificationService {
+	return &NotificationService{pool: pool}
+}
+
+func (r *NotificationService) ListNotifications(ctx context.Context, username string, filter domain.NotificationFilter) ([]domain.Notification, error) {
+	ctx, span := tracer.Start(ctx, "repository.ListNotifications")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+notificationColumns+`
+		FROM notifications
+		WHERE username = $1 AND (NOT $2 OR read_at IS NULL)
+		ORDER BY id DESC
+		LIMIT $3 OFFSET $4`, username, filter.UnreadOnly, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListNotifications: could not retrieve notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []domain.Notification{}
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListNotifications: could not scan notification: %w", err)
+		}
+		notifications = append(notifications, notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ListNotifications: error reading rows: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (r *NotificationService) CountUnread(ctx context.Context, username string) (int, error) {
+	ctx, span := tracer.Start(ctx, "repository.CountUnread")
+	defer span.End()
+
+	var unread int
+	err := r.pool.QueryRow(ctx, "SELECT COUNT(*) FROM notifications WHERE username = $1 AND read_at IS NULL", username).
+		Scan(&unread)
+	if err != nil {
+		return 0, fmt.Errorf("repository.CountUnread: %w", err)
+	}
+	return unread, nil
+}
+
+// MarkRead marks the given notifications of username as read, or all of
+// them when ids is empty, and returns how many were unread before.
+func (r *NotificationService) MarkRead(ctx context.Context, username string, ids []int64, at time.Time) (int, error) {
+	ctx, span := tracer.Start(ctx, "repository.MarkRead")
+	defer span.End()
+
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE notifications SET read_at = $3
+		WHERE username = $1 AND read_at IS NULL AND (cardinality($2::bigint[]) = 0 OR id = ANY($2))`,
+		username, ids, at)
+	if err != nil {
+		return 0, fmt.Errorf("repository.MarkRead: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// notify adds a notification to the inbox of username as part of tx, so
+// that it is only delivered when the event itself is committed.
+func notify(ctx context.Context, tx pgx.Tx, username, kind, message, item string) error {
+	_, err := tx.Exec(ctx, "INSERT INTO notifications (username, kind, message, item) VALUES ($1, $2, $3, $4)",
+		username, kind, message, item)
+	if err != nil {
+		return fmt.Errorf("could not insert notification: %w", err)
+	}
+	return nil
+}
+
+// itemLabel describes quantity units of an item for notification messages.
+func itemLabel(item, variant string, quantity int) string {
+	if variant != "" {
+		item = fmt.Sprintf("%s (%s)", item, variant)
+	}
+	return fmt.Sprintf("%d x %s", quantity, item)
+}
+
+func scanNotification(row pgx.Row) (domain.Notification, error) {
+	var notification domain.Notification
+	err := row.Scan(&notification.ID, &notification.Kind, &notification.Message, &notification.Item,
+		&notification.CreatedAt, &notification.ReadAt)
+	return notification, err
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// outboxDeliveries queues every row of the events CTE for the active
+// webhooks subscribed to its type. It completes a WITH events AS (...) clause.
+const outboxDeliveries = `
+	INSERT INTO webhook_deliveries (webhook_id, event_id)
+	SELECT webhooks.id, events.id
+	FROM events
+	JOIN webhooks ON webhooks.active
+		AND (cardinality(webhooks.event_types) = 0 OR events.event_type = ANY(webhooks.event_types))`
+
+// enqueueOutbox records an integration event as part of tx, so webhooks only
+// ever hear about changes that were committed.
+func enqueueOutbox(ctx context.Context, tx pgx.Tx, eventType string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("could not encode outbox event: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		WITH events AS (
+			INSERT INTO outbox_events (event_type, payload)
+			VALUES ($1, $2)
+			RETURNING id, event_type
+		)`+outboxDeliveries, eventType, data)
+	if err != nil {
+		return fmt.Errorf("could not insert outbox event: %w", err)
+	}
+	return nil
+}
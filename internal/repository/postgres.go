@@ -23,6 +23,8 @@ func GetPgxPool(DSN string) (*pgxpool.Pool, error) {
 		return nil, fmt.Errorf("repository.GetPgxPool: %w", err)
 	}
 
+	config.ConnConfig.Tracer = queryTracer{}
+
 	pool, err := pgxpool.NewWithConfig(context.Background(), config)
 	if err != nil {
 		return nil, fmt.Errorf("repository.GetPgxPool: %w", err)
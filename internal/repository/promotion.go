@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+)
+
+const promotionColumns = `id, name, COALESCE(item, ''), COALESCE(category, ''), discount_type, discount_value,
+	COALESCE(code, ''), max_uses, per_user_limit, uses, starts_at, ends_at, created_at`
+
+type PromotionService struct {
+	pool *pgxpool.Pool
+}
+
+func NewPromotionService(pool *pgxpool.Pool) *PromotionService {
+	return &PromotionService{pool: pool}
+}
+
+func (r *PromotionService) CreatePromotion(ctx context.Context, promotion domain.Promotion) (domain.Promotion, error) {
+	ctx, span := tracer.Start(ctx, "repository.CreatePromotion")
+	defer span.End()
+
+	created, err := scanPromotion(r.pool.QueryRow(ctx, `
+		INSERT INTO promotions (name, item, category, discount_type, discount_value, code, max_uses, per_user_limit, starts_at, ends_at)
+		VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), $4, $5, NULLIF($6, ''), $7, $8, $9, $10)
+		RETURNING `+promotionColumns,
+		promotion.Name, promotion.Item, promotion.Category, promotion.DiscountType, promotion.DiscountValue,
+		promotion.Code, promotion.MaxUses, promotion.PerUserLimit, promotion.StartsAt, promotion.EndsAt))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return domain.Promotion{}, appErrors.ErrPromoCodeTaken
+		}
+		return domain.Promotion{}, fmt.Errorf("repository.CreatePromotion: could not insert promotion: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *PromotionService) ListPromotions(ctx context.Context, limit, offset int) ([]domain.Promotion, error) {
+	ctx, span := tracer.Start(ctx, "repository.ListPromotions")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+promotionColumns+`
+		FROM promotions
+		ORDER BY created_at DESC, id DESC
+		LIMIT $1 OFFSET $2`, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListPromotions: could not retrieve promotions: %w", err)
+	}
+	defer rows.Close()
+
+	promotions := []domain.Promotion{}
+	for rows.Next() {
+		promotion, err := scanPromotion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListPromotions: could not scan promotion: %w", err)
+		}
+		promotions = append(promotions, promotion)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ListPromotions: error reading rows: %w", err)
+	}
+
+	return promotions, nil
+}
+
+// EndPromotion stops a promotion at the given time. Promotions that have
+// already ended keep their original end.
+func (r *PromotionService) EndPromotion(ctx context.Context, id int, at time.Time) (domain.Promotion, error) {
+	ctx, span := tracer.Start(ctx, "repository.EndPromotion")
+	defer span.End()
+
+	promotion, err := scanPromotion(r.pool.QueryRow(ctx, `
+		UPDATE promotions SET ends_at = LEAST(COALESCE(ends_at, $2), $2)
+		WHERE id = $1
+		RETURNING `+promotionColumns, id, at))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Promotion{}, appErrors.ErrPromotionNotFound
+		}
+		return domain.Promotion{}, fmt.Errorf("repository.EndPromotion: could not update promotion: %w", err)
+	}
+
+	return promotion, nil
+}
+
+// usePromotion counts one use of the promotion for username, enforcing the
+// total and per-user limits under a row lock. Fully refunded purchases do
+// not count towards the per-user limit; see releasePromotion.
+func usePromotion(ctx context.Context, tx pgx.Tx, id int, username string) error {
+	var maxUses, perUserLimit, uses int
+	err := tx.QueryRow(ctx, `
+		SELECT max_uses, per_user_limit, uses
+		FROM promotions
+		WHERE id = $1 AND starts_at <= CURRENT_TIMESTAMP AND (ends_at IS NULL OR ends_at > CURRENT_TIMESTAMP)
+		FOR UPDATE`, id).Scan(&maxUses, &perUserLimit, &uses)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return appErrors.ErrInvalidPromoCode
+		}
+		return fmt.Errorf("usePromotion: could not get promotion: %w", err)
+	}
+
+	if maxUses > 0 && uses >= maxUses {
+		return appErrors.ErrPromoCodeExhausted
+	}
+
+	if perUserLimit > 0 {
+		var used int
+		err = tx.QueryRow(ctx, `
+			SELECT COUNT(*) FROM purchases
+			WHERE promotion_id = $1 AND username = $2 AND refunded_quantity < quantity`, id, username).Scan(&used)
+		if err != nil {
+			return fmt.Errorf("usePromotion: could not count uses: %w", err)
+		}
+		if used >= perUserLimit {
+			return appErrors.ErrPromoUserLimit
+		}
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE promotions SET uses = uses + 1 WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("usePromotion: could not update promotion: %w", err)
+	}
+
+	return nil
+}
+
+// releasePromotion gives back the use a purchase took from the promotion.
+// It is called when the last unit of that purchase is refunded.
+func releasePromotion(ctx context.Context, tx pgx.Tx, id int) error {
+	_, err := tx.Exec(ctx, "UPDATE promotions SET uses = uses - 1 WHERE id = $1 AND uses > 0", id)
+	if err != nil {
+		return fmt.Errorf("releasePromotion: could not update promotion: %w", err)
+	}
+	return nil
+}
+
+func scanPromotion(row pgx.Row) (domain.Promotion, error) {
+	var promotion domain.Promotion
+	err := row.Scan(&promotion.ID, &promotion.Name, &promotion.Item, &promotion.Category, &promotion.DiscountType,
+		&promotion.DiscountValue, &promotion.Code, &promotion.MaxUses, &promotion.PerUserLimit, &promotion.Uses,
+		&promotion.StartsAt, &promotion.EndsAt, &promotion.CreatedAt)
+	return promotion, err
+}
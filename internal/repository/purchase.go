@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/pkg/logger"
+)
+
+type PurchaseService struct {
+	pool *pgxpool.Pool
+}
+
+func NewPurchaseService(pool *pgxpool.Pool) *PurchaseService {
+	return &PurchaseService{pool: pool}
+}
+
+func (r *PurchaseService) ListPurchases(ctx context.Context, username string, limit, offset int) ([]domain.Purchase, error) {
+	ctx, span := tracer.Start(ctx, "repository.ListPurchases")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, username, item, variant, price, paid_price, discount, COALESCE(promotion_id, 0), promo_code,
+			quantity, refunded_quantity, purchase_date, refunded_at
+		FROM purchases
+		WHERE username = $1
+		ORDER BY purchase_date DESC, id DESC
+		LIMIT $2 OFFSET $3`, username, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListPurchases: could not retrieve purchases: %w", err)
+	}
+	defer rows.Close()
+
+	purchases := []domain.Purchase{}
+	for rows.Next() {
+		var purchase domain.Purchase
+		err := rows.Scan(&purchase.ID, &purchase.Username, &purchase.Item, &purchase.Variant, &purchase.UnitPrice, &purchase.PaidPrice,
+			&purchase.Discount, &purchase.PromotionID, &purchase.PromoCode, &purchase.Quantity,
+			&purchase.RefundedQuantity, &purchase.PurchasedAt, &purchase.RefundedAt)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListPurchases: could not scan purchase: %w", err)
+		}
+		purchases = append(purchases, purchase)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ListPurchases: error reading rows: %w", err)
+	}
+
+	return purchases, nil
+}
+
+// RefundPurchase returns the price actually paid for the refunded units,
+// takes them out of the inventory and marks the purchase row, all in one
+// transaction. Refunding the last unit also gives the promotion use back.
+func (r *PurchaseService) RefundPurchase(ctx context.Context, req domain.RefundRequest) (domain.Refund, error) {
+	ctx, span := tracer.Start(ctx, "repository.RefundPurchase")
+	defer span.End()
+
+	refund := domain.Refund{PurchaseID: req.PurchaseID}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return refund, fmt.Errorf("repository.RefundPurchase: could not begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			logger.FromContext(ctx).Error("repository.RefundPurchase: failed to rollback transaction", zap.Error(err))
+		}
+	}()
+
+	var purchase domain.Purchase
+	err = tx.QueryRow(ctx, `
+		SELECT username, item, variant, paid_price, COALESCE(promotion_id, 0), quantity, refunded_quantity, purchase_date
+		FROM purchases
+		WHERE id = $1
+		FOR UPDATE`, req.PurchaseID).
+		Scan(&purchase.Username, &purchase.Item, &purchase.Variant, &purchase.PaidPrice, &purchase.PromotionID,
+			&purchase.Quantity, &purchase.RefundedQuantity, &purchase.PurchasedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return refund, appErrors.ErrPurchaseNotFound
+		}
+		return refund, fmt.Errorf("repository.RefundPurchase: could not get purchase: %w", err)
+	}
+
+	if req.Username != "" && purchase.Username != req.Username {
+		return refund, appErrors.ErrPurchaseNotFound
+	}
+	if purchase.PurchasedAt.Before(req.PurchasedAfter) {
+		return refund, appErrors.ErrRefundWindowExpired
+	}
+
+	left := purchase.Quantity - purchase.RefundedQuantity
+	if left == 0 {
+		return refund, appErrors.ErrAlreadyRefunded
+	}
+
+	quantity := req.Quantity
+	if quantity == 0 {
+		quantity = left
+	}
+	if quantity > left {
+		return refund, appErrors.ErrRefundQuantityExceeded
+	}
+
+	if err := removeFromInventory(ctx, tx, purchase.Username, purchase.Item, purchase.Variant, quantity); err != nil {
+		if errors.Is(err, appErrors.ErrItemNotInInventory) {
+			return refund, err
+		}
+		return refund, fmt.Errorf("repository.RefundPurchase: %w", err)
+	}
+
+	if err := returnStock(ctx, tx, purchase.Item, purchase.Variant, quantity); err != nil {
+		return refund, fmt.Errorf("repository.RefundPurchase: %w", err)
+	}
+
+	amount := purchase.PaidPrice * quantity
+
+	_, err = tx.Exec(ctx, "UPDATE users SET balance = balance + $1 WHERE username = $2", amount, purchase.Username)
+	if err != nil {
+		return refund, fmt.Errorf("repository.RefundPurchase: could not update balance: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE purchases SET refunded_quantity = refunded_quantity + $1, refunded_at = CURRENT_TIMESTAMP
+		WHERE id = $2`, quantity, req.PurchaseID)
+	if err != nil {
+		return refund, fmt.Errorf("repository.RefundPurchase: could not update purchase: %w", err)
+	}
+
+	if purchase.PromotionID != 0 && quantity == left {
+		if err := releasePromotion(ctx, tx, purchase.PromotionID); err != nil {
+			return refund, fmt.Errorf("repository.RefundPurchase: %w", err)
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO transactions (from_user, to_user, amount, kind, reason)
+		VALUES ($1, $2, $3, $4, $5)`,
+		domain.SystemAccount, purchase.Username, amount, domain.TransactionKindRefund, req.Reason)
+	if err != nil {
+		return refund, fmt.Errorf("repository.RefundPurchase: could not insert transaction: %w", err)
+	}
+
+	message := fmt.Sprintf("%d coins refunded for %s", amount, itemLabel(purchase.Item, purchase.Variant, quantity))
+	if err := notify(ctx, tx, purchase.Username, domain.NotificationRefund, message, purchase.Item); err != nil {
+		return refund, fmt.Errorf("repository.RefundPurchase: %w", err)
+	}
+	if err := publishBalances(ctx, tx, purchase.Username); err != nil {
+		return refund, fmt.Errorf("repository.RefundPurchase: %w", err)
+	}
+
+	err = enqueueOutbox(ctx, tx, domain.OutboxMerchRefunded, map[string]any{
+		"purchaseId": req.PurchaseID, "username": purchase.Username, "item": purchase.Item, "variant": purchase.Variant,
+		"quantity": quantity, "amount": amount, "reason": req.Reason,
+	})
+	if err != nil {
+		return refund, fmt.Errorf("repository.RefundPurchase: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("repository.RefundPurchase: failed to commit transaction", zap.Error(err))
+		return refund, fmt.Errorf("repository.RefundPurchase: could not commit transaction: %w", err)
+	}
+
+	refund.Item = purchase.Item
+	refund.Variant = purchase.Variant
+	refund.Quantity = quantity
+	refund.Amount = amount
+
+	return refund, nil
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/pkg/logger"
+)
+
+const redemptionColumns = "id, username, item, variant, quantity, office, note, status, created_at, updated_at"
+
+type RedemptionService struct {
+	pool *pgxpool.Pool
+}
+
+func NewRedemptionService(pool *pgxpool.Pool) *RedemptionService {
+	return &RedemptionService{pool: pool}
+}
+
+// CreateRedemption takes the redeemed units out of the user's inventory and
+// records a pending redemption in one transaction.
+func (r *RedemptionService) CreateRedemption(ctx context.Context, redemption domain.Redemption) (domain.Redemption, error) {
+	ctx, span := tracer.Start(ctx, "repository.CreateRedemption")
+	defer span.End()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.Redemption{}, fmt.Errorf("repository.CreateRedemption: could not begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			logger.FromContext(ctx).Error("repository.CreateRedemption: failed to rollback transaction", zap.Error(err))
+		}
+	}()
+
+	if err := removeFromInventory(ctx, tx, redemption.Username, redemption.Item, redemption.Variant, redemption.Quantity); err != nil {
+		if errors.Is(err, appErrors.ErrItemNotInInventory) {
+			return domain.Redemption{}, err
+		}
+		return domain.Redemption{}, fmt.Errorf("repository.CreateRedemption: %w", err)
+	}
+
+	created, err := scanRedemption(tx.QueryRow(ctx, `
+		INSERT INTO redemptions (username, item, variant, quantity, office, note, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+redemptionColumns,
+		redemption.Username, redemption.Item, redemption.Variant, redemption.Quantity, redemption.Office, redemption.Note,
+		domain.RedemptionStatusPending))
+	if err != nil {
+		return domain.Redemption{}, fmt.Errorf("repository.CreateRedemption: could not insert redemption: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("repository.CreateRedemption: failed to commit transaction", zap.Error(err))
+		return domain.Redemption{}, fmt.Errorf("repository.CreateRedemption: could not commit transaction: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *RedemptionService) GetRedemption(ctx context.Context, id int) (domain.Redemption, error) {
+	ctx, span := tracer.Start(ctx, "repository.GetRedemption")
+	defer span.End()
+
+	redemption, err := scanRedemption(r.pool.QueryRow(ctx, "SELECT "+redemptionColumns+" FROM redemptions WHERE id = $1", id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Redemption{}, appErrors.ErrRedemptionNotFound
+		}
+		return domain.Redemption{}, fmt.Errorf("repository.GetRedemption: could not get redemption: %w", err)
+	}
+
+	return redemption, nil
+}
+
+func (r *RedemptionService) ListRedemptions(ctx context.Context, filter domain.RedemptionFilter) ([]domain.Redemption, error) {
+	ctx, span := tracer.Start(ctx, "repository.ListRedemptions")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+redemptionColumns+`
+		FROM redemptions
+		WHERE ($1::text = '' OR username = $1)
+			AND ($2::text = '' OR status = $2)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4`, filter.Username, filter.Status, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListRedemptions: could not retrieve redemptions: %w", err)
+	}
+	defer rows.Close()
+
+	redemptions := []domain.Redemption{}
+	for rows.Next() {
+		redemption, err := scanRedemption(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListRedemptions: could not scan redemption: %w", err)
+		}
+		redemptions = append(redemptions, redemption)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ListRedemptions: error reading rows: %w", err)
+	}
+
+	return redemptions, nil
+}
+
+// UpdateRedemptionStatus moves a redemption to status if the transition is
+// allowed. Rejected redemptions give the units back to the user.
+func (r *RedemptionService) UpdateRedemptionStatus(ctx context.Context, id int, status string) (domain.Redemption, error) {
+	ctx, span := tracer.Start(ctx, "repository.UpdateRedemptionStatus")
+	defer span.End()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return domain.Redemption{}, fmt.Errorf("repository.UpdateRedemptionStatus: could not begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			logger.FromContext(ctx).Error("repository.UpdateRedemptionStatus: failed to rollback transaction", zap.Error(err))
+		}
+	}()
+
+	redemption, err := scanRedemption(tx.QueryRow(ctx, "SELECT "+redemptionColumns+" FROM redemptions WHERE id = $1 FOR UPDATE", id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Redemption{}, appErrors.ErrRedemptionNotFound
+		}
+		return domain.Redemption{}, fmt.Errorf("repository.UpdateRedemptionStatus: could not get redemption: %w", err)
+	}
+
+	if !domain.CanTransitionRedemption(redemption.Status, status) {
+		return domain.Redemption{}, appErrors.ErrStatusTransition
+	}
+
+	if status == domain.RedemptionStatusRejected {
+		if err := addToInventory(ctx, tx, redemption.Username, redemption.Item, redemption.Variant, redemption.Quantity); err != nil {
+			return domain.Redemption{}, fmt.Errorf("repository.UpdateRedemptionStatus: %w", err)
+		}
+	}
+
+	redemption, err = scanRedemption(tx.QueryRow(ctx, `
+		UPDATE redemptions SET status = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING `+redemptionColumns, id, status))
+	if err != nil {
+		return domain.Redemption{}, fmt.Errorf("repository.UpdateRedemptionStatus: could not update redemption: %w", err)
+	}
+
+	message := fmt.Sprintf("Your redemption #%d of %s is now %s", redemption.ID,
+		itemLabel(redemption.Item, redemption.Variant, redemption.Quantity), redemption.Status)
+	if err := notify(ctx, tx, redemption.Username, domain.NotificationRedemptionStatus, message, redemption.Item); err != nil {
+		return domain.Redemption{}, fmt.Errorf("repository.UpdateRedemptionStatus: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("repository.UpdateRedemptionStatus: failed to commit transaction", zap.Error(err))
+		return domain.Redemption{}, fmt.Errorf("repository.UpdateRedemptionStatus: could not commit transaction: %w", err)
+	}
+
+	return redemption, nil
+}
+
+func scanRedemption(row pgx.Row) (domain.Redemption, error) {
+	var redemption domain.Redemption
+	err := row.Scan(&redemption.ID, &redemption.Username, &redemption.Item, &redemption.Variant, &redemption.Quantity,
+		&redemption.Office, &redemption.Note, &redemption.Status, &redemption.CreatedAt, &redemption.UpdatedAt)
+	return redemption, err
+}
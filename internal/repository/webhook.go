@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+)
+
+const (
+	webhookColumns  = "id, url, secret, event_types, active, created_at, updated_at"
+	deliveryColumns = `d.id, d.webhook_id, d.event_id, e.event_type, d.status, d.attempts, d.next_attempt_at,
+		d.last_status_code, d.last_error, d.delivered_at, d.created_at`
+)
+
+type WebhookService struct {
+	pool *pgxpool.Pool
+}
+
+func NewWebhookService(pool *pgxpool.Pool) *WebhookService {
+	return &WebhookService{pool: pool}
+}
+
+func (r *WebhookService) CreateWebhook(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "repository.CreateWebhook")
+	defer span.End()
+
+	created, err := scanWebhook(r.pool.QueryRow(ctx, `
+		INSERT INTO webhooks (url, secret, event_types, active)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+webhookColumns, webhook.URL, webhook.Secret, webhook.EventTypes, webhook.Active))
+	if err != nil {
+		return domain.Webhook{}, fmt.Errorf("repository.CreateWebhook: could not insert webhook: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *WebhookService) ListWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "repository.ListWebhooks")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, "SELECT "+webhookColumns+" FROM webhooks ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListWebhooks: could not retrieve webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := []domain.Webhook{}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListWebhooks: could not scan webhook: %w", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ListWebhooks: error reading rows: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *WebhookService) UpdateWebhook(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "repository.UpdateWebhook")
+	defer span.End()
+
+	updated, err := scanWebhook(r.pool.QueryRow(ctx, `
+		UPDATE webhooks SET url = $2, event_types = $3, active = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING `+webhookColumns, webhook.ID, webhook.URL, webhook.EventTypes, webhook.Active))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Webhook{}, appErrors.ErrWebhookNotFound
+		}
+		return domain.Webhook{}, fmt.Errorf("repository.UpdateWebhook: could not update webhook: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (r *WebhookService) DeleteWebhook(ctx context.Context, id int64) error {
+	ctx, span := tracer.Start(ctx, "repository.DeleteWebhook")
+	defer span.End()
+
+	tag, err := r.pool.Exec(ctx, "DELETE FROM webhooks WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("repository.DeleteWebhook: could not delete webhook: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return appErrors.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (r *WebhookService) ListDeliveries(ctx context.Context, filter domain.DeliveryFilter) ([]domain.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "repository.ListDeliveries")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+deliveryColumns+`
+		FROM webhook_deliveries d
+		JOIN outbox_events e ON e.id = d.event_id
+		WHERE ($1::bigint = 0 OR d.webhook_id = $1)
+			AND ($2::text = '' OR d.status = $2)
+		ORDER BY d.id DESC
+		LIMIT $3 OFFSET $4`, filter.WebhookID, filter.Status, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListDeliveries: could not retrieve deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []domain.WebhookDelivery{}
+	for rows.Next() {
+		var delivery domain.WebhookDelivery
+		if err := rows.Scan(deliveryFields(&delivery)...); err != nil {
+			return nil, fmt.Errorf("repository.ListDeliveries: could not scan delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ListDeliveries: error reading rows: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// ClaimDeliveries picks up to limit due deliveries of active webhooks, counts
+// the attempt and hides them from other dispatchers until leaseUntil, so a
+// dispatcher that dies mid-delivery only delays the retry.
+func (r *WebhookService) ClaimDeliveries(ctx context.Context, at, leaseUntil time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "repository.ClaimDeliveries")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, `
+		WITH due AS (
+			SELECT d.id
+			FROM webhook_deliveries d
+			JOIN webhooks w ON w.id = d.webhook_id
+			WHERE d.status = $4 AND d.next_attempt_at <= $1 AND w.active
+			ORDER BY d.next_attempt_at, d.id
+			LIMIT $3
+			FOR UPDATE OF d SKIP LOCKED
+		), d AS (
+			UPDATE webhook_deliveries SET attempts = attempts + 1, next_attempt_at = $2
+			FROM due
+			WHERE webhook_deliveries.id = due.id
+			RETURNING webhook_deliveries.*
+		)
+		SELECT `+deliveryColumns+`, e.payload, e.created_at, w.url, w.secret
+		FROM d
+		JOIN outbox_events e ON e.id = d.event_id
+		JOIN webhooks w ON w.id = d.webhook_id
+		ORDER BY d.id`, at, leaseUntil, limit, domain.DeliveryPending)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ClaimDeliveries: could not claim deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []domain.WebhookDelivery{}
+	for rows.Next() {
+		var delivery domain.WebhookDelivery
+		fields := append(deliveryFields(&delivery), &delivery.Payload, &delivery.EventCreatedAt, &delivery.URL, &delivery.Secret)
+		if err := rows.Scan(fields...); err != nil {
+			return nil, fmt.Errorf("repository.ClaimDeliveries: could not scan delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("repository.ClaimDeliveries: error reading rows: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *WebhookService) SaveDeliveryResult(ctx context.Context, id int64, result domain.DeliveryResult) error {
+	ctx, span := tracer.Start(ctx, "repository.SaveDeliveryResult")
+	defer span.End()
+
+	_, err := r.pool.Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2, last_status_code = $3, last_error = $4, next_attempt_at = $5,
+			delivered_at = CASE WHEN $2 = $7 THEN $6::timestamptz END
+		WHERE id = $1`,
+		id, result.Status, result.StatusCode, result.Error, result.NextAttemptAt, result.At, domain.DeliveryDelivered)
+	if err != nil {
+		return fmt.Errorf("repository.SaveDeliveryResult: %w", err)
+	}
+
+	return nil
+}
+
+// ReplayEvent queues an outbox event again for every subscribed active
+// webhook, or only for webhookID when it is not zero. Existing deliveries are
+// reset to a fresh pending state.
+func (r *WebhookService) ReplayEvent(ctx context.Context, eventID, webhookID int64, at time.Time) (int, error) {
+	ctx, span := tracer.Start(ctx, "repository.ReplayEvent")
+	defer span.End()
+
+	var exists bool
+	if err := r.pool.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM outbox_events WHERE id = $1)", eventID).Scan(&exists); err != nil {
+		return 0, fmt.Errorf("repository.ReplayEvent: could not check event: %w", err)
+	}
+	if !exists {
+		return 0, appErrors.ErrOutboxEventNotFound
+	}
+
+	if webhookID != 0 {
+		if err := r.pool.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM webhooks WHERE id = $1)", webhookID).Scan(&exists); err != nil {
+			return 0, fmt.Errorf("repository.ReplayEvent: could not check webhook: %w", err)
+		}
+		if !exists {
+			return 0, appErrors.ErrWebhookNotFound
+		}
+	}
+
+	tag, err := r.pool.Exec(ctx, `
+		INSERT INTO webhook_deliveries (webhook_id, event_id, next_attempt_at)
+		SELECT w.id, e.id, $3
+		FROM outbox_events e
+		JOIN webhooks w ON w.active
+			AND (w.id = $2 OR ($2 = 0 AND (cardinality(w.event_types) = 0 OR e.event_type = ANY(w.event_types))))
+		WHERE e.id = $1
+		ON CONFLICT (webhook_id, event_id) DO UPDATE
+		SET status = $4, attempts = 0, next_attempt_at = EXCLUDED.next_attempt_at,
+			last_status_code = 0, last_error = '', delivered_at = NULL`,
+		eventID, webhookID, at, domain.DeliveryPending)
+	if err != nil {
+		return 0, fmt.Errorf("repository.ReplayEvent: could not queue deliveries: %w", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+func scanWebhook(row pgx.Row) (domain.Webhook, error) {
+	var webhook domain.Webhook
+	err := row.Scan(&webhook.ID, &webhook.URL, &webhook.Secret, &webhook.EventTypes, &webhook.Active,
+		&webhook.CreatedAt, &webhook.UpdatedAt)
+	return webhook, err
+}
+
+func deliveryFields(delivery *domain.WebhookDelivery) []any {
+	return []any{&delivery.ID, &delivery.WebhookID, &delivery.EventID, &delivery.EventType, &delivery.Status,
+		&delivery.Attempts, &delivery.NextAttemptAt, &delivery.LastStatusCode, &delivery.LastError,
+		&delivery.DeliveredAt, &delivery.CreatedAt}
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+)
+
+type WishlistService struct {
+	pool *pgxpool.Pool
+}
+
+func NewWishlistService(pool *pgxpool.Pool) *WishlistService {
+	return &WishlistService{pool: pool}
+}
+
+func (r *WishlistService) GetWishlist(ctx context.Context, username string) (domain.Wishlist, error) {
+	ctx, span := tracer.Start(ctx, "repository.GetWishlist")
+	defer span.End()
+
+	wishlist := domain.Wishlist{Items: []domain.WishlistItem{}}
+
+	err := r.pool.QueryRow(ctx, "SELECT balance FROM users WHERE username = $1", username).Scan(&wishlist.Balance)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return wishlist, appErrors.ErrUserNotFound
+		}
+		return wishlist, fmt.Errorf("repository.GetWishlist: could not get balance: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT w.item_name, m.price, m.category, w.created_at
+		FROM wishlist w
+		INNER JOIN merch m ON m.item_name = w.item_name
+		WHERE w.username = $1
+		ORDER BY w.created_at, w.item_name`, username)
+	if err != nil {
+		return wishlist, fmt.Errorf("repository.GetWishlist: could not retrieve wishlist: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item domain.WishlistItem
+		if err := rows.Scan(&item.Item, &item.Price, &item.Category, &item.AddedAt); err != nil {
+			return wishlist, fmt.Errorf("repository.GetWishlist: could not scan item: %w", err)
+		}
+		wishlist.Items = append(wishlist.Items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return wishlist, fmt.Errorf("repository.GetWishlist: error reading rows: %w", err)
+	}
+
+	return wishlist, nil
+}
+
+// AddToWishlist is idempotent: adding an item that is already wishlisted
+// succeeds without changing anything.
+func (r *WishlistService) AddToWishlist(ctx context.Context, username, item string) error {
+	ctx, span := tracer.Start(ctx, "repository.AddToWishlist")
+	defer span.End()
+
+	tag, err := r.pool.Exec(ctx, `
+		INSERT INTO wishlist (username, item_name)
+		SELECT $1, $2
+		WHERE (SELECT COUNT(*) FROM wishlist WHERE username = $1) < $3
+		ON CONFLICT (username, item_name) DO NOTHING`,
+		username, item, domain.MaxWishlistItems)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			return appErrors.ErrItemNotFound
+		}
+		return fmt.Errorf("repository.AddToWishlist: could not add item: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		return nil
+	}
+
+	var exists bool
+	err = r.pool.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM wishlist WHERE username = $1 AND item_name = $2)", username, item).
+		Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("repository.AddToWishlist: could not check wishlist: %w", err)
+	}
+	if !exists {
+		return appErrors.ErrWishlistFull
+	}
+
+	return nil
+}
+
+func (r *WishlistService) RemoveFromWishlist(ctx context.Context, username, item string) error {
+	ctx, span := tracer.Start(ctx, "repository.RemoveFromWishlist")
+	defer span.End()
+
+	tag, err := r.pool.Exec(ctx, "DELETE FROM wishlist WHERE username = $1 AND item_name = $2", username, item)
+	if err != nil {
+		return fmt.Errorf("repository.RemoveFromWishlist: could not remove item: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return appErrors.ErrNotInWishlist
+	}
+
+	return nil
+}
+
+// NotifyStartedPromotions notifies wishlisting users about automatic
+// promotions that have started by at and marks them as notified. Coded and
+// already ended promotions are marked without notifying anyone. Rows locked
+// by another replica are skipped.
+func (r *WishlistService) NotifyStartedPromotions(ctx context.Context, at time.Time) (int, error) {
+	ctx, span := tracer.Start(ctx, "repository.NotifyStartedPromotions")
+	defer span.End()
+
+	tag, err := r.pool.Exec(ctx, `
+		WITH started AS (
+			SELECT id, name, item, category, code, ends_at
+			FROM promotions
+			WHERE notified_at IS NULL AND starts_at <= $1
+			FOR UPDATE SKIP LOCKED
+		), marked AS (
+			UPDATE promotions SET notified_at = $1
+			WHERE id IN (SELECT id FROM started)
+		)
+		INSERT INTO notifications (username, kind, message, item)
+		SELECT w.username, $2, format('%s is on sale: %s', w.item_name, s.name), w.item_name
+		FROM started s
+		INNER JOIN merch m ON m.item_name = s.item OR m.category = s.category
+		INNER JOIN wishlist w ON w.item_name = m.item_name
+		WHERE s.code IS NULL AND (s.ends_at IS NULL OR s.ends_at > $1)`,
+		at, domain.NotificationPromotion)
+	if err != nil {
+		return 0, fmt.Errorf("repository.NotifyStartedPromotions: %w", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
@@ -10,19 +10,28 @@ import (
 
 	"github.com/Te8va/MerchStore/internal/domain"
 	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/metrics"
 	"github.com/Te8va/MerchStore/pkg/jwt"
 )
 
 type Authorization struct {
-	repo   domain.AuthorizationRepository
-	JWTKey string
+	repo         domain.AuthorizationRepository
+	JWTKey       string
+	welcomeBonus int
 }
 
-func NewAuthorization(repo domain.AuthorizationRepository, jwtKey string) *Authorization {
-	return &Authorization{repo: repo, JWTKey: jwtKey}
+func NewAuthorization(repo domain.AuthorizationRepository, jwtKey string, welcomeBonus int) *Authorization {
+	return &Authorization{repo: repo, JWTKey: jwtKey, welcomeBonus: welcomeBonus}
 }
 
 func (s *Authorization) RegisterOrAuthenticate(ctx context.Context, username, password string) (string, error) {
+	ctx, span := tracer.Start(ctx, "service.RegisterOrAuthenticate")
+	defer span.End()
+
+	if username == domain.SystemAccount {
+		return "", appErrors.ErrSystemAccount
+	}
+
 	user, err := s.repo.GetUserByUsername(ctx, username)
 	if err != nil {
 		if err == appErrors.ErrUserNotFound {
@@ -32,6 +41,7 @@ func (s *Authorization) RegisterOrAuthenticate(ctx context.Context, username, pa
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		metrics.FailedLogins.Inc()
 		return "", appErrors.ErrWrongPassword
 	}
 
@@ -53,6 +63,7 @@ func (s *Authorization) registerNewUser(ctx context.Context, username, password
 		Username: username,
 		Password: string(passwordHash),
 		Token:    tokenStr,
+		Balance:  s.welcomeBonus,
 	}
 
 	err = s.repo.CreateUser(ctx, user)
@@ -63,5 +74,9 @@ func (s *Authorization) registerNewUser(ctx context.Context, username, password
 		return "", fmt.Errorf("service.registerNewUser: %w", err)
 	}
 
+	if s.welcomeBonus > 0 {
+		metrics.CoinsIssued.WithLabelValues(domain.TransactionKindWelcome).Add(float64(s.welcomeBonus))
+	}
+
 	return tokenStr, nil
 }
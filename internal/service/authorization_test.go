@@ -19,7 +19,7 @@ func TestRegisterOrAuthenticate(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockAuthorizationRepository(ctrl)
-	testService := NewAuthorization(mockRepo, "test_secret")
+	testService := NewAuthorization(mockRepo, "test_secret", 1000)
 
 	testPassword := "password123"
 	passwordHash, _ := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.DefaultCost)
@@ -78,6 +78,14 @@ func TestRegisterOrAuthenticate(t *testing.T) {
 			expectedErr: errors.New("service.RegisterOrAuthenticate: db error"),
 			expectedTok: "",
 		},
+		{
+			name:        "system account",
+			username:    domain.SystemAccount,
+			password:    testPassword,
+			mockRepo:    func() {},
+			expectedErr: appErrors.ErrSystemAccount,
+			expectedTok: "",
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -102,7 +110,7 @@ func TestRegisterNewUser(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockAuthorizationRepository(ctrl)
-	testService := NewAuthorization(mockRepo, "test_secret")
+	testService := NewAuthorization(mockRepo, "test_secret", 1000)
 
 	testCases := []struct {
 		name        string
@@ -117,7 +125,10 @@ func TestRegisterNewUser(t *testing.T) {
 			password: "newpassword123",
 			mockRepo: func() {
 				mockRepo.EXPECT().GetUserByUsername(gomock.Any(), "newuser").Return(nil, appErrors.ErrUserNotFound).Times(1)
-				mockRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				mockRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, user domain.User) error {
+					require.Equal(t, 1000, user.Balance)
+					return nil
+				}).Times(1)
 			},
 			expectedErr: nil,
 		},
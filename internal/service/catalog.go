@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+)
+
+type Catalog struct {
+	repo domain.CatalogRepository
+}
+
+func NewCatalog(repo domain.CatalogRepository) *Catalog {
+	return &Catalog{repo: repo}
+}
+
+func (s *Catalog) ListCatalog(ctx context.Context, category string) ([]domain.MerchItem, error) {
+	ctx, span := tracer.Start(ctx, "service.ListCatalog")
+	defer span.End()
+
+	items, err := s.repo.ListCatalog(ctx, strings.TrimSpace(category))
+	if err != nil {
+		return nil, fmt.Errorf("service.ListCatalog: %w", err)
+	}
+
+	return items, nil
+}
+
+func (s *Catalog) GetCatalogItem(ctx context.Context, name string) (domain.MerchItem, error) {
+	ctx, span := tracer.Start(ctx, "service.GetCatalogItem")
+	defer span.End()
+
+	item, err := s.repo.GetCatalogItem(ctx, name)
+	if err != nil {
+		return domain.MerchItem{}, fmt.Errorf("service.GetCatalogItem: %w", err)
+	}
+
+	return item, nil
+}
+
+func (s *Catalog) UpdateCatalogItem(ctx context.Context, item domain.MerchItem) (domain.MerchItem, error) {
+	ctx, span := tracer.Start(ctx, "service.UpdateCatalogItem")
+	defer span.End()
+
+	item.Category = strings.TrimSpace(item.Category)
+	item.Description = strings.TrimSpace(item.Description)
+	item.ImageURL = strings.TrimSpace(item.ImageURL)
+
+	switch {
+	case item.Category == "":
+		return domain.MerchItem{}, fmt.Errorf("%w: category is required", appErrors.ErrInvalidCatalogItem)
+	case utf8.RuneCountInString(item.Description) > domain.MaxDescriptionLength:
+		return domain.MerchItem{}, fmt.Errorf("%w: description is too long", appErrors.ErrInvalidCatalogItem)
+	case item.ImageURL != "" && !isHTTPURL(item.ImageURL):
+		return domain.MerchItem{}, fmt.Errorf("%w: imageUrl must be an http or https URL", appErrors.ErrInvalidCatalogItem)
+	}
+
+	updated, err := s.repo.UpdateCatalogItem(ctx, item)
+	if err != nil {
+		return domain.MerchItem{}, fmt.Errorf("service.UpdateCatalogItem: %w", err)
+	}
+
+	return updated, nil
+}
+
+// UpsertVariant adds a variant to an item or replaces the kind and stock of
+// an existing one.
+func (s *Catalog) UpsertVariant(ctx context.Context, item string, variant domain.Variant) (domain.Variant, error) {
+	ctx, span := tracer.Start(ctx, "service.UpsertVariant")
+	defer span.End()
+
+	variant.Name = strings.TrimSpace(variant.Name)
+
+	switch {
+	case variant.Name == "":
+		return domain.Variant{}, fmt.Errorf("%w: variant name is required", appErrors.ErrInvalidCatalogItem)
+	case variant.Kind != domain.VariantKindSize && variant.Kind != domain.VariantKindColour:
+		return domain.Variant{}, fmt.Errorf("%w: kind must be size or colour", appErrors.ErrInvalidCatalogItem)
+	case variant.Stock < 0:
+		return domain.Variant{}, fmt.Errorf("%w: stock must not be negative", appErrors.ErrInvalidCatalogItem)
+	}
+
+	saved, err := s.repo.UpsertVariant(ctx, item, variant)
+	if err != nil {
+		return domain.Variant{}, fmt.Errorf("service.UpsertVariant: %w", err)
+	}
+
+	return saved, nil
+}
+
+func (s *Catalog) UpdatePrice(ctx context.Context, item string, price int) (domain.MerchItem, error) {
+	ctx, span := tracer.Start(ctx, "service.UpdatePrice")
+	defer span.End()
+
+	if price <= 0 || price > math.MaxInt32 {
+		return domain.MerchItem{}, appErrors.ErrInvalidPrice
+	}
+
+	updated, err := s.repo.UpdatePrice(ctx, item, price)
+	if err != nil {
+		return domain.MerchItem{}, fmt.Errorf("service.UpdatePrice: %w", err)
+	}
+
+	return updated, nil
+}
+
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
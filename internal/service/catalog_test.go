@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+)
+
+func TestCatalogUpdateItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCatalogRepository(ctrl)
+	catalogService := NewCatalog(mockRepo)
+
+	testCases := []struct {
+		name        string
+		item        domain.MerchItem
+		mockRepo    func()
+		expectedErr error
+	}{
+		{
+			name: "valid update",
+			item: domain.MerchItem{Name: "hoody", Category: " clothing ", Description: "Warm", ImageURL: "https://cdn.example.com/hoody.png"},
+			mockRepo: func() {
+				mockRepo.EXPECT().UpdateCatalogItem(gomock.Any(), domain.MerchItem{
+					Name: "hoody", Category: "clothing", Description: "Warm", ImageURL: "https://cdn.example.com/hoody.png",
+				}).Return(domain.MerchItem{Name: "hoody", Category: "clothing"}, nil).Times(1)
+			},
+		},
+		{
+			name:        "missing category",
+			item:        domain.MerchItem{Name: "hoody"},
+			mockRepo:    func() {},
+			expectedErr: appErrors.ErrInvalidCatalogItem,
+		},
+		{
+			name:        "image url without http scheme",
+			item:        domain.MerchItem{Name: "hoody", Category: "clothing", ImageURL: "ftp://cdn.example.com/hoody.png"},
+			mockRepo:    func() {},
+			expectedErr: appErrors.ErrInvalidCatalogItem,
+		},
+		{
+			name:        "description too long",
+			item:        domain.MerchItem{Name: "hoody", Category: "clothing", Description: strings.Repeat("я", domain.MaxDescriptionLength+1)},
+			mockRepo:    func() {},
+			expectedErr: appErrors.ErrInvalidCatalogItem,
+		},
+		{
+			name: "unknown item",
+			item: domain.MerchItem{Name: "ghost", Category: "clothing"},
+			mockRepo: func() {
+				mockRepo.EXPECT().UpdateCatalogItem(gomock.Any(), gomock.Any()).Return(domain.MerchItem{}, appErrors.ErrItemNotFound).Times(1)
+			},
+			expectedErr: appErrors.ErrItemNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockRepo()
+
+			_, err := catalogService.UpdateCatalogItem(context.Background(), testCase.item)
+
+			if testCase.expectedErr != nil {
+				require.ErrorIs(t, err, testCase.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCatalogUpsertVariant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCatalogRepository(ctrl)
+	catalogService := NewCatalog(mockRepo)
+
+	_, err := catalogService.UpsertVariant(context.Background(), "t-shirt", domain.Variant{Name: "XXL", Kind: "weight", Stock: 5})
+	require.ErrorIs(t, err, appErrors.ErrInvalidCatalogItem)
+
+	_, err = catalogService.UpsertVariant(context.Background(), "t-shirt", domain.Variant{Name: "XXL", Kind: domain.VariantKindSize, Stock: -1})
+	require.ErrorIs(t, err, appErrors.ErrInvalidCatalogItem)
+
+	_, err = catalogService.UpsertVariant(context.Background(), "t-shirt", domain.Variant{Name: " ", Kind: domain.VariantKindSize})
+	require.ErrorIs(t, err, appErrors.ErrInvalidCatalogItem)
+
+	mockRepo.EXPECT().UpsertVariant(gomock.Any(), "t-shirt", domain.Variant{Name: "XXL", Kind: domain.VariantKindSize, Stock: 10}).
+		Return(domain.Variant{Name: "XXL", Kind: domain.VariantKindSize, Stock: 10}, nil).Times(1)
+	variant, err := catalogService.UpsertVariant(context.Background(), "t-shirt", domain.Variant{Name: " XXL ", Kind: domain.VariantKindSize, Stock: 10})
+	require.NoError(t, err)
+	require.Equal(t, 10, variant.Stock)
+}
+
+func TestCatalogUpdatePrice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCatalogRepository(ctrl)
+	catalogService := NewCatalog(mockRepo)
+
+	_, err := catalogService.UpdatePrice(context.Background(), "hoody", 0)
+	require.ErrorIs(t, err, appErrors.ErrInvalidPrice)
+
+	mockRepo.EXPECT().UpdatePrice(gomock.Any(), "hoody", 250).Return(domain.MerchItem{Name: "hoody", Price: 250}, nil).Times(1)
+	item, err := catalogService.UpdatePrice(context.Background(), "hoody", 250)
+	require.NoError(t, err)
+	require.Equal(t, 250, item.Price)
+}
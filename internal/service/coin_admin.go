@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/metrics"
+)
+
+const MaxAirdropRecipients = 10000
+
+type CoinAdmin struct {
+	repo domain.CoinAdminRepository
+}
+
+func NewCoinAdmin(repo domain.CoinAdminRepository) *CoinAdmin {
+	return &CoinAdmin{repo: repo}
+}
+
+func (s *CoinAdmin) Grant(ctx context.Context, adjustment domain.CoinAdjustment) error {
+	ctx, span := tracer.Start(ctx, "service.Grant")
+	defer span.End()
+
+	adjustment, err := normalizeAdjustment(adjustment)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.GrantCoins(ctx, domain.TransactionKindGrant, []domain.CoinAdjustment{adjustment}); err != nil {
+		return fmt.Errorf("service.Grant: %w", err)
+	}
+
+	metrics.CoinsIssued.WithLabelValues(domain.TransactionKindGrant).Add(float64(adjustment.Amount))
+
+	return nil
+}
+
+func (s *CoinAdmin) Deduct(ctx context.Context, adjustment domain.CoinAdjustment) error {
+	ctx, span := tracer.Start(ctx, "service.Deduct")
+	defer span.End()
+
+	adjustment, err := normalizeAdjustment(adjustment)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeductCoins(ctx, adjustment); err != nil {
+		return fmt.Errorf("service.Deduct: %w", err)
+	}
+
+	metrics.CoinsIssued.WithLabelValues(domain.TransactionKindDeduction).Add(float64(adjustment.Amount))
+
+	return nil
+}
+
+func (s *CoinAdmin) Airdrop(ctx context.Context, adjustments []domain.CoinAdjustment) (domain.AirdropSummary, error) {
+	ctx, span := tracer.Start(ctx, "service.Airdrop")
+	defer span.End()
+
+	var summary domain.AirdropSummary
+
+	if len(adjustments) == 0 {
+		return summary, appErrors.ErrNoRecipients
+	}
+	if len(adjustments) > MaxAirdropRecipients {
+		return summary, appErrors.ErrTooManyRecipients
+	}
+
+	normalized := make([]domain.CoinAdjustment, len(adjustments))
+	perUser := make(map[string]int, len(adjustments))
+	for i, adjustment := range adjustments {
+		adjustment, err := normalizeAdjustment(adjustment)
+		if err != nil {
+			return summary, fmt.Errorf("recipient %d: %w", i+1, err)
+		}
+		// Rows for the same user are summed into one balance update.
+		perUser[adjustment.Username] += adjustment.Amount
+		if perUser[adjustment.Username] > math.MaxInt32 {
+			return summary, fmt.Errorf("recipient %d: %w", i+1, appErrors.ErrInvalidAmount)
+		}
+		normalized[i] = adjustment
+		summary.Total += adjustment.Amount
+	}
+	summary.Recipients = len(normalized)
+
+	if err := s.repo.GrantCoins(ctx, domain.TransactionKindAirdrop, normalized); err != nil {
+		return domain.AirdropSummary{}, fmt.Errorf("service.Airdrop: %w", err)
+	}
+
+	metrics.CoinsIssued.WithLabelValues(domain.TransactionKindAirdrop).Add(float64(summary.Total))
+
+	return summary, nil
+}
+
+func normalizeAdjustment(adjustment domain.CoinAdjustment) (domain.CoinAdjustment, error) {
+	adjustment.Username = strings.TrimSpace(adjustment.Username)
+	adjustment.Reason = strings.TrimSpace(adjustment.Reason)
+
+	switch {
+	case adjustment.Username == "":
+		return adjustment, appErrors.ErrUserNotFound
+	case adjustment.Username == domain.SystemAccount:
+		return adjustment, appErrors.ErrSystemAccount
+	case adjustment.Amount <= 0, adjustment.Amount > math.MaxInt32:
+		return adjustment, appErrors.ErrInvalidAmount
+	case adjustment.Reason == "":
+		return adjustment, appErrors.ErrReasonRequired
+	}
+
+	return adjustment, nil
+}
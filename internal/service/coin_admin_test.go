@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+)
+
+func TestCoinAdminGrant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCoinAdminRepository(ctrl)
+	coinAdmin := NewCoinAdmin(mockRepo)
+
+	testCases := []struct {
+		name        string
+		adjustment  domain.CoinAdjustment
+		mockRepo    func()
+		expectedErr error
+	}{
+		{
+			name:       "success",
+			adjustment: domain.CoinAdjustment{Username: " alice ", Amount: 100, Reason: " hackathon "},
+			mockRepo: func() {
+				mockRepo.EXPECT().GrantCoins(gomock.Any(), domain.TransactionKindGrant, []domain.CoinAdjustment{
+					{Username: "alice", Amount: 100, Reason: "hackathon"},
+				}).Return(nil).Times(1)
+			},
+		},
+		{
+			name:        "system account",
+			adjustment:  domain.CoinAdjustment{Username: domain.SystemAccount, Amount: 100, Reason: "test"},
+			mockRepo:    func() {},
+			expectedErr: appErrors.ErrSystemAccount,
+		},
+		{
+			name:        "non-positive amount",
+			adjustment:  domain.CoinAdjustment{Username: "alice", Amount: 0, Reason: "test"},
+			mockRepo:    func() {},
+			expectedErr: appErrors.ErrInvalidAmount,
+		},
+		{
+			name:        "missing reason",
+			adjustment:  domain.CoinAdjustment{Username: "alice", Amount: 10},
+			mockRepo:    func() {},
+			expectedErr: appErrors.ErrReasonRequired,
+		},
+		{
+			name:       "db error",
+			adjustment: domain.CoinAdjustment{Username: "alice", Amount: 10, Reason: "test"},
+			mockRepo: func() {
+				mockRepo.EXPECT().GrantCoins(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("db error")).Times(1)
+			},
+			expectedErr: errors.New("service.Grant: db error"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockRepo()
+
+			err := coinAdmin.Grant(context.Background(), testCase.adjustment)
+
+			if testCase.expectedErr != nil {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), testCase.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCoinAdminDeduct(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCoinAdminRepository(ctrl)
+	coinAdmin := NewCoinAdmin(mockRepo)
+
+	adjustment := domain.CoinAdjustment{Username: "alice", Amount: 50, Reason: "mistake"}
+
+	mockRepo.EXPECT().DeductCoins(gomock.Any(), adjustment).Return(nil).Times(1)
+	require.NoError(t, coinAdmin.Deduct(context.Background(), adjustment))
+
+	mockRepo.EXPECT().DeductCoins(gomock.Any(), adjustment).Return(appErrors.ErrInsufficientBalance).Times(1)
+	require.ErrorIs(t, coinAdmin.Deduct(context.Background(), adjustment), appErrors.ErrInsufficientBalance)
+}
+
+func TestCoinAdminAirdrop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCoinAdminRepository(ctrl)
+	coinAdmin := NewCoinAdmin(mockRepo)
+
+	recipients := []domain.CoinAdjustment{
+		{Username: "alice", Amount: 10, Reason: "new year"},
+		{Username: "bob", Amount: 20, Reason: "new year"},
+	}
+
+	mockRepo.EXPECT().GrantCoins(gomock.Any(), domain.TransactionKindAirdrop, recipients).Return(nil).Times(1)
+	summary, err := coinAdmin.Airdrop(context.Background(), recipients)
+	require.NoError(t, err)
+	require.Equal(t, domain.AirdropSummary{Recipients: 2, Total: 30}, summary)
+
+	_, err = coinAdmin.Airdrop(context.Background(), nil)
+	require.ErrorIs(t, err, appErrors.ErrNoRecipients)
+
+	_, err = coinAdmin.Airdrop(context.Background(), []domain.CoinAdjustment{
+		{Username: "alice", Amount: 10, Reason: "ok"},
+		{Username: "bob", Amount: -5, Reason: "ok"},
+	})
+	require.ErrorIs(t, err, appErrors.ErrInvalidAmount)
+	require.Contains(t, err.Error(), "recipient 2")
+
+	_, err = coinAdmin.Airdrop(context.Background(), []domain.CoinAdjustment{
+		{Username: "alice", Amount: math.MaxInt32, Reason: "ok"},
+		{Username: "bob", Amount: 10, Reason: "ok"},
+		{Username: "alice", Amount: 1, Reason: "ok"},
+	})
+	require.ErrorIs(t, err, appErrors.ErrInvalidAmount)
+	require.Contains(t, err.Error(), "recipient 3")
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/metrics"
+	"github.com/Te8va/MerchStore/pkg/logger"
+)
+
+const maxListenBackoff = 30 * time.Second
+
+// Events fans database notifications out to the event streams open on this
+// instance. A subscriber only gets a wake-up signal and reads the events
+// themselves from the database, so a dropped signal never loses an event.
+type Events struct {
+	repo domain.EventRepository
+	now  func() time.Time
+
+	mu          sync.Mutex
+	subscribers map[string]map[chan struct{}]struct{}
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func NewEvents(repo domain.EventRepository) *Events {
+	return &Events{
+		repo:        repo,
+		now:         time.Now,
+		subscribers: make(map[string]map[chan struct{}]struct{}),
+		done:        make(chan struct{}),
+	}
+}
+
+func (s *Events) Subscribe(username string) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	s.mu.Lock()
+	if s.subscribers[username] == nil {
+		s.subscribers[username] = make(map[chan struct{}]struct{})
+	}
+	s.subscribers[username][ch] = struct{}{}
+	s.mu.Unlock()
+	metrics.EventStreams.Inc()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			s.mu.Lock()
+			delete(s.subscribers[username], ch)
+			if len(s.subscribers[username]) == 0 {
+				delete(s.subscribers, username)
+			}
+			s.mu.Unlock()
+			metrics.EventStreams.Dec()
+		})
+	}
+}
+
+// Done is closed when the server shuts down so that open streams can end.
+func (s *Events) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *Events) Close() {
+	s.closeOnce.Do(func() { close(s.done) })
+}
+
+func (s *Events) ListEvents(ctx context.Context, username string, afterID int64) ([]domain.UserEvent, error) {
+	ctx, span := tracer.Start(ctx, "service.ListEvents")
+	defer span.End()
+
+	events, err := s.repo.ListEvents(ctx, username, afterID, domain.EventBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListEvents: %w", err)
+	}
+	return events, nil
+}
+
+func (s *Events) LatestEventID(ctx context.Context, username string) (int64, error) {
+	ctx, span := tracer.Start(ctx, "service.LatestEventID")
+	defer span.End()
+
+	id, err := s.repo.LatestEventID(ctx, username)
+	if err != nil {
+		return 0, fmt.Errorf("service.LatestEventID: %w", err)
+	}
+	return id, nil
+}
+
+// Run listens for database notifications until ctx is done, reconnecting
+// with exponential backoff when the listening connection fails.
+func (s *Events) Run(ctx context.Context) error {
+	backoff := time.Second
+	for {
+		started := s.now()
+		err := s.repo.Listen(ctx, s.wake)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if s.now().Sub(started) > maxListenBackoff {
+			backoff = time.Second
+		}
+		logger.FromContext(ctx).Error("Event listener stopped", zap.Error(err), zap.Duration("retry_in", backoff))
+
+		// Notifications sent while the listener was down are lost, so every
+		// stream has to look for new events itself.
+		s.wakeAll()
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxListenBackoff)
+	}
+}
+
+func (s *Events) Prune(ctx context.Context, retention time.Duration) error {
+	ctx, span := tracer.Start(ctx, "service.Prune")
+	defer span.End()
+
+	deleted, err := s.repo.DeleteEventsBefore(ctx, s.now().Add(-retention))
+	if err != nil {
+		return fmt.Errorf("service.Prune: %w", err)
+	}
+	if deleted > 0 {
+		logger.FromContext(ctx).Info("Pruned old events", zap.Int("deleted", deleted))
+	}
+	return nil
+}
+
+// RunRetention deletes events older than retention at least once an hour.
+func (s *Events) RunRetention(ctx context.Context, retention time.Duration) error {
+	ticker := time.NewTicker(min(retention, time.Hour))
+	defer ticker.Stop()
+
+	for {
+		if err := s.Prune(ctx, retention); err != nil && ctx.Err() == nil {
+			logger.FromContext(ctx).Error("Event pruning failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Events) wake(username string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for ch := range s.subscribers[username] {
+		signal(ch)
+	}
+}
+
+func (s *Events) wakeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, subscribers := range s.subscribers {
+		for ch := range subscribers {
+			signal(ch)
+		}
+	}
+}
+
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+)
+
+func TestEventsFanOut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEventRepository(ctrl)
+	eventService := NewEvents(mockRepo)
+
+	alice, unsubscribeAlice := eventService.Subscribe("alice")
+	defer unsubscribeAlice()
+	bob, unsubscribeBob := eventService.Subscribe("bob")
+	defer unsubscribeBob()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	listened := make(chan struct{})
+	mockRepo.EXPECT().Listen(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, onNotify func(string)) error {
+		onNotify("alice")
+		onNotify("alice")
+		close(listened)
+		<-ctx.Done()
+		return nil
+	}).Times(1)
+
+	done := make(chan error)
+	go func() { done <- eventService.Run(ctx) }()
+	<-listened
+
+	// Несколько уведомлений подряд сливаются в один сигнал
+	require.Len(t, alice, 1)
+	require.Empty(t, bob)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestEventsListenerFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEventRepository(ctrl)
+	eventService := NewEvents(mockRepo)
+
+	alice, unsubscribe := eventService.Subscribe("alice")
+	defer unsubscribe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockRepo.EXPECT().Listen(gomock.Any(), gomock.Any()).Return(errors.New("connection reset")).Times(1)
+
+	done := make(chan error)
+	go func() { done <- eventService.Run(ctx) }()
+
+	// После сбоя слушателя все потоки перечитывают события
+	select {
+	case <-alice:
+	case <-time.After(time.Second):
+		t.Fatal("subscriber was not woken after listener failure")
+	}
+
+	cancel()
+	require.NoError(t, <-done)
+
+	eventService.Close()
+	eventService.Close()
+	<-eventService.Done()
+}
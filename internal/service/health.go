@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+)
+
+type Health struct {
+	repo             domain.HealthRepository
+	migrationVersion uint
+	draining         atomic.Bool
+}
+
+func NewHealth(repo domain.HealthRepository, migrationVersion uint) *Health {
+	return &Health{repo: repo, migrationVersion: migrationVersion}
+}
+
+func (s *Health) SetDraining() {
+	s.draining.Store(true)
+}
+
+func (s *Health) Ready(ctx context.Context) error {
+	if s.draining.Load() {
+		return appErrors.ErrDraining
+	}
+
+	if err := s.repo.Ping(ctx); err != nil {
+		return fmt.Errorf("service.Ready: %w", err)
+	}
+
+	if err := s.checkMigrations(ctx); err != nil {
+		return fmt.Errorf("service.Ready: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Health) Report(ctx context.Context) domain.HealthReport {
+	report := domain.HealthReport{
+		Status:   domain.StatusUp,
+		Draining: s.draining.Load(),
+		Dependencies: []domain.DependencyStatus{
+			s.check(ctx, "postgres", "", s.repo.Ping),
+			s.check(ctx, "migrations", fmt.Sprintf("expected version %d", s.migrationVersion), s.checkMigrations),
+		},
+	}
+
+	if report.Draining {
+		report.Status = domain.StatusDown
+	}
+
+	for _, dep := range report.Dependencies {
+		if dep.Status != domain.StatusUp {
+			report.Status = domain.StatusDown
+		}
+	}
+
+	return report
+}
+
+func (s *Health) check(ctx context.Context, name, details string, fn func(context.Context) error) domain.DependencyStatus {
+	status := domain.DependencyStatus{Name: name, Status: domain.StatusUp, Details: details}
+
+	start := time.Now()
+	err := fn(ctx)
+	status.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+
+	if err != nil {
+		status.Status = domain.StatusDown
+		status.Error = err.Error()
+	}
+
+	return status
+}
+
+func (s *Health) checkMigrations(ctx context.Context) error {
+	version, dirty, err := s.repo.MigrationVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	if dirty {
+		return appErrors.ErrMigrationsDirty
+	}
+
+	if version != s.migrationVersion {
+		return fmt.Errorf("%w: have %d, want %d", appErrors.ErrMigrationsOutdated, version, s.migrationVersion)
+	}
+
+	return nil
+}
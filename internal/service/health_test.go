@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+)
+
+func TestHealthReady(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockHealthRepository(ctrl)
+
+	testCases := []struct {
+		name        string
+		mockRepo    func()
+		draining    bool
+		expectedErr error
+	}{
+		{
+			name: "ready",
+			mockRepo: func() {
+				mockRepo.EXPECT().Ping(gomock.Any()).Return(nil).Times(1)
+				mockRepo.EXPECT().MigrationVersion(gomock.Any()).Return(uint(2), false, nil).Times(1)
+			},
+		},
+		{
+			name: "database unavailable",
+			mockRepo: func() {
+				mockRepo.EXPECT().Ping(gomock.Any()).Return(errors.New("connection refused")).Times(1)
+			},
+			expectedErr: errors.New("connection refused"),
+		},
+		{
+			name: "dirty migrations",
+			mockRepo: func() {
+				mockRepo.EXPECT().Ping(gomock.Any()).Return(nil).Times(1)
+				mockRepo.EXPECT().MigrationVersion(gomock.Any()).Return(uint(2), true, nil).Times(1)
+			},
+			expectedErr: appErrors.ErrMigrationsDirty,
+		},
+		{
+			name: "outdated migrations",
+			mockRepo: func() {
+				mockRepo.EXPECT().Ping(gomock.Any()).Return(nil).Times(1)
+				mockRepo.EXPECT().MigrationVersion(gomock.Any()).Return(uint(1), false, nil).Times(1)
+			},
+			expectedErr: appErrors.ErrMigrationsOutdated,
+		},
+		{
+			name:        "draining",
+			mockRepo:    func() {},
+			draining:    true,
+			expectedErr: appErrors.ErrDraining,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			healthService := NewHealth(mockRepo, 2)
+			if testCase.draining {
+				healthService.SetDraining()
+			}
+
+			testCase.mockRepo()
+
+			err := healthService.Ready(context.Background())
+
+			if testCase.expectedErr != nil {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), testCase.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHealthReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockHealthRepository(ctrl)
+	healthService := NewHealth(mockRepo, 2)
+
+	mockRepo.EXPECT().Ping(gomock.Any()).Return(nil).Times(1)
+	mockRepo.EXPECT().MigrationVersion(gomock.Any()).Return(uint(1), false, nil).Times(1)
+
+	report := healthService.Report(context.Background())
+
+	require.Equal(t, domain.StatusDown, report.Status)
+	require.False(t, report.Draining)
+	require.Len(t, report.Dependencies, 2)
+	require.Equal(t, domain.StatusUp, report.Dependencies[0].Status)
+	require.Equal(t, domain.StatusDown, report.Dependencies[1].Status)
+	require.Contains(t, report.Dependencies[1].Error, appErrors.ErrMigrationsOutdated.Error())
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/metrics"
+	"github.com/Te8va/MerchStore/pkg/logger"
+)
+
+type Issuance struct {
+	repo     domain.IssuanceRepository
+	policies []domain.IssuancePolicy
+	now      func() time.Time
+}
+
+func NewIssuance(repo domain.IssuanceRepository, policies []domain.IssuancePolicy) *Issuance {
+	return &Issuance{repo: repo, policies: policies, now: time.Now}
+}
+
+// RunDue pays every policy whose current period has not been paid yet.
+func (s *Issuance) RunDue(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "service.RunDue")
+	defer span.End()
+
+	now := s.now()
+
+	var errs []error
+	for _, policy := range s.policies {
+		periodKey := policy.PeriodKey(now)
+
+		recipients, issued, err := s.repo.RunIssuance(ctx, policy, periodKey)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("service.RunDue: %s: %w", policy.Name, err))
+			continue
+		}
+		if !issued {
+			continue
+		}
+
+		metrics.CoinsIssued.WithLabelValues(domain.TransactionKindAllowance).Add(float64(policy.Amount * recipients))
+		logger.FromContext(ctx).Info("Issuance policy paid",
+			zap.String("policy", policy.Name),
+			zap.String("period", periodKey),
+			zap.Int("amount", policy.Amount),
+			zap.Int("recipients", recipients))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Run calls RunDue immediately and then every interval until ctx is done.
+// Failures are logged and retried on the next tick.
+func (s *Issuance) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.RunDue(ctx); err != nil && ctx.Err() == nil {
+			logger.FromContext(ctx).Error("Issuance run failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+)
+
+func TestIssuanceRunDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIssuanceRepository(ctrl)
+
+	monthly := domain.IssuancePolicy{Name: "monthly-allowance", Period: domain.PeriodMonthly, Amount: 100}
+	daily := domain.IssuancePolicy{Name: "daily-bonus", Period: domain.PeriodDaily, Amount: 5}
+	weekly := domain.IssuancePolicy{Name: "weekly-bonus", Period: domain.PeriodWeekly, Amount: 10}
+
+	issuance := NewIssuance(mockRepo, []domain.IssuancePolicy{monthly, daily, weekly})
+	issuance.now = func() time.Time { return time.Date(2025, time.January, 1, 10, 0, 0, 0, time.UTC) }
+
+	testCases := []struct {
+		name        string
+		mockRepo    func()
+		expectedErr error
+	}{
+		{
+			name: "pays every due policy",
+			mockRepo: func() {
+				mockRepo.EXPECT().RunIssuance(gomock.Any(), monthly, "2025-01").Return(3, true, nil).Times(1)
+				mockRepo.EXPECT().RunIssuance(gomock.Any(), daily, "2025-01-01").Return(3, true, nil).Times(1)
+				mockRepo.EXPECT().RunIssuance(gomock.Any(), weekly, "2025-W01").Return(3, true, nil).Times(1)
+			},
+		},
+		{
+			name: "already paid periods are skipped",
+			mockRepo: func() {
+				mockRepo.EXPECT().RunIssuance(gomock.Any(), monthly, "2025-01").Return(0, false, nil).Times(1)
+				mockRepo.EXPECT().RunIssuance(gomock.Any(), daily, "2025-01-01").Return(0, false, nil).Times(1)
+				mockRepo.EXPECT().RunIssuance(gomock.Any(), weekly, "2025-W01").Return(0, false, nil).Times(1)
+			},
+		},
+		{
+			name: "failed policy does not block the others",
+			mockRepo: func() {
+				mockRepo.EXPECT().RunIssuance(gomock.Any(), monthly, "2025-01").Return(0, false, errors.New("db error")).Times(1)
+				mockRepo.EXPECT().RunIssuance(gomock.Any(), daily, "2025-01-01").Return(3, true, nil).Times(1)
+				mockRepo.EXPECT().RunIssuance(gomock.Any(), weekly, "2025-W01").Return(3, true, nil).Times(1)
+			},
+			expectedErr: errors.New("service.RunDue: monthly-allowance: db error"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockRepo()
+
+			err := issuance.RunDue(context.Background())
+
+			if testCase.expectedErr != nil {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), testCase.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/metrics"
+)
+
+const maxRoleLength = 50
+
+// checkItemRules applies the per-item purchase rule for req.Item before any
+// lock is taken. The repository checks the rule again under the buyer's
+// row lock, so concurrent purchases cannot exceed a limit together.
+func (s *Merch) checkItemRules(ctx context.Context, req domain.PurchaseRequest) error {
+	rule, err := s.repo.GetItemRule(ctx, req.Item)
+	if err != nil {
+		return fmt.Errorf("service.checkItemRules: %w", err)
+	}
+
+	if !rule.Active() {
+		return nil
+	}
+
+	now := s.now()
+	stats, err := s.repo.GetPurchaseStats(ctx, req.Username, req.Item, domain.PeriodStart(rule.Period, now))
+	if err != nil {
+		return fmt.Errorf("service.checkItemRules: %w", err)
+	}
+
+	if err := rule.Check(stats, req.Quantity, now); err != nil {
+		return rejectPurchase(err)
+	}
+
+	return nil
+}
+
+// rejectPurchase counts a rule rejection by the code of the violated rule.
+func rejectPurchase(err error) error {
+	var ruleErr *appErrors.RuleError
+	if errors.As(err, &ruleErr) {
+		metrics.ItemRuleRejections.WithLabelValues(ruleErr.Code).Inc()
+	}
+	return err
+}
+
+type ItemRule struct {
+	repo domain.ItemRuleRepository
+}
+
+func NewItemRule(repo domain.ItemRuleRepository) *ItemRule {
+	return &ItemRule{repo: repo}
+}
+
+func (s *ItemRule) ListItemRules(ctx context.Context) ([]domain.ItemRule, error) {
+	ctx, span := tracer.Start(ctx, "service.ListItemRules")
+	defer span.End()
+
+	rules, err := s.repo.ListItemRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListItemRules: %w", err)
+	}
+
+	return rules, nil
+}
+
+func (s *ItemRule) SaveItemRule(ctx context.Context, rule domain.ItemRule) (domain.ItemRule, error) {
+	ctx, span := tracer.Start(ctx, "service.SaveItemRule")
+	defer span.End()
+
+	rule.RequiredRole = strings.TrimSpace(rule.RequiredRole)
+	if rule.Period == "" {
+		rule.Period = domain.PeriodMonthly
+	}
+
+	switch {
+	case rule.MaxPerUser < 0, rule.MaxPerPeriod < 0, rule.MinAccountAgeDays < 0:
+		return domain.ItemRule{}, fmt.Errorf("%w: limits must not be negative", appErrors.ErrInvalidItemRule)
+	case rule.Period != domain.PeriodDaily && rule.Period != domain.PeriodWeekly && rule.Period != domain.PeriodMonthly:
+		return domain.ItemRule{}, fmt.Errorf("%w: period must be daily, weekly or monthly", appErrors.ErrInvalidItemRule)
+	case len(rule.RequiredRole) > maxRoleLength:
+		return domain.ItemRule{}, fmt.Errorf("%w: role is too long", appErrors.ErrInvalidItemRule)
+	}
+
+	saved, err := s.repo.SaveItemRule(ctx, rule)
+	if err != nil {
+		return domain.ItemRule{}, fmt.Errorf("service.SaveItemRule: %w", err)
+	}
+
+	return saved, nil
+}
+
+func (s *ItemRule) DeleteItemRule(ctx context.Context, item string) error {
+	ctx, span := tracer.Start(ctx, "service.DeleteItemRule")
+	defer span.End()
+
+	if err := s.repo.DeleteItemRule(ctx, item); err != nil {
+		return fmt.Errorf("service.DeleteItemRule: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ItemRule) SetUserRole(ctx context.Context, username, role string) error {
+	ctx, span := tracer.Start(ctx, "service.SetUserRole")
+	defer span.End()
+
+	role = strings.TrimSpace(role)
+	if role == "" || len(role) > maxRoleLength {
+		return appErrors.ErrInvalidRole
+	}
+	if username == domain.SystemAccount {
+		return appErrors.ErrSystemAccount
+	}
+
+	if err := s.repo.SetUserRole(ctx, username, role); err != nil {
+		return fmt.Errorf("service.SetUserRole: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+)
+
+func TestBuyMerchItemRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMerchRepository(ctrl)
+	merchService := NewMerch(mockRepo, domain.TransferPolicy{})
+
+	now := time.Date(2025, time.March, 12, 15, 0, 0, 0, time.UTC)
+	merchService.now = func() time.Time { return now }
+	weekStart := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+	oldAccount := now.AddDate(0, -6, 0)
+
+	rule := domain.ItemRule{
+		Item:                  "pink-hoody",
+		MaxPerUser:            3,
+		MaxPerPeriod:          2,
+		Period:                domain.PeriodWeekly,
+		MinAccountAgeDays:     30,
+		RequiredRole:          "designer",
+		RequiresReceivedCoins: true,
+	}
+
+	mockRepo.EXPECT().UserExists(gomock.Any(), "user1").Return(true, nil).AnyTimes()
+	mockRepo.EXPECT().GetMerchPrice(gomock.Any(), "pink-hoody").Return(500, nil).AnyTimes()
+	mockRepo.EXPECT().GetItemRule(gomock.Any(), "pink-hoody").Return(rule, nil).AnyTimes()
+
+	testCases := []struct {
+		name              string
+		quantity          int
+		stats             domain.PurchaseStats
+		saveErr           error
+		succeeds          bool
+		expectedErr       error
+		expectedCode      string
+		expectedRemaining int
+	}{
+		{
+			name:     "all rules pass",
+			quantity: 1,
+			stats:    domain.PurchaseStats{Role: "designer", CreatedAt: oldAccount, ReceivedCoins: true, Bought: 2, BoughtSince: 1},
+			succeeds: true,
+		},
+		{
+			name:         "wrong role",
+			quantity:     1,
+			stats:        domain.PurchaseStats{Role: domain.RoleEmployee, CreatedAt: oldAccount, ReceivedCoins: true},
+			expectedErr:  appErrors.ErrNotEligible,
+			expectedCode: domain.RuleRoleRequired,
+		},
+		{
+			name:         "account too new",
+			quantity:     1,
+			stats:        domain.PurchaseStats{Role: "designer", CreatedAt: now.AddDate(0, 0, -29), ReceivedCoins: true},
+			expectedErr:  appErrors.ErrNotEligible,
+			expectedCode: domain.RuleMinAccountAge,
+		},
+		{
+			name:         "never received coins",
+			quantity:     1,
+			stats:        domain.PurchaseStats{Role: "designer", CreatedAt: oldAccount},
+			expectedErr:  appErrors.ErrNotEligible,
+			expectedCode: domain.RuleReceivedCoinsRequired,
+		},
+		{
+			name:              "per-user maximum",
+			quantity:          2,
+			stats:             domain.PurchaseStats{Role: "designer", CreatedAt: oldAccount, ReceivedCoins: true, Bought: 2},
+			expectedErr:       appErrors.ErrItemLimitReached,
+			expectedCode:      domain.RuleMaxPerUser,
+			expectedRemaining: 1,
+		},
+		{
+			name:              "per-period maximum",
+			quantity:          2,
+			stats:             domain.PurchaseStats{Role: "designer", CreatedAt: oldAccount, ReceivedCoins: true, Bought: 1, BoughtSince: 1},
+			expectedErr:       appErrors.ErrItemPeriodLimitReached,
+			expectedCode:      domain.RuleMaxPerPeriod,
+			expectedRemaining: 1,
+		},
+		{
+			name:     "per-user maximum reached by a concurrent purchase",
+			quantity: 1,
+			stats:    domain.PurchaseStats{Role: "designer", CreatedAt: oldAccount, ReceivedCoins: true, Bought: 2},
+			saveErr: &appErrors.RuleError{
+				Err:  &appErrors.LimitError{Err: appErrors.ErrItemLimitReached},
+				Code: domain.RuleMaxPerUser,
+			},
+			expectedErr:  appErrors.ErrItemLimitReached,
+			expectedCode: domain.RuleMaxPerUser,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockRepo.EXPECT().GetPurchaseStats(gomock.Any(), "user1", "pink-hoody", weekStart).Return(testCase.stats, nil).Times(1)
+			if testCase.succeeds || testCase.saveErr != nil {
+				mockRepo.EXPECT().GetPromotions(gomock.Any(), "pink-hoody", "", now).Return(nil, nil).Times(1)
+				mockRepo.EXPECT().GetUserBalance(gomock.Any(), "user1").Return(1000, nil).Times(1)
+				mockRepo.EXPECT().SavePurchase(gomock.Any(), gomock.Any(), now).Return(domain.Purchase{ID: 1}, testCase.saveErr).Times(1)
+			}
+
+			_, err := merchService.BuyMerch(context.Background(), domain.PurchaseRequest{Username: "user1", Item: "pink-hoody", Quantity: testCase.quantity})
+
+			if testCase.succeeds {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, testCase.expectedErr)
+
+			var ruleErr *appErrors.RuleError
+			require.True(t, errors.As(err, &ruleErr))
+			require.Equal(t, testCase.expectedCode, ruleErr.Code)
+
+			var limitErr *appErrors.LimitError
+			if errors.As(err, &limitErr) {
+				require.Equal(t, testCase.expectedRemaining, limitErr.Remaining)
+			}
+		})
+	}
+}
+
+func TestItemRuleService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockItemRuleRepository(ctrl)
+	itemRuleService := NewItemRule(mockRepo)
+
+	_, err := itemRuleService.SaveItemRule(context.Background(), domain.ItemRule{Item: "cup", MaxPerUser: -1})
+	require.ErrorIs(t, err, appErrors.ErrInvalidItemRule)
+
+	_, err = itemRuleService.SaveItemRule(context.Background(), domain.ItemRule{Item: "cup", MaxPerPeriod: 1, Period: "yearly"})
+	require.ErrorIs(t, err, appErrors.ErrInvalidItemRule)
+
+	// Период по умолчанию - месяц
+	mockRepo.EXPECT().SaveItemRule(gomock.Any(), domain.ItemRule{Item: "cup", MaxPerPeriod: 1, Period: domain.PeriodMonthly}).
+		Return(domain.ItemRule{Item: "cup", MaxPerPeriod: 1, Period: domain.PeriodMonthly}, nil).Times(1)
+	_, err = itemRuleService.SaveItemRule(context.Background(), domain.ItemRule{Item: "cup", MaxPerPeriod: 1})
+	require.NoError(t, err)
+
+	err = itemRuleService.SetUserRole(context.Background(), "user1", " ")
+	require.ErrorIs(t, err, appErrors.ErrInvalidRole)
+
+	err = itemRuleService.SetUserRole(context.Background(), domain.SystemAccount, "designer")
+	require.ErrorIs(t, err, appErrors.ErrSystemAccount)
+
+	mockRepo.EXPECT().SetUserRole(gomock.Any(), "user1", "designer").Return(nil).Times(1)
+	require.NoError(t, itemRuleService.SetUserRole(context.Background(), "user1", " designer "))
+}
+
+func TestPeriodStart(t *testing.T) {
+	at := time.Date(2025, time.March, 16, 23, 30, 0, 0, time.UTC)
+
+	require.Equal(t, time.Date(2025, time.March, 16, 0, 0, 0, 0, time.UTC), domain.PeriodStart(domain.PeriodDaily, at))
+	require.Equal(t, time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC), domain.PeriodStart(domain.PeriodWeekly, at))
+	require.Equal(t, time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), domain.PeriodStart(domain.PeriodMonthly, at))
+}
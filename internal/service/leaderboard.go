@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/pkg/logger"
+)
+
+// Leaderboard serves rankings from an in-memory cache that Run refreshes, so
+// requests never aggregate transactions themselves.
+type Leaderboard struct {
+	repo domain.LeaderboardRepository
+	now  func() time.Time
+
+	mu     sync.RWMutex
+	boards map[string]domain.Leaderboard
+}
+
+func NewLeaderboard(repo domain.LeaderboardRepository) *Leaderboard {
+	return &Leaderboard{repo: repo, now: time.Now, boards: make(map[string]domain.Leaderboard)}
+}
+
+func (s *Leaderboard) GetLeaderboard(ctx context.Context, board, period string, limit int) (domain.Leaderboard, error) {
+	ctx, span := tracer.Start(ctx, "service.GetLeaderboard")
+	defer span.End()
+
+	if !slices.Contains(domain.LeaderboardBoards, board) {
+		return domain.Leaderboard{}, fmt.Errorf("%w: unknown board %q", appErrors.ErrInvalidLeaderboard, board)
+	}
+	if !slices.Contains(domain.LeaderboardPeriods, period) {
+		return domain.Leaderboard{}, fmt.Errorf("%w: unknown period %q", appErrors.ErrInvalidLeaderboard, period)
+	}
+
+	s.mu.RLock()
+	leaderboard, ok := s.boards[leaderboardKey(board, period)]
+	s.mu.RUnlock()
+
+	// A period that rolled over since the last refresh must not show the
+	// previous week's or month's ranking.
+	if !ok || !sameSince(leaderboard.Since, leaderboardSince(period, s.now())) {
+		var err error
+		leaderboard, err = s.refreshBoard(ctx, board, period)
+		if err != nil {
+			return domain.Leaderboard{}, fmt.Errorf("service.GetLeaderboard: %w", err)
+		}
+	}
+
+	leaderboard.Entries = leaderboard.Entries[:min(limit, len(leaderboard.Entries))]
+	return leaderboard, nil
+}
+
+// SetOptOut hides or shows the user on public boards. Hiding also removes
+// the user from the boards cached on this instance right away.
+func (s *Leaderboard) SetOptOut(ctx context.Context, username string, optOut bool) error {
+	ctx, span := tracer.Start(ctx, "service.SetOptOut")
+	defer span.End()
+
+	if err := s.repo.SetLeaderboardOptOut(ctx, username, optOut); err != nil {
+		return fmt.Errorf("service.SetOptOut: %w", err)
+	}
+
+	if optOut {
+		s.mu.Lock()
+		for key, leaderboard := range s.boards {
+			leaderboard.Entries = slices.DeleteFunc(slices.Clone(leaderboard.Entries), func(entry domain.LeaderboardEntry) bool {
+				return entry.Username == username
+			})
+			s.boards[key] = leaderboard
+		}
+		s.mu.Unlock()
+	}
+
+	return nil
+}
+
+func (s *Leaderboard) Refresh(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "service.Refresh")
+	defer span.End()
+
+	for _, board := range domain.LeaderboardBoards {
+		for _, period := range domain.LeaderboardPeriods {
+			if _, err := s.refreshBoard(ctx, board, period); err != nil {
+				return fmt.Errorf("service.Refresh: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Leaderboard) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Refresh(ctx); err != nil && ctx.Err() == nil {
+			logger.FromContext(ctx).Error("Leaderboard refresh failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Leaderboard) refreshBoard(ctx context.Context, board, period string) (domain.Leaderboard, error) {
+	now := s.now()
+	since := leaderboardSince(period, now)
+
+	entries, err := s.repo.GetLeaderboard(ctx, board, since, domain.LeaderboardSize)
+	if err != nil {
+		return domain.Leaderboard{}, err
+	}
+
+	leaderboard := domain.Leaderboard{Board: board, Period: period, UpdatedAt: now, Entries: entries}
+	if !since.IsZero() {
+		leaderboard.Since = &since
+	}
+
+	s.mu.Lock()
+	s.boards[leaderboardKey(board, period)] = leaderboard
+	s.mu.Unlock()
+
+	return leaderboard, nil
+}
+
+func leaderboardSince(period string, now time.Time) time.Time {
+	switch period {
+	case domain.LeaderboardWeek:
+		return domain.PeriodStart(domain.PeriodWeekly, now)
+	case domain.LeaderboardMonth:
+		return domain.PeriodStart(domain.PeriodMonthly, now)
+	default:
+		return time.Time{}
+	}
+}
+
+func sameSince(cached *time.Time, since time.Time) bool {
+	if cached == nil {
+		return since.IsZero()
+	}
+	return cached.Equal(since)
+}
+
+func leaderboardKey(board, period string) string {
+	return board + ":" + period
+}
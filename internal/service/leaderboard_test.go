@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+)
+
+func TestLeaderboardCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockLeaderboardRepository(ctrl)
+	leaderboardService := NewLeaderboard(mockRepo)
+
+	now := time.Date(2025, time.June, 4, 12, 0, 0, 0, time.UTC)
+	leaderboardService.now = func() time.Time { return now }
+	monday := time.Date(2025, time.June, 2, 0, 0, 0, 0, time.UTC)
+
+	entries := []domain.LeaderboardEntry{
+		{Rank: 1, Username: "alice", Amount: 300},
+		{Rank: 2, Username: "bob", Amount: 200},
+		{Rank: 3, Username: "carol", Amount: 100},
+	}
+
+	// Первый запрос заполняет кэш, следующие читают из него
+	mockRepo.EXPECT().GetLeaderboard(gomock.Any(), domain.BoardReceived, monday, domain.LeaderboardSize).Return(entries, nil).Times(1)
+	leaderboard, err := leaderboardService.GetLeaderboard(context.Background(), domain.BoardReceived, domain.LeaderboardWeek, 2)
+	require.NoError(t, err)
+	require.Len(t, leaderboard.Entries, 2)
+	require.Equal(t, monday, *leaderboard.Since)
+
+	leaderboard, err = leaderboardService.GetLeaderboard(context.Background(), domain.BoardReceived, domain.LeaderboardWeek, 10)
+	require.NoError(t, err)
+	require.Len(t, leaderboard.Entries, 3)
+
+	// Отказ от участия сразу убирает пользователя из кэша
+	mockRepo.EXPECT().SetLeaderboardOptOut(gomock.Any(), "bob", true).Return(nil).Times(1)
+	require.NoError(t, leaderboardService.SetOptOut(context.Background(), "bob", true))
+	leaderboard, err = leaderboardService.GetLeaderboard(context.Background(), domain.BoardReceived, domain.LeaderboardWeek, 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{"alice", "carol"}, []string{leaderboard.Entries[0].Username, leaderboard.Entries[1].Username})
+	require.Len(t, entries, 3)
+
+	// С началом новой недели кэш прошлой недели не используется
+	now = now.AddDate(0, 0, 7)
+	mockRepo.EXPECT().GetLeaderboard(gomock.Any(), domain.BoardReceived, monday.AddDate(0, 0, 7), domain.LeaderboardSize).
+		Return([]domain.LeaderboardEntry{}, nil).Times(1)
+	leaderboard, err = leaderboardService.GetLeaderboard(context.Background(), domain.BoardReceived, domain.LeaderboardWeek, 10)
+	require.NoError(t, err)
+	require.Empty(t, leaderboard.Entries)
+
+	_, err = leaderboardService.GetLeaderboard(context.Background(), "richest", domain.LeaderboardWeek, 10)
+	require.ErrorIs(t, err, appErrors.ErrInvalidLeaderboard)
+	_, err = leaderboardService.GetLeaderboard(context.Background(), domain.BoardSpent, "year", 10)
+	require.ErrorIs(t, err, appErrors.ErrInvalidLeaderboard)
+}
+
+func TestLeaderboardRefresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockLeaderboardRepository(ctrl)
+	leaderboardService := NewLeaderboard(mockRepo)
+
+	// Обновление пересчитывает все доски за все периоды, "за всё время" - без нижней границы
+	mockRepo.EXPECT().GetLeaderboard(gomock.Any(), gomock.Any(), gomock.Any(), domain.LeaderboardSize).
+		Return([]domain.LeaderboardEntry{}, nil).Times(len(domain.LeaderboardBoards) * len(domain.LeaderboardPeriods))
+	require.NoError(t, leaderboardService.Refresh(context.Background()))
+
+	leaderboard, err := leaderboardService.GetLeaderboard(context.Background(), domain.BoardSpent, domain.LeaderboardAllTime, 10)
+	require.NoError(t, err)
+	require.Nil(t, leaderboard.Since)
+}
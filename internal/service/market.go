@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/metrics"
+)
+
+type Market struct {
+	repo   domain.MarketRepository
+	policy domain.TransferPolicy
+	now    func() time.Time
+}
+
+func NewMarket(repo domain.MarketRepository, policy domain.TransferPolicy) *Market {
+	return &Market{repo: repo, policy: policy, now: time.Now}
+}
+
+func (s *Market) TransferItem(ctx context.Context, transfer domain.ItemTransfer) error {
+	ctx, span := tracer.Start(ctx, "service.TransferItem")
+	defer span.End()
+
+	quantity, err := normalizeItemQuantity(transfer.Quantity)
+	if err != nil {
+		return err
+	}
+	transfer.Quantity = quantity
+	transfer.Item = strings.TrimSpace(transfer.Item)
+	transfer.Variant = strings.TrimSpace(transfer.Variant)
+
+	if transfer.Item == "" {
+		return appErrors.ErrItemNotFound
+	}
+	if transfer.ToUser == domain.SystemAccount {
+		return appErrors.ErrSystemAccount
+	}
+	if transfer.ToUser == transfer.FromUser {
+		return appErrors.ErrSelfTransfer
+	}
+
+	message, err := normalizeMessage(transfer.Message)
+	if err != nil {
+		return err
+	}
+	transfer.Message = message
+
+	if err := s.repo.TransferItem(ctx, transfer, s.now()); err != nil {
+		var ruleErr *appErrors.RuleError
+		if errors.As(err, &ruleErr) {
+			return rejectPurchase(ruleErr)
+		}
+		if errors.Is(err, appErrors.ErrItemNotInInventory) || errors.Is(err, appErrors.ErrUserNotFound) {
+			return err
+		}
+		return fmt.Errorf("service.TransferItem: %w", err)
+	}
+
+	metrics.ItemTransfers.WithLabelValues(transfer.Item).Add(float64(transfer.Quantity))
+
+	return nil
+}
+
+func (s *Market) CreateListing(ctx context.Context, listing domain.Listing) (domain.Listing, error) {
+	ctx, span := tracer.Start(ctx, "service.CreateListing")
+	defer span.End()
+
+	quantity, err := normalizeItemQuantity(listing.Quantity)
+	if err != nil {
+		return domain.Listing{}, err
+	}
+	if listing.Price <= 0 || listing.Price > math.MaxInt32 {
+		return domain.Listing{}, appErrors.ErrInvalidPrice
+	}
+
+	listing.Item = strings.TrimSpace(listing.Item)
+	if listing.Item == "" {
+		return domain.Listing{}, appErrors.ErrItemNotFound
+	}
+
+	listing, err = s.repo.CreateListing(ctx, domain.Listing{
+		Seller:   listing.Seller,
+		Item:     listing.Item,
+		Variant:  strings.TrimSpace(listing.Variant),
+		Quantity: quantity,
+		Price:    listing.Price,
+	})
+	if err != nil {
+		if errors.Is(err, appErrors.ErrItemNotInInventory) {
+			return domain.Listing{}, err
+		}
+		return domain.Listing{}, fmt.Errorf("service.CreateListing: %w", err)
+	}
+
+	metrics.MarketListings.WithLabelValues(domain.ListingStatusOpen).Inc()
+
+	return listing, nil
+}
+
+func (s *Market) CancelListing(ctx context.Context, id int, seller string) (domain.Listing, error) {
+	ctx, span := tracer.Start(ctx, "service.CancelListing")
+	defer span.End()
+
+	if id <= 0 {
+		return domain.Listing{}, appErrors.ErrListingNotFound
+	}
+
+	listing, err := s.repo.CancelListing(ctx, id, seller)
+	if err != nil {
+		return domain.Listing{}, fmt.Errorf("service.CancelListing: %w", err)
+	}
+
+	metrics.MarketListings.WithLabelValues(domain.ListingStatusCancelled).Inc()
+
+	return listing, nil
+}
+
+func (s *Market) BuyListing(ctx context.Context, id int, buyer string) (domain.Listing, error) {
+	ctx, span := tracer.Start(ctx, "service.BuyListing")
+	defer span.End()
+
+	if id <= 0 {
+		return domain.Listing{}, appErrors.ErrListingNotFound
+	}
+
+	listing, err := s.repo.BuyListing(ctx, id, buyer, s.policy, s.now())
+	if err != nil {
+		var ruleErr *appErrors.RuleError
+		if errors.As(err, &ruleErr) {
+			return domain.Listing{}, rejectPurchase(ruleErr)
+		}
+		countTransferRejection(err)
+		if errors.Is(err, appErrors.ErrInsufficientBalance) {
+			metrics.InsufficientBalance.WithLabelValues("buy_listing").Inc()
+		}
+		return domain.Listing{}, fmt.Errorf("service.BuyListing: %w", err)
+	}
+
+	metrics.MarketListings.WithLabelValues(domain.ListingStatusSold).Inc()
+	metrics.MarketSales.WithLabelValues(listing.Item).Add(float64(listing.Quantity))
+
+	return listing, nil
+}
+
+func (s *Market) ListListings(ctx context.Context, filter domain.ListingFilter) ([]domain.Listing, error) {
+	ctx, span := tracer.Start(ctx, "service.ListListings")
+	defer span.End()
+
+	filter.Item = strings.TrimSpace(filter.Item)
+	filter.Seller = strings.TrimSpace(filter.Seller)
+
+	listings, err := s.repo.ListListings(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListListings: %w", err)
+	}
+
+	return listings, nil
+}
+
+// normalizeItemQuantity defaults an omitted quantity to a single unit.
+func normalizeItemQuantity(quantity int) (int, error) {
+	if quantity == 0 {
+		return 1, nil
+	}
+	if quantity < 0 || quantity > domain.MaxPurchaseQuantity {
+		return 0, appErrors.ErrInvalidQuantity
+	}
+	return quantity, nil
+}
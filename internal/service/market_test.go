@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+)
+
+func TestMarketTransferItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMarketRepository(ctrl)
+	marketService := NewMarket(mockRepo, domain.TransferPolicy{})
+
+	testCases := []struct {
+		name        string
+		transfer    domain.ItemTransfer
+		mockRepo    func()
+		expectedErr error
+	}{
+		{
+			name:     "successful transfer defaults to one unit",
+			transfer: domain.ItemTransfer{FromUser: "user1", ToUser: "user2", Item: "cup", Message: " enjoy "},
+			mockRepo: func() {
+				mockRepo.EXPECT().TransferItem(gomock.Any(), domain.ItemTransfer{
+					FromUser: "user1", ToUser: "user2", Item: "cup", Quantity: 1, Message: "enjoy",
+				}, gomock.Any()).Return(nil).Times(1)
+			},
+		},
+		{
+			name:        "self transfer",
+			transfer:    domain.ItemTransfer{FromUser: "user1", ToUser: "user1", Item: "cup"},
+			mockRepo:    func() {},
+			expectedErr: appErrors.ErrSelfTransfer,
+		},
+		{
+			name:        "system account",
+			transfer:    domain.ItemTransfer{FromUser: "user1", ToUser: domain.SystemAccount, Item: "cup"},
+			mockRepo:    func() {},
+			expectedErr: appErrors.ErrSystemAccount,
+		},
+		{
+			name:        "quantity too large",
+			transfer:    domain.ItemTransfer{FromUser: "user1", ToUser: "user2", Item: "cup", Quantity: domain.MaxPurchaseQuantity + 1},
+			mockRepo:    func() {},
+			expectedErr: appErrors.ErrInvalidQuantity,
+		},
+		{
+			name:     "recipient reached item limit",
+			transfer: domain.ItemTransfer{FromUser: "user1", ToUser: "user2", Item: "pink-hoody"},
+			mockRepo: func() {
+				mockRepo.EXPECT().TransferItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(&appErrors.RuleError{
+					Err:  &appErrors.LimitError{Err: appErrors.ErrItemLimitReached},
+					Code: domain.RuleMaxPerUser,
+				}).Times(1)
+			},
+			expectedErr: appErrors.ErrItemLimitReached,
+		},
+		{
+			name:     "item not in inventory",
+			transfer: domain.ItemTransfer{FromUser: "user1", ToUser: "user2", Item: "cup", Quantity: 2},
+			mockRepo: func() {
+				mockRepo.EXPECT().TransferItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(appErrors.ErrItemNotInInventory).Times(1)
+			},
+			expectedErr: appErrors.ErrItemNotInInventory,
+		},
+		{
+			name:     "db error",
+			transfer: domain.ItemTransfer{FromUser: "user1", ToUser: "user2", Item: "cup"},
+			mockRepo: func() {
+				mockRepo.EXPECT().TransferItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("db error")).Times(1)
+			},
+			expectedErr: errors.New("service.TransferItem: db error"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockRepo()
+
+			err := marketService.TransferItem(context.Background(), testCase.transfer)
+
+			if testCase.expectedErr != nil {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), testCase.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMarketListings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMarketRepository(ctrl)
+	marketService := NewMarket(mockRepo, domain.TransferPolicy{})
+
+	_, err := marketService.CreateListing(context.Background(), domain.Listing{Seller: "user1", Item: "cup", Quantity: 1, Price: 0})
+	require.ErrorIs(t, err, appErrors.ErrInvalidPrice)
+
+	_, err = marketService.CreateListing(context.Background(), domain.Listing{Seller: "user1", Item: "cup", Quantity: -1, Price: 10})
+	require.ErrorIs(t, err, appErrors.ErrInvalidQuantity)
+
+	mockRepo.EXPECT().CreateListing(gomock.Any(), domain.Listing{Seller: "user1", Item: "cup", Quantity: 1, Price: 50}).
+		Return(domain.Listing{ID: 3, Seller: "user1", Item: "cup", Quantity: 1, Price: 50, Status: domain.ListingStatusOpen}, nil).Times(1)
+	listing, err := marketService.CreateListing(context.Background(), domain.Listing{Seller: "user1", Item: "cup", Quantity: 0, Price: 50})
+	require.NoError(t, err)
+	require.Equal(t, 3, listing.ID)
+
+	// Покупка закрытого лота
+	mockRepo.EXPECT().BuyListing(gomock.Any(), 3, "user2", domain.TransferPolicy{}, gomock.Any()).Return(domain.Listing{}, appErrors.ErrListingClosed).Times(1)
+	_, err = marketService.BuyListing(context.Background(), 3, "user2")
+	require.ErrorIs(t, err, appErrors.ErrListingClosed)
+
+	mockRepo.EXPECT().BuyListing(gomock.Any(), 3, "user2", domain.TransferPolicy{}, gomock.Any()).
+		Return(domain.Listing{ID: 3, Item: "cup", Quantity: 1, Status: domain.ListingStatusSold, Buyer: "user2"}, nil).Times(1)
+	listing, err = marketService.BuyListing(context.Background(), 3, "user2")
+	require.NoError(t, err)
+	require.Equal(t, domain.ListingStatusSold, listing.Status)
+
+	_, err = marketService.CancelListing(context.Background(), 0, "user1")
+	require.ErrorIs(t, err, appErrors.ErrListingNotFound)
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/metrics"
+)
+
+type Notification struct {
+	repo domain.NotificationRepository
+	now  func() time.Time
+}
+
+func NewNotification(repo domain.NotificationRepository) *Notification {
+	return &Notification{repo: repo, now: time.Now}
+}
+
+func (s *Notification) GetInbox(ctx context.Context, username string, filter domain.NotificationFilter) (domain.Inbox, error) {
+	ctx, span := tracer.Start(ctx, "service.GetInbox")
+	defer span.End()
+
+	notifications, err := s.repo.ListNotifications(ctx, username, filter)
+	if err != nil {
+		return domain.Inbox{}, fmt.Errorf("service.GetInbox: %w", err)
+	}
+
+	unread, err := s.repo.CountUnread(ctx, username)
+	if err != nil {
+		return domain.Inbox{}, fmt.Errorf("service.GetInbox: %w", err)
+	}
+
+	return domain.Inbox{Unread: unread, Notifications: notifications}, nil
+}
+
+// MarkRead marks the given notifications as read, or the whole inbox when
+// ids is empty, and returns the number of notifications left unread.
+func (s *Notification) MarkRead(ctx context.Context, username string, ids []int64) (int, error) {
+	ctx, span := tracer.Start(ctx, "service.MarkRead")
+	defer span.End()
+
+	if len(ids) > domain.MaxMarkReadIDs {
+		return 0, appErrors.ErrTooManyNotificationIDs
+	}
+
+	marked, err := s.repo.MarkRead(ctx, username, ids, s.now())
+	if err != nil {
+		return 0, fmt.Errorf("service.MarkRead: %w", err)
+	}
+	metrics.NotificationsRead.Add(float64(marked))
+
+	unread, err := s.repo.CountUnread(ctx, username)
+	if err != nil {
+		return 0, fmt.Errorf("service.MarkRead: %w", err)
+	}
+
+	return unread, nil
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+)
+
+func TestNotificationInbox(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockNotificationRepository(ctrl)
+	notificationService := NewNotification(mockRepo)
+
+	filter := domain.NotificationFilter{UnreadOnly: true, Limit: 10}
+	mockRepo.EXPECT().ListNotifications(gomock.Any(), "user1", filter).
+		Return([]domain.Notification{{ID: 3, Kind: domain.NotificationCoinsReceived}}, nil).Times(1)
+	mockRepo.EXPECT().CountUnread(gomock.Any(), "user1").Return(4, nil).Times(1)
+
+	inbox, err := notificationService.GetInbox(context.Background(), "user1", filter)
+	require.NoError(t, err)
+	require.Equal(t, 4, inbox.Unread)
+	require.Len(t, inbox.Notifications, 1)
+
+	mockRepo.EXPECT().ListNotifications(gomock.Any(), "user1", gomock.Any()).Return(nil, errors.New("db error")).Times(1)
+	_, err = notificationService.GetInbox(context.Background(), "user1", filter)
+	require.ErrorContains(t, err, "service.GetInbox: db error")
+}
+
+func TestNotificationMarkRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockNotificationRepository(ctrl)
+	notificationService := NewNotification(mockRepo)
+
+	now := time.Date(2025, time.June, 1, 9, 0, 0, 0, time.UTC)
+	notificationService.now = func() time.Time { return now }
+
+	mockRepo.EXPECT().MarkRead(gomock.Any(), "user1", []int64{3, 5}, now).Return(2, nil).Times(1)
+	mockRepo.EXPECT().CountUnread(gomock.Any(), "user1").Return(1, nil).Times(1)
+	unread, err := notificationService.MarkRead(context.Background(), "user1", []int64{3, 5})
+	require.NoError(t, err)
+	require.Equal(t, 1, unread)
+
+	_, err = notificationService.MarkRead(context.Background(), "user1", make([]int64, domain.MaxMarkReadIDs+1))
+	require.ErrorIs(t, err, appErrors.ErrTooManyNotificationIDs)
+}
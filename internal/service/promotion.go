@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+)
+
+type Promotion struct {
+	repo domain.PromotionRepository
+	now  func() time.Time
+}
+
+func NewPromotion(repo domain.PromotionRepository) *Promotion {
+	return &Promotion{repo: repo, now: time.Now}
+}
+
+func (s *Promotion) CreatePromotion(ctx context.Context, promotion domain.Promotion) (domain.Promotion, error) {
+	ctx, span := tracer.Start(ctx, "service.CreatePromotion")
+	defer span.End()
+
+	promotion, err := s.normalizePromotion(promotion)
+	if err != nil {
+		return domain.Promotion{}, err
+	}
+
+	created, err := s.repo.CreatePromotion(ctx, promotion)
+	if err != nil {
+		return domain.Promotion{}, fmt.Errorf("service.CreatePromotion: %w", err)
+	}
+
+	return created, nil
+}
+
+func (s *Promotion) ListPromotions(ctx context.Context, limit, offset int) ([]domain.Promotion, error) {
+	ctx, span := tracer.Start(ctx, "service.ListPromotions")
+	defer span.End()
+
+	promotions, err := s.repo.ListPromotions(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListPromotions: %w", err)
+	}
+
+	return promotions, nil
+}
+
+func (s *Promotion) EndPromotion(ctx context.Context, id int) (domain.Promotion, error) {
+	ctx, span := tracer.Start(ctx, "service.EndPromotion")
+	defer span.End()
+
+	if id <= 0 {
+		return domain.Promotion{}, appErrors.ErrPromotionNotFound
+	}
+
+	promotion, err := s.repo.EndPromotion(ctx, id, s.now())
+	if err != nil {
+		return domain.Promotion{}, fmt.Errorf("service.EndPromotion: %w", err)
+	}
+
+	return promotion, nil
+}
+
+func (s *Promotion) normalizePromotion(promotion domain.Promotion) (domain.Promotion, error) {
+	promotion.Name = strings.TrimSpace(promotion.Name)
+	promotion.Item = strings.TrimSpace(promotion.Item)
+	promotion.Category = strings.TrimSpace(promotion.Category)
+	promotion.Code = normalizePromoCode(promotion.Code)
+
+	switch {
+	case promotion.Name == "":
+		return promotion, fmt.Errorf("%w: name is required", appErrors.ErrInvalidPromotion)
+	case (promotion.Item == "") == (promotion.Category == ""):
+		return promotion, fmt.Errorf("%w: exactly one of item and category is required", appErrors.ErrInvalidPromotion)
+	case promotion.DiscountType != domain.DiscountPercent && promotion.DiscountType != domain.DiscountFixed:
+		return promotion, fmt.Errorf("%w: discountType must be percent or fixed", appErrors.ErrInvalidPromotion)
+	case promotion.DiscountValue <= 0,
+		promotion.DiscountType == domain.DiscountPercent && promotion.DiscountValue > 100:
+		return promotion, fmt.Errorf("%w: discountValue is out of range", appErrors.ErrInvalidPromotion)
+	case promotion.MaxUses < 0 || promotion.PerUserLimit < 0:
+		return promotion, fmt.Errorf("%w: limits must not be negative", appErrors.ErrInvalidPromotion)
+	case promotion.Code == "" && (promotion.MaxUses > 0 || promotion.PerUserLimit > 0):
+		return promotion, fmt.Errorf("%w: usage limits require a code", appErrors.ErrInvalidPromotion)
+	}
+
+	if promotion.StartsAt.IsZero() {
+		promotion.StartsAt = s.now()
+	}
+	if promotion.EndsAt != nil && !promotion.EndsAt.After(promotion.StartsAt) {
+		return promotion, fmt.Errorf("%w: endsAt must be after startsAt", appErrors.ErrInvalidPromotion)
+	}
+
+	return promotion, nil
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+)
+
+func TestBuyMerchPromotions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMerchRepository(ctrl)
+	merchService := NewMerch(mockRepo, domain.TransferPolicy{})
+
+	sale := domain.Promotion{ID: 1, Name: "summer", Category: "clothing", DiscountType: domain.DiscountPercent, DiscountValue: 10}
+	code := domain.Promotion{ID: 2, Name: "welcome", Item: "hoody", DiscountType: domain.DiscountFixed, DiscountValue: 50, Code: "HELLO"}
+
+	mockRepo.EXPECT().UserExists(gomock.Any(), "user1").Return(true, nil).AnyTimes()
+	mockRepo.EXPECT().GetMerchPrice(gomock.Any(), "hoody").Return(300, nil).AnyTimes()
+	mockRepo.EXPECT().GetItemRule(gomock.Any(), "hoody").Return(domain.ItemRule{Item: "hoody"}, nil).AnyTimes()
+	mockRepo.EXPECT().GetUserBalance(gomock.Any(), "user1").Return(1000, nil).AnyTimes()
+
+	// Автоматическая скидка применяется без кода
+	mockRepo.EXPECT().GetPromotions(gomock.Any(), "hoody", "", gomock.Any()).Return([]domain.Promotion{sale}, nil).Times(1)
+	mockRepo.EXPECT().SavePurchase(gomock.Any(), domain.Purchase{
+		Username: "user1", Item: "hoody", UnitPrice: 300, PaidPrice: 270, Discount: 30, PromotionID: 1, Quantity: 2,
+	}, gomock.Any()).DoAndReturn(func(_ context.Context, purchase domain.Purchase, _ time.Time) (domain.Purchase, error) {
+		return purchase, nil
+	}).Times(1)
+	purchase, err := merchService.BuyMerch(context.Background(), domain.PurchaseRequest{Username: "user1", Item: "hoody", Quantity: 2})
+	require.NoError(t, err)
+	require.Equal(t, 270, purchase.PaidPrice)
+
+	// Скидки не суммируются - выбирается наибольшая
+	mockRepo.EXPECT().GetPromotions(gomock.Any(), "hoody", "HELLO", gomock.Any()).Return([]domain.Promotion{sale, code}, nil).Times(1)
+	mockRepo.EXPECT().SavePurchase(gomock.Any(), domain.Purchase{
+		Username: "user1", Item: "hoody", UnitPrice: 300, PaidPrice: 250, Discount: 50, PromotionID: 2, PromoCode: "HELLO", Quantity: 1,
+	}, gomock.Any()).DoAndReturn(func(_ context.Context, purchase domain.Purchase, _ time.Time) (domain.Purchase, error) {
+		return purchase, nil
+	}).Times(1)
+	purchase, err = merchService.BuyMerch(context.Background(), domain.PurchaseRequest{Username: "user1", Item: "hoody", Quantity: 1, Promo: " hello "})
+	require.NoError(t, err)
+	require.Equal(t, "HELLO", purchase.PromoCode)
+
+	// Неизвестный код отклоняется даже при действующей распродаже
+	mockRepo.EXPECT().GetPromotions(gomock.Any(), "hoody", "NOPE", gomock.Any()).Return([]domain.Promotion{sale}, nil).Times(1)
+	_, err = merchService.BuyMerch(context.Background(), domain.PurchaseRequest{Username: "user1", Item: "hoody", Quantity: 1, Promo: "nope"})
+	require.ErrorIs(t, err, appErrors.ErrInvalidPromoCode)
+
+	// Исчерпанный код отличается от неизвестного
+	exhausted := code
+	exhausted.MaxUses, exhausted.Uses = 3, 3
+	mockRepo.EXPECT().GetPromotions(gomock.Any(), "hoody", "HELLO", gomock.Any()).Return([]domain.Promotion{sale, exhausted}, nil).Times(1)
+	_, err = merchService.BuyMerch(context.Background(), domain.PurchaseRequest{Username: "user1", Item: "hoody", Quantity: 1, Promo: "HELLO"})
+	require.ErrorIs(t, err, appErrors.ErrPromoCodeExhausted)
+
+	// Лимит использований проверяется в транзакции покупки
+	mockRepo.EXPECT().GetPromotions(gomock.Any(), "hoody", "HELLO", gomock.Any()).Return([]domain.Promotion{code}, nil).Times(1)
+	mockRepo.EXPECT().SavePurchase(gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.Purchase{}, appErrors.ErrPromoUserLimit).Times(1)
+	_, err = merchService.BuyMerch(context.Background(), domain.PurchaseRequest{Username: "user1", Item: "hoody", Quantity: 1, Promo: "HELLO"})
+	require.ErrorIs(t, err, appErrors.ErrPromoUserLimit)
+}
+
+func TestPromotionUnitDiscount(t *testing.T) {
+	percent := domain.Promotion{DiscountType: domain.DiscountPercent, DiscountValue: 15}
+	require.Equal(t, 1, percent.UnitDiscount(10))
+	require.Equal(t, 45, percent.UnitDiscount(300))
+
+	fixed := domain.Promotion{DiscountType: domain.DiscountFixed, DiscountValue: 50}
+	require.Equal(t, 50, fixed.UnitDiscount(300))
+	require.Equal(t, 20, fixed.UnitDiscount(20))
+}
+
+func TestCreatePromotion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPromotionRepository(ctrl)
+	promotionService := NewPromotion(mockRepo)
+
+	now := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	promotionService.now = func() time.Time { return now }
+
+	testCases := []struct {
+		name      string
+		promotion domain.Promotion
+	}{
+		{"no name", domain.Promotion{Item: "cup", DiscountType: domain.DiscountFixed, DiscountValue: 5}},
+		{"item and category", domain.Promotion{Name: "x", Item: "cup", Category: "accessories", DiscountType: domain.DiscountFixed, DiscountValue: 5}},
+		{"unknown type", domain.Promotion{Name: "x", Item: "cup", DiscountType: "bogo", DiscountValue: 5}},
+		{"percent over 100", domain.Promotion{Name: "x", Item: "cup", DiscountType: domain.DiscountPercent, DiscountValue: 101}},
+		{"limits without code", domain.Promotion{Name: "x", Item: "cup", DiscountType: domain.DiscountFixed, DiscountValue: 5, MaxUses: 10}},
+		{"ends before start", domain.Promotion{Name: "x", Item: "cup", DiscountType: domain.DiscountFixed, DiscountValue: 5, EndsAt: &now}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := promotionService.CreatePromotion(context.Background(), testCase.promotion)
+			require.ErrorIs(t, err, appErrors.ErrInvalidPromotion)
+		})
+	}
+
+	mockRepo.EXPECT().CreatePromotion(gomock.Any(), domain.Promotion{
+		Name: "welcome", Item: "hoody", DiscountType: domain.DiscountFixed, DiscountValue: 50,
+		Code: "HELLO", PerUserLimit: 1, StartsAt: now,
+	}).Return(domain.Promotion{ID: 2}, nil).Times(1)
+	_, err := promotionService.CreatePromotion(context.Background(), domain.Promotion{
+		Name: "welcome", Item: "hoody", DiscountType: domain.DiscountFixed, DiscountValue: 50, Code: "hello", PerUserLimit: 1,
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/metrics"
+)
+
+type Purchase struct {
+	repo         domain.PurchaseRepository
+	refundWindow time.Duration
+	now          func() time.Time
+}
+
+// NewPurchase creates the purchase service. Users may refund their own
+// purchases within refundWindow; a zero window leaves refunds to admins.
+func NewPurchase(repo domain.PurchaseRepository, refundWindow time.Duration) *Purchase {
+	return &Purchase{repo: repo, refundWindow: refundWindow, now: time.Now}
+}
+
+func (s *Purchase) ListPurchases(ctx context.Context, username string, limit, offset int) ([]domain.Purchase, error) {
+	ctx, span := tracer.Start(ctx, "service.ListPurchases")
+	defer span.End()
+
+	purchases, err := s.repo.ListPurchases(ctx, username, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListPurchases: %w", err)
+	}
+
+	return purchases, nil
+}
+
+func (s *Purchase) Refund(ctx context.Context, username string, purchaseID, quantity int) (domain.Refund, error) {
+	ctx, span := tracer.Start(ctx, "service.Refund")
+	defer span.End()
+
+	if s.refundWindow <= 0 {
+		return domain.Refund{}, appErrors.ErrRefundsDisabled
+	}
+
+	return s.refund(ctx, "user", domain.RefundRequest{
+		PurchaseID:     purchaseID,
+		Username:       username,
+		Quantity:       quantity,
+		Reason:         "cancelled by user",
+		PurchasedAfter: s.now().Add(-s.refundWindow),
+	})
+}
+
+func (s *Purchase) AdminRefund(ctx context.Context, purchaseID, quantity int, reason string) (domain.Refund, error) {
+	ctx, span := tracer.Start(ctx, "service.AdminRefund")
+	defer span.End()
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return domain.Refund{}, appErrors.ErrReasonRequired
+	}
+
+	return s.refund(ctx, "admin", domain.RefundRequest{
+		PurchaseID: purchaseID,
+		Quantity:   quantity,
+		Reason:     reason,
+	})
+}
+
+func (s *Purchase) refund(ctx context.Context, initiator string, req domain.RefundRequest) (domain.Refund, error) {
+	if req.PurchaseID <= 0 {
+		return domain.Refund{}, appErrors.ErrPurchaseNotFound
+	}
+	if req.Quantity < 0 {
+		return domain.Refund{}, appErrors.ErrInvalidQuantity
+	}
+
+	refund, err := s.repo.RefundPurchase(ctx, req)
+	if err != nil {
+		return domain.Refund{}, fmt.Errorf("service.Refund: %w", err)
+	}
+
+	metrics.Refunds.WithLabelValues(refund.Item, initiator).Add(float64(refund.Quantity))
+
+	return refund, nil
+}
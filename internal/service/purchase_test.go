@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+)
+
+func TestPurchaseRefund(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPurchaseRepository(ctrl)
+	purchaseService := NewPurchase(mockRepo, 24*time.Hour)
+
+	now := time.Date(2025, time.May, 5, 12, 0, 0, 0, time.UTC)
+	purchaseService.now = func() time.Time { return now }
+
+	testCases := []struct {
+		name        string
+		quantity    int
+		mockRepo    func()
+		expectedErr error
+	}{
+		{
+			name:     "partial refund within the window",
+			quantity: 2,
+			mockRepo: func() {
+				mockRepo.EXPECT().RefundPurchase(gomock.Any(), domain.RefundRequest{
+					PurchaseID:     7,
+					Username:       "user1",
+					Quantity:       2,
+					Reason:         "cancelled by user",
+					PurchasedAfter: now.Add(-24 * time.Hour),
+				}).Return(domain.Refund{PurchaseID: 7, Item: "cup", Quantity: 2, Amount: 40}, nil).Times(1)
+			},
+		},
+		{
+			name:     "window expired",
+			quantity: 0,
+			mockRepo: func() {
+				mockRepo.EXPECT().RefundPurchase(gomock.Any(), gomock.Any()).Return(domain.Refund{}, appErrors.ErrRefundWindowExpired).Times(1)
+			},
+			expectedErr: appErrors.ErrRefundWindowExpired,
+		},
+		{
+			name:        "negative quantity",
+			quantity:    -1,
+			mockRepo:    func() {},
+			expectedErr: appErrors.ErrInvalidQuantity,
+		},
+		{
+			name:     "db error",
+			quantity: 1,
+			mockRepo: func() {
+				mockRepo.EXPECT().RefundPurchase(gomock.Any(), gomock.Any()).Return(domain.Refund{}, errors.New("db error")).Times(1)
+			},
+			expectedErr: errors.New("service.Refund: db error"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockRepo()
+
+			_, err := purchaseService.Refund(context.Background(), "user1", 7, testCase.quantity)
+
+			if testCase.expectedErr != nil {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), testCase.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPurchaseAdminRefund(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPurchaseRepository(ctrl)
+
+	// Администратор может вернуть покупку даже при отключённом окне возврата
+	purchaseService := NewPurchase(mockRepo, 0)
+
+	_, err := purchaseService.Refund(context.Background(), "user1", 7, 1)
+	require.ErrorIs(t, err, appErrors.ErrRefundsDisabled)
+
+	_, err = purchaseService.AdminRefund(context.Background(), 7, 1, " ")
+	require.ErrorIs(t, err, appErrors.ErrReasonRequired)
+
+	mockRepo.EXPECT().RefundPurchase(gomock.Any(), domain.RefundRequest{PurchaseID: 7, Reason: "damaged"}).
+		Return(domain.Refund{PurchaseID: 7, Item: "cup", Quantity: 3, Amount: 60}, nil).Times(1)
+	refund, err := purchaseService.AdminRefund(context.Background(), 7, 0, "damaged")
+	require.NoError(t, err)
+	require.Equal(t, 60, refund.Amount)
+}
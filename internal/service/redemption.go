@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/metrics"
+)
+
+type Redemption struct {
+	repo domain.RedemptionRepository
+}
+
+func NewRedemption(repo domain.RedemptionRepository) *Redemption {
+	return &Redemption{repo: repo}
+}
+
+func (s *Redemption) Redeem(ctx context.Context, redemption domain.Redemption) (domain.Redemption, error) {
+	ctx, span := tracer.Start(ctx, "service.Redeem")
+	defer span.End()
+
+	quantity, err := normalizeItemQuantity(redemption.Quantity)
+	if err != nil {
+		return domain.Redemption{}, err
+	}
+	redemption.Quantity = quantity
+
+	redemption.Item = strings.TrimSpace(redemption.Item)
+	redemption.Variant = strings.TrimSpace(redemption.Variant)
+	if redemption.Item == "" {
+		return domain.Redemption{}, appErrors.ErrItemNotFound
+	}
+
+	if redemption.Office, err = normalizeMessage(redemption.Office); err != nil {
+		return domain.Redemption{}, err
+	}
+	if redemption.Note, err = normalizeMessage(redemption.Note); err != nil {
+		return domain.Redemption{}, err
+	}
+	if redemption.Office == "" && redemption.Note == "" {
+		return domain.Redemption{}, appErrors.ErrDeliveryRequired
+	}
+
+	created, err := s.repo.CreateRedemption(ctx, redemption)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrItemNotInInventory) {
+			return domain.Redemption{}, err
+		}
+		return domain.Redemption{}, fmt.Errorf("service.Redeem: %w", err)
+	}
+
+	metrics.Redemptions.WithLabelValues(created.Status).Inc()
+
+	return created, nil
+}
+
+// GetRedemption returns the redemption only to the user who requested it.
+func (s *Redemption) GetRedemption(ctx context.Context, username string, id int) (domain.Redemption, error) {
+	ctx, span := tracer.Start(ctx, "service.GetRedemption")
+	defer span.End()
+
+	if id <= 0 {
+		return domain.Redemption{}, appErrors.ErrRedemptionNotFound
+	}
+
+	redemption, err := s.repo.GetRedemption(ctx, id)
+	if err != nil {
+		return domain.Redemption{}, fmt.Errorf("service.GetRedemption: %w", err)
+	}
+	if redemption.Username != username {
+		return domain.Redemption{}, appErrors.ErrRedemptionNotFound
+	}
+
+	return redemption, nil
+}
+
+func (s *Redemption) ListRedemptions(ctx context.Context, filter domain.RedemptionFilter) ([]domain.Redemption, error) {
+	ctx, span := tracer.Start(ctx, "service.ListRedemptions")
+	defer span.End()
+
+	if filter.Status != "" && !domain.IsRedemptionStatus(filter.Status) {
+		return nil, appErrors.ErrInvalidStatus
+	}
+
+	redemptions, err := s.repo.ListRedemptions(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListRedemptions: %w", err)
+	}
+
+	return redemptions, nil
+}
+
+func (s *Redemption) UpdateStatus(ctx context.Context, id int, status string) (domain.Redemption, error) {
+	ctx, span := tracer.Start(ctx, "service.UpdateStatus")
+	defer span.End()
+
+	if id <= 0 {
+		return domain.Redemption{}, appErrors.ErrRedemptionNotFound
+	}
+	if !domain.IsRedemptionStatus(status) {
+		return domain.Redemption{}, appErrors.ErrInvalidStatus
+	}
+
+	redemption, err := s.repo.UpdateRedemptionStatus(ctx, id, status)
+	if err != nil {
+		return domain.Redemption{}, fmt.Errorf("service.UpdateStatus: %w", err)
+	}
+
+	metrics.Redemptions.WithLabelValues(status).Inc()
+
+	return redemption, nil
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+)
+
+func TestRedemptionRedeem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRedemptionRepository(ctrl)
+	redemptionService := NewRedemption(mockRepo)
+
+	testCases := []struct {
+		name        string
+		redemption  domain.Redemption
+		mockRepo    func()
+		expectedErr error
+	}{
+		{
+			name:       "successful redemption",
+			redemption: domain.Redemption{Username: "user1", Item: "hoody", Office: " Moscow "},
+			mockRepo: func() {
+				mockRepo.EXPECT().CreateRedemption(gomock.Any(), domain.Redemption{
+					Username: "user1", Item: "hoody", Quantity: 1, Office: "Moscow",
+				}).Return(domain.Redemption{ID: 1, Status: domain.RedemptionStatusPending}, nil).Times(1)
+			},
+		},
+		{
+			name:        "no office or note",
+			redemption:  domain.Redemption{Username: "user1", Item: "hoody", Note: "  "},
+			mockRepo:    func() {},
+			expectedErr: appErrors.ErrDeliveryRequired,
+		},
+		{
+			name:        "invalid quantity",
+			redemption:  domain.Redemption{Username: "user1", Item: "hoody", Quantity: -2, Note: "desk 4"},
+			mockRepo:    func() {},
+			expectedErr: appErrors.ErrInvalidQuantity,
+		},
+		{
+			name:       "item not in inventory",
+			redemption: domain.Redemption{Username: "user1", Item: "hoody", Note: "desk 4"},
+			mockRepo: func() {
+				mockRepo.EXPECT().CreateRedemption(gomock.Any(), gomock.Any()).Return(domain.Redemption{}, appErrors.ErrItemNotInInventory).Times(1)
+			},
+			expectedErr: appErrors.ErrItemNotInInventory,
+		},
+		{
+			name:       "db error",
+			redemption: domain.Redemption{Username: "user1", Item: "hoody", Note: "desk 4"},
+			mockRepo: func() {
+				mockRepo.EXPECT().CreateRedemption(gomock.Any(), gomock.Any()).Return(domain.Redemption{}, errors.New("db error")).Times(1)
+			},
+			expectedErr: errors.New("service.Redeem: db error"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockRepo()
+
+			_, err := redemptionService.Redeem(context.Background(), testCase.redemption)
+
+			if testCase.expectedErr != nil {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), testCase.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRedemptionStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRedemptionRepository(ctrl)
+	redemptionService := NewRedemption(mockRepo)
+
+	// Чужую заявку пользователь не видит
+	mockRepo.EXPECT().GetRedemption(gomock.Any(), 5).Return(domain.Redemption{ID: 5, Username: "user2"}, nil).Times(1)
+	_, err := redemptionService.GetRedemption(context.Background(), "user1", 5)
+	require.ErrorIs(t, err, appErrors.ErrRedemptionNotFound)
+
+	_, err = redemptionService.UpdateStatus(context.Background(), 5, "lost")
+	require.ErrorIs(t, err, appErrors.ErrInvalidStatus)
+
+	mockRepo.EXPECT().UpdateRedemptionStatus(gomock.Any(), 5, domain.RedemptionStatusDelivered).
+		Return(domain.Redemption{}, appErrors.ErrStatusTransition).Times(1)
+	_, err = redemptionService.UpdateStatus(context.Background(), 5, domain.RedemptionStatusDelivered)
+	require.ErrorIs(t, err, appErrors.ErrStatusTransition)
+
+	_, err = redemptionService.ListRedemptions(context.Background(), domain.RedemptionFilter{Status: "lost"})
+	require.ErrorIs(t, err, appErrors.ErrInvalidStatus)
+
+	require.True(t, domain.CanTransitionRedemption(domain.RedemptionStatusPending, domain.RedemptionStatusApproved))
+	require.True(t, domain.CanTransitionRedemption(domain.RedemptionStatusShipped, domain.RedemptionStatusDelivered))
+	require.False(t, domain.CanTransitionRedemption(domain.RedemptionStatusPending, domain.RedemptionStatusShipped))
+	require.False(t, domain.CanTransitionRedemption(domain.RedemptionStatusDelivered, domain.RedemptionStatusRejected))
+}
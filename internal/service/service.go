@@ -2,23 +2,47 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel"
 
 	"github.com/Te8va/MerchStore/internal/domain"
 	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/metrics"
 )
 
+var tracer = otel.Tracer("github.com/Te8va/MerchStore/internal/service")
+
 type Merch struct {
-	repo domain.MerchRepository
+	repo   domain.MerchRepository
+	policy domain.TransferPolicy
+	now    func() time.Time
 }
 
-func NewMerch(repo domain.MerchRepository) *Merch {
-	return &Merch{repo: repo}
+func NewMerch(repo domain.MerchRepository, policy domain.TransferPolicy) *Merch {
+	return &Merch{repo: repo, policy: policy, now: time.Now}
 }
 
-func (s *Merch) SendCoin(ctx context.Context, fromUser, toUser string, amount int) error {
-	userExists, err := s.repo.UserExists(ctx, toUser)
+func (s *Merch) SendCoin(ctx context.Context, transfer domain.Transfer) error {
+	ctx, span := tracer.Start(ctx, "service.SendCoin")
+	defer span.End()
+
+	if transfer.ToUser == domain.SystemAccount {
+		return appErrors.ErrSystemAccount
+	}
+
+	transfer, err := normalizeTransferNote(transfer)
+	if err != nil {
+		return err
+	}
+
+	userExists, err := s.repo.UserExists(ctx, transfer.ToUser)
 	if err != nil {
 		return fmt.Errorf("service.SendCoin: %w", err)
 	}
@@ -26,23 +50,45 @@ func (s *Merch) SendCoin(ctx context.Context, fromUser, toUser string, amount in
 		return appErrors.ErrUserNotFound
 	}
 
-	balance, err := s.repo.GetUserBalance(ctx, fromUser)
+	if err := s.checkTransferPolicy(ctx, transfer); err != nil {
+		return err
+	}
+
+	balance, err := s.repo.GetUserBalance(ctx, transfer.FromUser)
 	if err != nil {
 		return fmt.Errorf("service.SendCoin: %w", err)
 	}
-	if balance < amount {
+	if balance < transfer.Amount {
+		metrics.InsufficientBalance.WithLabelValues("send_coin").Inc()
 		return appErrors.ErrInsufficientBalance
 	}
 
-	err = s.repo.TransferCoins(ctx, fromUser, toUser, amount)
+	err = s.repo.TransferCoins(ctx, transfer, s.policy, dayStart(s.now()))
 	if err != nil {
-		return fmt.Errorf("could not complete transaction: %w", err)
+		var limitErr *appErrors.LimitError
+		switch {
+		case errors.As(err, &limitErr):
+			countTransferRejection(err)
+			return err
+		case errors.Is(err, appErrors.ErrInsufficientBalance):
+			metrics.InsufficientBalance.WithLabelValues("send_coin").Inc()
+			return appErrors.ErrInsufficientBalance
+		case errors.Is(err, appErrors.ErrUserNotFound):
+			return appErrors.ErrUserNotFound
+		}
+		return fmt.Errorf("service.SendCoin: %w", err)
 	}
 
+	metrics.Transfers.Inc()
+	metrics.CoinsTransferred.Add(float64(transfer.Amount))
+
 	return nil
 }
 
 func (s *Merch) GetUserInfo(ctx context.Context, username string) (domain.UserInfo, error) {
+	ctx, span := tracer.Start(ctx, "service.GetUserInfo")
+	defer span.End()
+
 	var info domain.UserInfo
 
 	userExists, err := s.repo.UserExists(ctx, username)
@@ -74,40 +120,244 @@ func (s *Merch) GetUserInfo(ctx context.Context, username string) (domain.UserIn
 	return info, nil
 }
 
-func (s *Merch) BuyMerch(ctx context.Context, username, item string) error {
-	userExists, err := s.repo.UserExists(ctx, username)
+func (s *Merch) BuyMerch(ctx context.Context, req domain.PurchaseRequest) (domain.Purchase, error) {
+	ctx, span := tracer.Start(ctx, "service.BuyMerch")
+	defer span.End()
+
+	if req.Quantity <= 0 || req.Quantity > domain.MaxPurchaseQuantity {
+		return domain.Purchase{}, appErrors.ErrInvalidQuantity
+	}
+
+	userExists, err := s.repo.UserExists(ctx, req.Username)
 	if err != nil {
-		return fmt.Errorf("service.BuyMerch: %w", err)
+		return domain.Purchase{}, fmt.Errorf("service.BuyMerch: %w", err)
+	}
+	if !userExists {
+		return domain.Purchase{}, appErrors.ErrUserNotFound
+	}
+
+	price, err := s.repo.GetMerchPrice(ctx, req.Item)
+	if err != nil {
+		if strings.Contains(err.Error(), "item not found") {
+			return domain.Purchase{}, appErrors.ErrItemNotFound
+		}
+		return domain.Purchase{}, fmt.Errorf("service.BuyMerch: %w", err)
+	}
+
+	if err := s.checkItemRules(ctx, req); err != nil {
+		return domain.Purchase{}, err
+	}
+
+	code := normalizePromoCode(req.Promo)
+	promotions, err := s.repo.GetPromotions(ctx, req.Item, code, s.now())
+	if err != nil {
+		return domain.Purchase{}, fmt.Errorf("service.BuyMerch: %w", err)
+	}
+
+	purchase := domain.Purchase{
+		Username:  req.Username,
+		Item:      req.Item,
+		Variant:   strings.TrimSpace(req.Variant),
+		UnitPrice: price,
+		PaidPrice: price,
+		Quantity:  req.Quantity,
+	}
+
+	promotion, ok, err := bestPromotion(promotions, code, price)
+	if err != nil {
+		return domain.Purchase{}, err
+	}
+	if ok {
+		purchase.Discount = promotion.UnitDiscount(price)
+		purchase.PaidPrice = price - purchase.Discount
+		purchase.PromotionID = promotion.ID
+		purchase.PromoCode = promotion.Code
+	}
+
+	balance, err := s.repo.GetUserBalance(ctx, req.Username)
+	if err != nil {
+		return domain.Purchase{}, fmt.Errorf("service.BuyMerch: %w", err)
+	}
+
+	if balance < purchase.PaidPrice*req.Quantity {
+		metrics.InsufficientBalance.WithLabelValues("buy_merch").Inc()
+		return domain.Purchase{}, appErrors.ErrInsufficientBalance
+	}
+
+	purchase, err = s.repo.SavePurchase(ctx, purchase, s.now())
+	if err != nil {
+		var ruleErr *appErrors.RuleError
+		switch {
+		case errors.As(err, &ruleErr):
+			return domain.Purchase{}, rejectPurchase(ruleErr)
+		case errors.Is(err, appErrors.ErrInsufficientBalance):
+			metrics.InsufficientBalance.WithLabelValues("buy_merch").Inc()
+			return domain.Purchase{}, appErrors.ErrInsufficientBalance
+		case errors.Is(err, appErrors.ErrInvalidPromoCode),
+			errors.Is(err, appErrors.ErrPromoCodeExhausted),
+			errors.Is(err, appErrors.ErrPromoUserLimit),
+			errors.Is(err, appErrors.ErrVariantNotFound),
+			errors.Is(err, appErrors.ErrOutOfStock):
+			return domain.Purchase{}, err
+		}
+		return domain.Purchase{}, fmt.Errorf("service.BuyMerch: %w", err)
+	}
+
+	metrics.Purchases.WithLabelValues(req.Item).Add(float64(req.Quantity))
+	if purchase.PromotionID != 0 {
+		metrics.Discounts.Add(float64(purchase.Discount * req.Quantity))
+	}
+
+	return purchase, nil
+}
+
+func (s *Merch) GiftMerch(ctx context.Context, gift domain.Gift) error {
+	ctx, span := tracer.Start(ctx, "service.GiftMerch")
+	defer span.End()
+
+	if gift.Quantity == 0 {
+		gift.Quantity = 1
+	}
+	if gift.Quantity < 0 || gift.Quantity > domain.MaxPurchaseQuantity {
+		return appErrors.ErrInvalidQuantity
+	}
+	if gift.ToUser == domain.SystemAccount {
+		return appErrors.ErrSystemAccount
+	}
+	if gift.ToUser == gift.FromUser {
+		return appErrors.ErrSelfGift
+	}
+
+	message, err := normalizeMessage(gift.Message)
+	if err != nil {
+		return err
+	}
+	gift.Message = message
+	gift.Variant = strings.TrimSpace(gift.Variant)
+
+	userExists, err := s.repo.UserExists(ctx, gift.ToUser)
+	if err != nil {
+		return fmt.Errorf("service.GiftMerch: %w", err)
 	}
 	if !userExists {
 		return appErrors.ErrUserNotFound
 	}
 
-	price, err := s.repo.GetMerchPrice(ctx, item)
+	price, err := s.repo.GetMerchPrice(ctx, gift.Item)
 	if err != nil {
 		if strings.Contains(err.Error(), "item not found") {
 			return appErrors.ErrItemNotFound
 		}
-		return fmt.Errorf("service.BuyMerch: %w", err)
+		return fmt.Errorf("service.GiftMerch: %w", err)
 	}
 
-	balance, err := s.repo.GetUserBalance(ctx, username)
+	if err := s.repo.SaveGift(ctx, gift, price, s.now()); err != nil {
+		var ruleErr *appErrors.RuleError
+		switch {
+		case errors.As(err, &ruleErr):
+			return rejectPurchase(ruleErr)
+		case errors.Is(err, appErrors.ErrInsufficientBalance):
+			metrics.InsufficientBalance.WithLabelValues("gift_merch").Inc()
+			return appErrors.ErrInsufficientBalance
+		case errors.Is(err, appErrors.ErrUserNotFound):
+			return appErrors.ErrUserNotFound
+		case errors.Is(err, appErrors.ErrVariantNotFound),
+			errors.Is(err, appErrors.ErrOutOfStock):
+			return err
+		}
+		return fmt.Errorf("service.GiftMerch: %w", err)
+	}
+
+	metrics.Gifts.WithLabelValues(gift.Item).Add(float64(gift.Quantity))
+
+	return nil
+}
+
+func (s *Merch) GetUserHistory(ctx context.Context, username string, filter domain.HistoryFilter) ([]domain.HistoryEntry, error) {
+	ctx, span := tracer.Start(ctx, "service.GetUserHistory")
+	defer span.End()
+
+	if filter.Category != "" && !slices.Contains(domain.TransferCategories, filter.Category) {
+		return nil, appErrors.ErrUnknownCategory
+	}
+
+	history, err := s.repo.GetUserHistory(ctx, username, filter)
 	if err != nil {
-		return fmt.Errorf("service.BuyMerch: %w", err)
+		return nil, fmt.Errorf("service.GetUserHistory: %w", err)
 	}
 
-	if balance < price {
-		return appErrors.ErrInsufficientBalance
+	return history, nil
+}
+
+// normalizeTransferNote trims the optional message and category and rejects
+// unknown categories.
+func normalizeTransferNote(transfer domain.Transfer) (domain.Transfer, error) {
+	message, err := normalizeMessage(transfer.Message)
+	if err != nil {
+		return transfer, err
 	}
+	transfer.Message = message
+	transfer.Category = strings.ToLower(strings.TrimSpace(transfer.Category))
 
-	newBalance := balance - price
-	if err := s.repo.UpdateUserBalance(ctx, username, newBalance); err != nil {
-		return fmt.Errorf("service.BuyMerch: %w", err)
+	if transfer.Category != "" && !slices.Contains(domain.TransferCategories, transfer.Category) {
+		return transfer, appErrors.ErrUnknownCategory
 	}
 
-	if err := s.repo.SavePurchase(ctx, username, item, price); err != nil {
-		return fmt.Errorf("service.BuyMerch: %w", err)
+	return transfer, nil
+}
+
+// bestPromotion picks the promotion with the largest discount; promotions
+// never stack. A code that matches none of the running promotions is
+// rejected even when an automatic sale would apply, and so is a code that
+// has used up its MaxUses.
+func bestPromotion(promotions []domain.Promotion, code string, price int) (domain.Promotion, bool, error) {
+	var (
+		best      domain.Promotion
+		found     bool
+		codeFound bool
+	)
+	for _, promotion := range promotions {
+		if promotion.Code != "" {
+			if promotion.Code != code {
+				continue
+			}
+			if promotion.Exhausted() {
+				return domain.Promotion{}, false, appErrors.ErrPromoCodeExhausted
+			}
+			codeFound = true
+		}
+		if !found || promotion.UnitDiscount(price) > best.UnitDiscount(price) {
+			best, found = promotion, true
+		}
 	}
 
-	return nil
+	if code != "" && !codeFound {
+		return domain.Promotion{}, false, appErrors.ErrInvalidPromoCode
+	}
+
+	return best, found, nil
+}
+
+func normalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// normalizeMessage trims a user supplied note and rejects notes that are
+// too long or contain control or invisible formatting characters.
+func normalizeMessage(message string) (string, error) {
+	message = strings.TrimSpace(message)
+
+	if !utf8.ValidString(message) {
+		return message, appErrors.ErrInvalidMessage
+	}
+	if utf8.RuneCountInString(message) > domain.MaxTransferMessageLength {
+		return message, appErrors.ErrMessageTooLong
+	}
+	for _, r := range message {
+		if unicode.IsControl(r) || unicode.Is(unicode.Cf, r) {
+			return message, appErrors.ErrInvalidMessage
+		}
+	}
+
+	return message, nil
 }
@@ -3,14 +3,17 @@ package service
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 
 	"github.com/Te8va/MerchStore/internal/domain"
 	"github.com/Te8va/MerchStore/internal/domain/mocks"
 	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/metrics"
 )
 
 func TestSendCoin(t *testing.T) {
@@ -18,13 +21,15 @@ func TestSendCoin(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockMerchRepository(ctrl)
-	merchService := NewMerch(mockRepo)
+	merchService := NewMerch(mockRepo, domain.TransferPolicy{})
 
 	testCases := []struct {
 		name        string
 		fromUser    string
 		toUser      string
 		amount      int
+		message     string
+		category    string
 		mockRepo    func()
 		expectedErr error
 	}{
@@ -34,9 +39,9 @@ func TestSendCoin(t *testing.T) {
 			toUser:   "user2",
 			amount:   100,
 			mockRepo: func() {
-				mockRepo.EXPECT().UserExists(context.Background(), "user2").Return(true, nil).Times(1)
-				mockRepo.EXPECT().GetUserBalance(context.Background(), "user1").Return(200, nil).Times(1)
-				mockRepo.EXPECT().TransferCoins(context.Background(), "user1", "user2", 100).Return(nil).Times(1)
+				mockRepo.EXPECT().UserExists(gomock.Any(), "user2").Return(true, nil).Times(1)
+				mockRepo.EXPECT().GetUserBalance(gomock.Any(), "user1").Return(200, nil).Times(1)
+				mockRepo.EXPECT().TransferCoins(gomock.Any(), domain.Transfer{FromUser: "user1", ToUser: "user2", Amount: 100}, gomock.Any(), gomock.Any()).Return(nil).Times(1)
 			},
 			expectedErr: nil,
 		},
@@ -46,18 +51,72 @@ func TestSendCoin(t *testing.T) {
 			toUser:   "nonexistentUser",
 			amount:   100,
 			mockRepo: func() {
-				mockRepo.EXPECT().UserExists(context.Background(), "nonexistentUser").Return(false, nil).Times(1)
+				mockRepo.EXPECT().UserExists(gomock.Any(), "nonexistentUser").Return(false, nil).Times(1)
 			},
 			expectedErr: appErrors.ErrUserNotFound,
 		},
+		{
+			name:     "message and category are normalized",
+			fromUser: "user1",
+			toUser:   "user2",
+			amount:   10,
+			message:  "  thanks for the code review ",
+			category: " Thanks",
+			mockRepo: func() {
+				mockRepo.EXPECT().UserExists(gomock.Any(), "user2").Return(true, nil).Times(1)
+				mockRepo.EXPECT().GetUserBalance(gomock.Any(), "user1").Return(200, nil).Times(1)
+				mockRepo.EXPECT().TransferCoins(gomock.Any(), domain.Transfer{
+					FromUser: "user1",
+					ToUser:   "user2",
+					Amount:   10,
+					Message:  "thanks for the code review",
+					Category: domain.CategoryThanks,
+				}, gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+		},
+		{
+			name:        "message too long",
+			fromUser:    "user1",
+			toUser:      "user2",
+			amount:      10,
+			message:     strings.Repeat("ы", domain.MaxTransferMessageLength+1),
+			mockRepo:    func() {},
+			expectedErr: appErrors.ErrMessageTooLong,
+		},
+		{
+			name:        "message with control characters",
+			fromUser:    "user1",
+			toUser:      "user2",
+			amount:      10,
+			message:     "hello\u202eworld",
+			mockRepo:    func() {},
+			expectedErr: appErrors.ErrInvalidMessage,
+		},
+		{
+			name:        "unknown category",
+			fromUser:    "user1",
+			toUser:      "user2",
+			amount:      10,
+			category:    "bribes",
+			mockRepo:    func() {},
+			expectedErr: appErrors.ErrUnknownCategory,
+		},
+		{
+			name:        "system account receiver",
+			fromUser:    "user1",
+			toUser:      domain.SystemAccount,
+			amount:      100,
+			mockRepo:    func() {},
+			expectedErr: appErrors.ErrSystemAccount,
+		},
 		{
 			name:     "insufficient balance",
 			fromUser: "user1",
 			toUser:   "user2",
 			amount:   1000,
 			mockRepo: func() {
-				mockRepo.EXPECT().UserExists(context.Background(), "user2").Return(true, nil).Times(1)
-				mockRepo.EXPECT().GetUserBalance(context.Background(), "user1").Return(100, nil).Times(1)
+				mockRepo.EXPECT().UserExists(gomock.Any(), "user2").Return(true, nil).Times(1)
+				mockRepo.EXPECT().GetUserBalance(gomock.Any(), "user1").Return(100, nil).Times(1)
 			},
 			expectedErr: appErrors.ErrInsufficientBalance,
 		},
@@ -67,7 +126,7 @@ func TestSendCoin(t *testing.T) {
 			toUser:   "user2",
 			amount:   100,
 			mockRepo: func() {
-				mockRepo.EXPECT().UserExists(context.Background(), "user2").Return(false, errors.New("db error")).Times(1)
+				mockRepo.EXPECT().UserExists(gomock.Any(), "user2").Return(false, errors.New("db error")).Times(1)
 			},
 			expectedErr: errors.New("service.SendCoin: db error"),
 		},
@@ -77,7 +136,13 @@ func TestSendCoin(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			testCase.mockRepo()
 
-			err := merchService.SendCoin(context.Background(), testCase.fromUser, testCase.toUser, testCase.amount)
+			err := merchService.SendCoin(context.Background(), domain.Transfer{
+				FromUser: testCase.fromUser,
+				ToUser:   testCase.toUser,
+				Amount:   testCase.amount,
+				Message:  testCase.message,
+				Category: testCase.category,
+			})
 
 			if testCase.expectedErr != nil {
 				require.Error(t, err)
@@ -93,7 +158,7 @@ func TestGetUserInfo(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockMerchRepository(ctrl)
-	merchService := NewMerch(mockRepo)
+	merchService := NewMerch(mockRepo, domain.TransferPolicy{})
 
 	testCases := []struct {
 		name         string
@@ -106,13 +171,13 @@ func TestGetUserInfo(t *testing.T) {
 			name:     "success",
 			username: "user1",
 			mockRepo: func() {
-				mockRepo.EXPECT().UserExists(context.Background(), "user1").Return(true, nil).Times(1)
-				mockRepo.EXPECT().GetUserBalance(context.Background(), "user1").Return(500, nil).Times(1)
-				mockRepo.EXPECT().GetUserPurchases(context.Background(), "user1").Return([]domain.InventoryItem{
+				mockRepo.EXPECT().UserExists(gomock.Any(), "user1").Return(true, nil).Times(1)
+				mockRepo.EXPECT().GetUserBalance(gomock.Any(), "user1").Return(500, nil).Times(1)
+				mockRepo.EXPECT().GetUserPurchases(gomock.Any(), "user1").Return([]domain.InventoryItem{
 					{Name: "item1", Quantity: 2},
 					{Name: "item2", Quantity: 1},
 				}, nil).Times(1)
-				mockRepo.EXPECT().GetUserTransactionHistory(context.Background(), "user1").Return(domain.CoinHistory{
+				mockRepo.EXPECT().GetUserTransactionHistory(gomock.Any(), "user1").Return(domain.CoinHistory{
 					Received: []domain.ReceivedTransaction{{FromUser: "user2", Amount: 100}},
 					Sent:     []domain.SentTransaction{{ToUser: "user3", Amount: 50}},
 				}, nil).Times(1)
@@ -134,7 +199,7 @@ func TestGetUserInfo(t *testing.T) {
 			name:     "user not found",
 			username: "nonexistentUser",
 			mockRepo: func() {
-				mockRepo.EXPECT().UserExists(context.Background(), "nonexistentUser").Return(false, nil).Times(1)
+				mockRepo.EXPECT().UserExists(gomock.Any(), "nonexistentUser").Return(false, nil).Times(1)
 			},
 			expectedErr: appErrors.ErrUserNotFound,
 		},
@@ -142,7 +207,7 @@ func TestGetUserInfo(t *testing.T) {
 			name:     "db error",
 			username: "user1",
 			mockRepo: func() {
-				mockRepo.EXPECT().UserExists(context.Background(), "user1").Return(false, errors.New("db error")).Times(1)
+				mockRepo.EXPECT().UserExists(gomock.Any(), "user1").Return(false, errors.New("db error")).Times(1)
 			},
 			expectedErr: errors.New("service.GetUserInfo: db error"),
 		},
@@ -170,7 +235,7 @@ func TestBuyMerch(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockMerchRepository(ctrl)
-	merchService := NewMerch(mockRepo)
+	merchService := NewMerch(mockRepo, domain.TransferPolicy{})
 
 	testCases := []struct {
 		name        string
@@ -184,11 +249,14 @@ func TestBuyMerch(t *testing.T) {
 			username: "user1",
 			item:     "merch1",
 			mockRepo: func() {
-				mockRepo.EXPECT().UserExists(context.Background(), "user1").Return(true, nil).Times(1)
-				mockRepo.EXPECT().GetMerchPrice(context.Background(), "merch1").Return(100, nil).Times(1)
-				mockRepo.EXPECT().GetUserBalance(context.Background(), "user1").Return(200, nil).Times(1)
-				mockRepo.EXPECT().UpdateUserBalance(context.Background(), "user1", 100).Times(1)
-				mockRepo.EXPECT().SavePurchase(context.Background(), "user1", "merch1", 100).Times(1)
+				mockRepo.EXPECT().UserExists(gomock.Any(), "user1").Return(true, nil).Times(1)
+				mockRepo.EXPECT().GetMerchPrice(gomock.Any(), "merch1").Return(100, nil).Times(1)
+				mockRepo.EXPECT().GetItemRule(gomock.Any(), "merch1").Return(domain.ItemRule{Item: "merch1"}, nil).Times(1)
+				mockRepo.EXPECT().GetPromotions(gomock.Any(), "merch1", "", gomock.Any()).Return(nil, nil).Times(1)
+				mockRepo.EXPECT().GetUserBalance(gomock.Any(), "user1").Return(200, nil).Times(1)
+				mockRepo.EXPECT().SavePurchase(gomock.Any(), domain.Purchase{
+					Username: "user1", Item: "merch1", UnitPrice: 100, PaidPrice: 100, Quantity: 1,
+				}, gomock.Any()).Return(domain.Purchase{ID: 1}, nil).Times(1)
 			},
 			expectedErr: nil,
 		},
@@ -197,7 +265,7 @@ func TestBuyMerch(t *testing.T) {
 			username: "nonexistentUser",
 			item:     "merch1",
 			mockRepo: func() {
-				mockRepo.EXPECT().UserExists(context.Background(), "nonexistentUser").Return(false, nil).Times(1)
+				mockRepo.EXPECT().UserExists(gomock.Any(), "nonexistentUser").Return(false, nil).Times(1)
 			},
 			expectedErr: appErrors.ErrUserNotFound,
 		},
@@ -206,9 +274,11 @@ func TestBuyMerch(t *testing.T) {
 			username: "user1",
 			item:     "merch1",
 			mockRepo: func() {
-				mockRepo.EXPECT().UserExists(context.Background(), "user1").Return(true, nil).Times(1)
-				mockRepo.EXPECT().GetMerchPrice(context.Background(), "merch1").Return(200, nil).Times(1)
-				mockRepo.EXPECT().GetUserBalance(context.Background(), "user1").Return(100, nil).Times(1)
+				mockRepo.EXPECT().UserExists(gomock.Any(), "user1").Return(true, nil).Times(1)
+				mockRepo.EXPECT().GetMerchPrice(gomock.Any(), "merch1").Return(200, nil).Times(1)
+				mockRepo.EXPECT().GetItemRule(gomock.Any(), "merch1").Return(domain.ItemRule{Item: "merch1"}, nil).Times(1)
+				mockRepo.EXPECT().GetPromotions(gomock.Any(), "merch1", "", gomock.Any()).Return(nil, nil).Times(1)
+				mockRepo.EXPECT().GetUserBalance(gomock.Any(), "user1").Return(100, nil).Times(1)
 			},
 			expectedErr: appErrors.ErrInsufficientBalance,
 		},
@@ -217,8 +287,8 @@ func TestBuyMerch(t *testing.T) {
 			username: "user1",
 			item:     "nonexistentItem",
 			mockRepo: func() {
-				mockRepo.EXPECT().UserExists(context.Background(), "user1").Return(true, nil).Times(1)
-				mockRepo.EXPECT().GetMerchPrice(context.Background(), "nonexistentItem").Return(0, errors.New("item not found")).Times(1)
+				mockRepo.EXPECT().UserExists(gomock.Any(), "user1").Return(true, nil).Times(1)
+				mockRepo.EXPECT().GetMerchPrice(gomock.Any(), "nonexistentItem").Return(0, errors.New("item not found")).Times(1)
 			},
 			expectedErr: appErrors.ErrItemNotFound,
 		},
@@ -227,11 +297,12 @@ func TestBuyMerch(t *testing.T) {
 			username: "user1",
 			item:     "merch1",
 			mockRepo: func() {
-				mockRepo.EXPECT().UserExists(context.Background(), "user1").Return(true, nil).Times(1)
-				mockRepo.EXPECT().GetMerchPrice(context.Background(), "merch1").Return(100, nil).Times(1)
-				mockRepo.EXPECT().GetUserBalance(context.Background(), "user1").Return(200, nil).Times(1)
-				mockRepo.EXPECT().UpdateUserBalance(context.Background(), "user1", 100).Times(1)
-				mockRepo.EXPECT().SavePurchase(context.Background(), "user1", "merch1", 100).Return(errors.New("db error")).Times(1)
+				mockRepo.EXPECT().UserExists(gomock.Any(), "user1").Return(true, nil).Times(1)
+				mockRepo.EXPECT().GetMerchPrice(gomock.Any(), "merch1").Return(100, nil).Times(1)
+				mockRepo.EXPECT().GetItemRule(gomock.Any(), "merch1").Return(domain.ItemRule{Item: "merch1"}, nil).Times(1)
+				mockRepo.EXPECT().GetPromotions(gomock.Any(), "merch1", "", gomock.Any()).Return(nil, nil).Times(1)
+				mockRepo.EXPECT().GetUserBalance(gomock.Any(), "user1").Return(200, nil).Times(1)
+				mockRepo.EXPECT().SavePurchase(gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.Purchase{}, errors.New("db error")).Times(1)
 			},
 			expectedErr: errors.New("service.BuyMerch: db error"),
 		},
@@ -241,7 +312,7 @@ func TestBuyMerch(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			testCase.mockRepo()
 
-			err := merchService.BuyMerch(context.Background(), testCase.username, testCase.item)
+			_, err := merchService.BuyMerch(context.Background(), domain.PurchaseRequest{Username: testCase.username, Item: testCase.item, Quantity: 1})
 
 			if testCase.expectedErr != nil {
 				require.Error(t, err)
@@ -252,3 +323,157 @@ func TestBuyMerch(t *testing.T) {
 		})
 	}
 }
+
+func TestSendCoinMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMerchRepository(ctrl)
+	merchService := NewMerch(mockRepo, domain.TransferPolicy{})
+
+	transferred := testutil.ToFloat64(metrics.CoinsTransferred)
+	rejected := testutil.ToFloat64(metrics.InsufficientBalance.WithLabelValues("send_coin"))
+
+	mockRepo.EXPECT().UserExists(gomock.Any(), "user2").Return(true, nil).Times(2)
+	mockRepo.EXPECT().GetUserBalance(gomock.Any(), "user1").Return(100, nil).Times(2)
+	mockRepo.EXPECT().TransferCoins(gomock.Any(), domain.Transfer{FromUser: "user1", ToUser: "user2", Amount: 40}, gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	require.NoError(t, merchService.SendCoin(context.Background(), domain.Transfer{FromUser: "user1", ToUser: "user2", Amount: 40}))
+	require.ErrorIs(t, merchService.SendCoin(context.Background(), domain.Transfer{FromUser: "user1", ToUser: "user2", Amount: 400}), appErrors.ErrInsufficientBalance)
+
+	require.Equal(t, transferred+40, testutil.ToFloat64(metrics.CoinsTransferred))
+	require.Equal(t, rejected+1, testutil.ToFloat64(metrics.InsufficientBalance.WithLabelValues("send_coin")))
+}
+
+func TestGetUserHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMerchRepository(ctrl)
+	merchService := NewMerch(mockRepo, domain.TransferPolicy{})
+
+	filter := domain.HistoryFilter{Category: domain.CategoryThanks, Limit: 10}
+	entries := []domain.HistoryEntry{{ID: 1, Kind: domain.TransactionKindTransfer, FromUser: "user2", ToUser: "user1", Amount: 5}}
+
+	mockRepo.EXPECT().GetUserHistory(gomock.Any(), "user1", filter).Return(entries, nil).Times(1)
+	history, err := merchService.GetUserHistory(context.Background(), "user1", filter)
+	require.NoError(t, err)
+	require.Equal(t, entries, history)
+
+	_, err = merchService.GetUserHistory(context.Background(), "user1", domain.HistoryFilter{Category: "bribes", Limit: 10})
+	require.ErrorIs(t, err, appErrors.ErrUnknownCategory)
+}
+
+func TestBuyMerchQuantity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMerchRepository(ctrl)
+	merchService := NewMerch(mockRepo, domain.TransferPolicy{})
+
+	mockRepo.EXPECT().UserExists(gomock.Any(), "user1").Return(true, nil).Times(2)
+	mockRepo.EXPECT().GetMerchPrice(gomock.Any(), "cup").Return(20, nil).Times(2)
+	mockRepo.EXPECT().GetItemRule(gomock.Any(), "cup").Return(domain.ItemRule{Item: "cup"}, nil).Times(2)
+	mockRepo.EXPECT().GetPromotions(gomock.Any(), "cup", "", gomock.Any()).Return(nil, nil).Times(2)
+	mockRepo.EXPECT().GetUserBalance(gomock.Any(), "user1").Return(100, nil).Times(2)
+	mockRepo.EXPECT().SavePurchase(gomock.Any(), domain.Purchase{Username: "user1", Item: "cup", UnitPrice: 20, PaidPrice: 20, Quantity: 3}, gomock.Any()).
+		Return(domain.Purchase{ID: 1}, nil).Times(1)
+
+	_, err := merchService.BuyMerch(context.Background(), domain.PurchaseRequest{Username: "user1", Item: "cup", Quantity: 3})
+	require.NoError(t, err)
+	_, err = merchService.BuyMerch(context.Background(), domain.PurchaseRequest{Username: "user1", Item: "cup", Quantity: 6})
+	require.ErrorIs(t, err, appErrors.ErrInsufficientBalance)
+
+	_, err = merchService.BuyMerch(context.Background(), domain.PurchaseRequest{Username: "user1", Item: "cup"})
+	require.ErrorIs(t, err, appErrors.ErrInvalidQuantity)
+	_, err = merchService.BuyMerch(context.Background(), domain.PurchaseRequest{Username: "user1", Item: "cup", Quantity: domain.MaxPurchaseQuantity + 1})
+	require.ErrorIs(t, err, appErrors.ErrInvalidQuantity)
+}
+
+func TestGiftMerch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMerchRepository(ctrl)
+	merchService := NewMerch(mockRepo, domain.TransferPolicy{})
+
+	testCases := []struct {
+		name        string
+		gift        domain.Gift
+		mockRepo    func()
+		expectedErr error
+	}{
+		{
+			name: "success",
+			gift: domain.Gift{FromUser: "user1", ToUser: "user2", Item: "cup", Message: " happy birthday "},
+			mockRepo: func() {
+				mockRepo.EXPECT().UserExists(gomock.Any(), "user2").Return(true, nil).Times(1)
+				mockRepo.EXPECT().GetMerchPrice(gomock.Any(), "cup").Return(20, nil).Times(1)
+				mockRepo.EXPECT().SaveGift(gomock.Any(), domain.Gift{
+					FromUser: "user1",
+					ToUser:   "user2",
+					Item:     "cup",
+					Quantity: 1,
+					Message:  "happy birthday",
+				}, 20, gomock.Any()).Return(nil).Times(1)
+			},
+		},
+		{
+			name:        "gift to yourself",
+			gift:        domain.Gift{FromUser: "user1", ToUser: "user1", Item: "cup"},
+			mockRepo:    func() {},
+			expectedErr: appErrors.ErrSelfGift,
+		},
+		{
+			name:        "gift to the system account",
+			gift:        domain.Gift{FromUser: "user1", ToUser: domain.SystemAccount, Item: "cup"},
+			mockRepo:    func() {},
+			expectedErr: appErrors.ErrSystemAccount,
+		},
+		{
+			name: "recipient not found",
+			gift: domain.Gift{FromUser: "user1", ToUser: "ghost", Item: "cup"},
+			mockRepo: func() {
+				mockRepo.EXPECT().UserExists(gomock.Any(), "ghost").Return(false, nil).Times(1)
+			},
+			expectedErr: appErrors.ErrUserNotFound,
+		},
+		{
+			name: "insufficient balance",
+			gift: domain.Gift{FromUser: "user1", ToUser: "user2", Item: "pink-hoody", Quantity: 2},
+			mockRepo: func() {
+				mockRepo.EXPECT().UserExists(gomock.Any(), "user2").Return(true, nil).Times(1)
+				mockRepo.EXPECT().GetMerchPrice(gomock.Any(), "pink-hoody").Return(500, nil).Times(1)
+				mockRepo.EXPECT().SaveGift(gomock.Any(), gomock.Any(), 500, gomock.Any()).Return(appErrors.ErrInsufficientBalance).Times(1)
+			},
+			expectedErr: appErrors.ErrInsufficientBalance,
+		},
+		{
+			name: "recipient reached item limit",
+			gift: domain.Gift{FromUser: "user1", ToUser: "user2", Item: "pink-hoody"},
+			mockRepo: func() {
+				mockRepo.EXPECT().UserExists(gomock.Any(), "user2").Return(true, nil).Times(1)
+				mockRepo.EXPECT().GetMerchPrice(gomock.Any(), "pink-hoody").Return(500, nil).Times(1)
+				mockRepo.EXPECT().SaveGift(gomock.Any(), gomock.Any(), 500, gomock.Any()).Return(&appErrors.RuleError{
+					Err:  &appErrors.LimitError{Err: appErrors.ErrItemLimitReached},
+					Code: domain.RuleMaxPerUser,
+				}).Times(1)
+			},
+			expectedErr: appErrors.ErrItemLimitReached,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockRepo()
+
+			err := merchService.GiftMerch(context.Background(), testCase.gift)
+
+			if testCase.expectedErr != nil {
+				require.ErrorIs(t, err, testCase.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/metrics"
+)
+
+// checkTransferPolicy applies the configured transfer limits. Daily caps
+// count transfers and market purchases made since midnight UTC. The
+// repository checks the amount limits again under the sender's row lock.
+func (s *Merch) checkTransferPolicy(ctx context.Context, transfer domain.Transfer) error {
+	policy := s.policy
+
+	if policy.MaxPerTransfer > 0 && transfer.Amount > policy.MaxPerTransfer {
+		return rejectTransfer("max_per_transfer", appErrors.ErrTransferLimitExceeded, policy.MaxPerTransfer)
+	}
+
+	now := s.now()
+
+	if policy.MinAccountAge > 0 {
+		createdAt, err := s.repo.GetUserCreatedAt(ctx, transfer.FromUser)
+		if err != nil {
+			return fmt.Errorf("service.checkTransferPolicy: %w", err)
+		}
+		if now.Sub(createdAt) < policy.MinAccountAge {
+			metrics.TransferPolicyRejections.WithLabelValues("min_account_age").Inc()
+			return appErrors.ErrAccountTooNew
+		}
+	}
+
+	if policy.DailyOutgoingCap <= 0 && policy.DailyPerRecipientCap <= 0 {
+		return nil
+	}
+
+	totals, err := s.repo.GetTransferTotals(ctx, transfer.FromUser, transfer.ToUser, dayStart(now))
+	if err != nil {
+		return fmt.Errorf("service.checkTransferPolicy: %w", err)
+	}
+
+	if policy.DailyOutgoingCap > 0 && totals.Outgoing+transfer.Amount > policy.DailyOutgoingCap {
+		return rejectTransfer("daily_outgoing_cap", appErrors.ErrDailyLimitExceeded, policy.DailyOutgoingCap-totals.Outgoing)
+	}
+
+	if policy.DailyPerRecipientCap > 0 && totals.ToRecipient+transfer.Amount > policy.DailyPerRecipientCap {
+		return rejectTransfer("daily_recipient_cap", appErrors.ErrRecipientLimitExceeded, policy.DailyPerRecipientCap-totals.ToRecipient)
+	}
+
+	return nil
+}
+
+// dayStart is the start of the UTC day the daily caps count from.
+func dayStart(now time.Time) time.Time {
+	return now.UTC().Truncate(24 * time.Hour)
+}
+
+// countTransferRejection records a limit violation reported by the
+// repository after it re-checked the policy inside the transaction.
+func countTransferRejection(err error) {
+	switch {
+	case errors.Is(err, appErrors.ErrTransferLimitExceeded):
+		metrics.TransferPolicyRejections.WithLabelValues("max_per_transfer").Inc()
+	case errors.Is(err, appErrors.ErrDailyLimitExceeded):
+		metrics.TransferPolicyRejections.WithLabelValues("daily_outgoing_cap").Inc()
+	case errors.Is(err, appErrors.ErrRecipientLimitExceeded):
+		metrics.TransferPolicyRejections.WithLabelValues("daily_recipient_cap").Inc()
+	}
+}
+
+func rejectTransfer(rule string, err error, remaining int) error {
+	metrics.TransferPolicyRejections.WithLabelValues(rule).Inc()
+	return &appErrors.LimitError{Err: err, Remaining: max(remaining, 0)}
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+)
+
+func TestSendCoinTransferPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMerchRepository(ctrl)
+	policy := domain.TransferPolicy{
+		MaxPerTransfer:       300,
+		DailyOutgoingCap:     500,
+		DailyPerRecipientCap: 200,
+		MinAccountAge:        24 * time.Hour,
+	}
+	merchService := NewMerch(mockRepo, policy)
+
+	now := time.Date(2025, time.March, 10, 15, 0, 0, 0, time.UTC)
+	merchService.now = func() time.Time { return now }
+	dayStart := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+	oldAccount := now.Add(-48 * time.Hour)
+
+	testCases := []struct {
+		name              string
+		amount            int
+		mockRepo          func()
+		expectedErr       error
+		expectedRemaining int
+	}{
+		{
+			name:   "within limits",
+			amount: 100,
+			mockRepo: func() {
+				mockRepo.EXPECT().UserExists(gomock.Any(), "user2").Return(true, nil).Times(1)
+				mockRepo.EXPECT().GetUserCreatedAt(gomock.Any(), "user1").Return(oldAccount, nil).Times(1)
+				mockRepo.EXPECT().GetTransferTotals(gomock.Any(), "user1", "user2", dayStart).
+					Return(domain.TransferTotals{Outgoing: 100, ToRecipient: 50}, nil).Times(1)
+				mockRepo.EXPECT().GetUserBalance(gomock.Any(), "user1").Return(1000, nil).Times(1)
+				mockRepo.EXPECT().TransferCoins(gomock.Any(), gomock.Any(), policy, dayStart).Return(nil).Times(1)
+			},
+		},
+		{
+			name:   "per-transfer maximum",
+			amount: 301,
+			mockRepo: func() {
+				mockRepo.EXPECT().UserExists(gomock.Any(), "user2").Return(true, nil).Times(1)
+			},
+			expectedErr:       appErrors.ErrTransferLimitExceeded,
+			expectedRemaining: 300,
+		},
+		{
+			name:   "account too new",
+			amount: 10,
+			mockRepo: func() {
+				mockRepo.EXPECT().UserExists(gomock.Any(), "user2").Return(true, nil).Times(1)
+				mockRepo.EXPECT().GetUserCreatedAt(gomock.Any(), "user1").Return(now.Add(-time.Hour), nil).Times(1)
+			},
+			expectedErr: appErrors.ErrAccountTooNew,
+		},
+		{
+			name:   "daily outgoing cap",
+			amount: 150,
+			mockRepo: func() {
+				mockRepo.EXPECT().UserExists(gomock.Any(), "user2").Return(true, nil).Times(1)
+				mockRepo.EXPECT().GetUserCreatedAt(gomock.Any(), "user1").Return(oldAccount, nil).Times(1)
+				mockRepo.EXPECT().GetTransferTotals(gomock.Any(), "user1", "user2", dayStart).
+					Return(domain.TransferTotals{Outgoing: 400}, nil).Times(1)
+			},
+			expectedErr:       appErrors.ErrDailyLimitExceeded,
+			expectedRemaining: 100,
+		},
+		{
+			name:   "per-recipient daily cap",
+			amount: 100,
+			mockRepo: func() {
+				mockRepo.EXPECT().UserExists(gomock.Any(), "user2").Return(true, nil).Times(1)
+				mockRepo.EXPECT().GetUserCreatedAt(gomock.Any(), "user1").Return(oldAccount, nil).Times(1)
+				mockRepo.EXPECT().GetTransferTotals(gomock.Any(), "user1", "user2", dayStart).
+					Return(domain.TransferTotals{Outgoing: 180, ToRecipient: 180}, nil).Times(1)
+			},
+			expectedErr:       appErrors.ErrRecipientLimitExceeded,
+			expectedRemaining: 20,
+		},
+		{
+			name:   "daily cap reached by a concurrent transfer",
+			amount: 100,
+			mockRepo: func() {
+				mockRepo.EXPECT().UserExists(gomock.Any(), "user2").Return(true, nil).Times(1)
+				mockRepo.EXPECT().GetUserCreatedAt(gomock.Any(), "user1").Return(oldAccount, nil).Times(1)
+				mockRepo.EXPECT().GetTransferTotals(gomock.Any(), "user1", "user2", dayStart).
+					Return(domain.TransferTotals{Outgoing: 300}, nil).Times(1)
+				mockRepo.EXPECT().GetUserBalance(gomock.Any(), "user1").Return(1000, nil).Times(1)
+				mockRepo.EXPECT().TransferCoins(gomock.Any(), gomock.Any(), policy, dayStart).
+					Return(&appErrors.LimitError{Err: appErrors.ErrDailyLimitExceeded, Remaining: 50}).Times(1)
+			},
+			expectedErr:       appErrors.ErrDailyLimitExceeded,
+			expectedRemaining: 50,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockRepo()
+
+			err := merchService.SendCoin(context.Background(), domain.Transfer{FromUser: "user1", ToUser: "user2", Amount: testCase.amount})
+
+			if testCase.expectedErr == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, testCase.expectedErr)
+			require.Equal(t, testCase.expectedErr.Error(), err.Error())
+
+			var limitErr *appErrors.LimitError
+			if errors.As(err, &limitErr) {
+				require.Equal(t, testCase.expectedRemaining, limitErr.Remaining)
+			}
+		})
+	}
+}
@@ -0,0 +1,295 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+	"github.com/Te8va/MerchStore/internal/metrics"
+	"github.com/Te8va/MerchStore/pkg/logger"
+)
+
+const (
+	webhookBatchSize    = 20
+	webhookMinSecretLen = 16
+	webhookBaseBackoff  = 30 * time.Second
+	webhookMaxBackoff   = 6 * time.Hour
+	webhookMaxErrorLen  = 500
+)
+
+type Webhooks struct {
+	repo        domain.WebhookRepository
+	client      *http.Client
+	maxAttempts int
+	now         func() time.Time
+}
+
+func NewWebhooks(repo domain.WebhookRepository, client *http.Client, maxAttempts int) *Webhooks {
+	return &Webhooks{repo: repo, client: client, maxAttempts: maxAttempts, now: time.Now}
+}
+
+func (s *Webhooks) CreateWebhook(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "service.CreateWebhook")
+	defer span.End()
+
+	if err := validateWebhook(&webhook); err != nil {
+		return domain.Webhook{}, err
+	}
+
+	switch {
+	case webhook.Secret == "":
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return domain.Webhook{}, fmt.Errorf("service.CreateWebhook: could not generate secret: %w", err)
+		}
+		webhook.Secret = hex.EncodeToString(secret)
+	case len(webhook.Secret) < webhookMinSecretLen:
+		return domain.Webhook{}, fmt.Errorf("%w: secret must be at least %d characters", appErrors.ErrInvalidWebhook, webhookMinSecretLen)
+	}
+	webhook.Active = true
+
+	created, err := s.repo.CreateWebhook(ctx, webhook)
+	if err != nil {
+		return domain.Webhook{}, fmt.Errorf("service.CreateWebhook: %w", err)
+	}
+
+	return created, nil
+}
+
+func (s *Webhooks) ListWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "service.ListWebhooks")
+	defer span.End()
+
+	webhooks, err := s.repo.ListWebhooks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListWebhooks: %w", err)
+	}
+
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+	return webhooks, nil
+}
+
+func (s *Webhooks) UpdateWebhook(ctx context.Context, webhook domain.Webhook) (domain.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "service.UpdateWebhook")
+	defer span.End()
+
+	if err := validateWebhook(&webhook); err != nil {
+		return domain.Webhook{}, err
+	}
+
+	updated, err := s.repo.UpdateWebhook(ctx, webhook)
+	if err != nil {
+		return domain.Webhook{}, fmt.Errorf("service.UpdateWebhook: %w", err)
+	}
+
+	updated.Secret = ""
+	return updated, nil
+}
+
+func (s *Webhooks) DeleteWebhook(ctx context.Context, id int64) error {
+	ctx, span := tracer.Start(ctx, "service.DeleteWebhook")
+	defer span.End()
+
+	if err := s.repo.DeleteWebhook(ctx, id); err != nil {
+		return fmt.Errorf("service.DeleteWebhook: %w", err)
+	}
+	return nil
+}
+
+func (s *Webhooks) ListDeliveries(ctx context.Context, filter domain.DeliveryFilter) ([]domain.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "service.ListDeliveries")
+	defer span.End()
+
+	switch filter.Status {
+	case "", domain.DeliveryPending, domain.DeliveryDelivered, domain.DeliveryDead:
+	default:
+		return nil, appErrors.ErrInvalidDeliveryStatus
+	}
+
+	deliveries, err := s.repo.ListDeliveries(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListDeliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// ReplayEvent queues an outbox event for delivery again. It also revives
+// dead deliveries and re-sends ones that already succeeded.
+func (s *Webhooks) ReplayEvent(ctx context.Context, eventID, webhookID int64) (int, error) {
+	ctx, span := tracer.Start(ctx, "service.ReplayEvent")
+	defer span.End()
+
+	queued, err := s.repo.ReplayEvent(ctx, eventID, webhookID, s.now())
+	if err != nil {
+		return 0, fmt.Errorf("service.ReplayEvent: %w", err)
+	}
+	return queued, nil
+}
+
+// Dispatch sends due deliveries until none are left. Deliveries of one batch
+// are sent concurrently so that one slow endpoint does not hold up the rest.
+func (s *Webhooks) Dispatch(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "service.Dispatch")
+	defer span.End()
+
+	for {
+		now := s.now()
+		deliveries, err := s.repo.ClaimDeliveries(ctx, now, now.Add(s.client.Timeout+time.Minute), webhookBatchSize)
+		if err != nil {
+			return fmt.Errorf("service.Dispatch: %w", err)
+		}
+
+		var wg sync.WaitGroup
+		for _, delivery := range deliveries {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				result := s.deliver(ctx, delivery)
+				metrics.WebhookDeliveries.WithLabelValues(deliveryOutcome(result)).Inc()
+				if err := s.repo.SaveDeliveryResult(ctx, delivery.ID, result); err != nil {
+					logger.FromContext(ctx).Error("Failed to save webhook delivery result",
+						zap.Int64("delivery_id", delivery.ID), zap.Error(err))
+				}
+			}()
+		}
+		wg.Wait()
+
+		if len(deliveries) < webhookBatchSize || ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+func (s *Webhooks) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Dispatch(ctx); err != nil && ctx.Err() == nil {
+			logger.FromContext(ctx).Error("Webhook dispatch failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Webhooks) deliver(ctx context.Context, delivery domain.WebhookDelivery) domain.DeliveryResult {
+	body, err := json.Marshal(struct {
+		ID        int64           `json:"id"`
+		Type      string          `json:"type"`
+		CreatedAt time.Time       `json:"createdAt"`
+		Data      json.RawMessage `json:"data"`
+	}{delivery.EventID, delivery.EventType, delivery.EventCreatedAt, delivery.Payload})
+	if err != nil {
+		return s.failure(delivery, 0, err.Error())
+	}
+
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return s.failure(delivery, 0, err.Error())
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "MerchStore-Webhooks")
+	req.Header.Set("X-MerchStore-Event", delivery.EventType)
+	req.Header.Set("X-MerchStore-Delivery", strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set("X-MerchStore-Timestamp", timestamp)
+	req.Header.Set("X-MerchStore-Signature", "sha256="+WebhookSignature(delivery.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return s.failure(delivery, 0, err.Error())
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return s.failure(delivery, resp.StatusCode, "unexpected status "+resp.Status)
+	}
+
+	now := s.now()
+	return domain.DeliveryResult{Status: domain.DeliveryDelivered, StatusCode: resp.StatusCode, NextAttemptAt: now, At: now}
+}
+
+// failure reschedules the delivery with exponential backoff or gives up
+// after maxAttempts.
+func (s *Webhooks) failure(delivery domain.WebhookDelivery, statusCode int, message string) domain.DeliveryResult {
+	now := s.now()
+	if len(message) > webhookMaxErrorLen {
+		message = message[:webhookMaxErrorLen]
+	}
+
+	result := domain.DeliveryResult{Status: domain.DeliveryDead, StatusCode: statusCode, Error: message, NextAttemptAt: now, At: now}
+	if delivery.Attempts < s.maxAttempts {
+		result.Status = domain.DeliveryPending
+		result.NextAttemptAt = now.Add(webhookBackoff(delivery.Attempts))
+	}
+	return result
+}
+
+// WebhookSignature is the hex HMAC-SHA256 of "<timestamp>.<body>" that
+// receivers compare with the X-MerchStore-Signature header.
+func WebhookSignature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func webhookBackoff(attempts int) time.Duration {
+	backoff := webhookBaseBackoff
+	for i := 1; i < attempts && backoff < webhookMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, webhookMaxBackoff)
+}
+
+func deliveryOutcome(result domain.DeliveryResult) string {
+	if result.Status == domain.DeliveryPending {
+		return "retry"
+	}
+	return result.Status
+}
+
+func validateWebhook(webhook *domain.Webhook) error {
+	webhook.URL = strings.TrimSpace(webhook.URL)
+	if !isHTTPURL(webhook.URL) {
+		return fmt.Errorf("%w: url must be an http or https URL", appErrors.ErrInvalidWebhook)
+	}
+
+	if webhook.EventTypes == nil {
+		webhook.EventTypes = []string{}
+	}
+	for _, eventType := range webhook.EventTypes {
+		if !slices.Contains(domain.OutboxEventTypes, eventType) {
+			return fmt.Errorf("%w: unknown event type %q", appErrors.ErrInvalidWebhook, eventType)
+		}
+	}
+	slices.Sort(webhook.EventTypes)
+	webhook.EventTypes = slices.Compact(webhook.EventTypes)
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+)
+
+func TestWebhookDispatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	status := http.StatusOK
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	mockRepo := mocks.NewMockWebhookRepository(ctrl)
+	webhookService := NewWebhooks(mockRepo, server.Client(), 3)
+	webhookService.now = func() time.Time { return now }
+
+	delivery := domain.WebhookDelivery{
+		ID: 1, EventID: 10, EventType: domain.OutboxCoinsTransferred, Attempts: 1,
+		Payload: json.RawMessage(`{"from":"alice","to":"bob","amount":50}`), URL: server.URL, Secret: "topsecret-topsecret",
+	}
+
+	// Успешная доставка подписывается HMAC
+	mockRepo.EXPECT().ClaimDeliveries(gomock.Any(), now, gomock.Any(), webhookBatchSize).Return([]domain.WebhookDelivery{delivery}, nil)
+	mockRepo.EXPECT().SaveDeliveryResult(gomock.Any(), int64(1), domain.DeliveryResult{
+		Status: domain.DeliveryDelivered, StatusCode: http.StatusOK, NextAttemptAt: now, At: now,
+	}).Return(nil)
+	require.NoError(t, webhookService.Dispatch(context.Background()))
+
+	timestamp := received.Header.Get("X-MerchStore-Timestamp")
+	require.Equal(t, "sha256="+WebhookSignature(delivery.Secret, timestamp, body), received.Header.Get("X-MerchStore-Signature"))
+	require.Equal(t, domain.OutboxCoinsTransferred, received.Header.Get("X-MerchStore-Event"))
+	require.JSONEq(t, `{"id":10,"type":"coins.transferred","createdAt":"0001-01-01T00:00:00Z","data":{"from":"alice","to":"bob","amount":50}}`, string(body))
+
+	// Ошибка получателя откладывает повтор с экспоненциальной задержкой
+	status = http.StatusInternalServerError
+	delivery.Attempts = 2
+	mockRepo.EXPECT().ClaimDeliveries(gomock.Any(), now, gomock.Any(), webhookBatchSize).Return([]domain.WebhookDelivery{delivery}, nil)
+	mockRepo.EXPECT().SaveDeliveryResult(gomock.Any(), int64(1), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ int64, result domain.DeliveryResult) error {
+			require.Equal(t, domain.DeliveryPending, result.Status)
+			require.Equal(t, http.StatusInternalServerError, result.StatusCode)
+			require.Equal(t, now.Add(time.Minute), result.NextAttemptAt)
+			return nil
+		})
+	require.NoError(t, webhookService.Dispatch(context.Background()))
+
+	// После последней попытки доставка становится dead
+	delivery.Attempts = 3
+	mockRepo.EXPECT().ClaimDeliveries(gomock.Any(), now, gomock.Any(), webhookBatchSize).Return([]domain.WebhookDelivery{delivery}, nil)
+	mockRepo.EXPECT().SaveDeliveryResult(gomock.Any(), int64(1), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ int64, result domain.DeliveryResult) error {
+			require.Equal(t, domain.DeliveryDead, result.Status)
+			return nil
+		})
+	require.NoError(t, webhookService.Dispatch(context.Background()))
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	require.Equal(t, 30*time.Second, webhookBackoff(1))
+	require.Equal(t, 4*time.Minute, webhookBackoff(4))
+	require.Equal(t, webhookMaxBackoff, webhookBackoff(50))
+}
+
+func TestCreateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWebhookRepository(ctrl)
+	webhookService := NewWebhooks(mockRepo, http.DefaultClient, 3)
+
+	testCases := []struct {
+		name    string
+		webhook domain.Webhook
+	}{
+		{"not a url", domain.Webhook{URL: "hooks.example.com"}},
+		{"ftp url", domain.Webhook{URL: "ftp://hooks.example.com"}},
+		{"unknown event type", domain.Webhook{URL: "https://hooks.example.com", EventTypes: []string{"coins.stolen"}}},
+		{"short secret", domain.Webhook{URL: "https://hooks.example.com", Secret: "123"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := webhookService.CreateWebhook(context.Background(), testCase.webhook)
+			require.ErrorIs(t, err, appErrors.ErrInvalidWebhook)
+		})
+	}
+
+	mockRepo.EXPECT().CreateWebhook(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, webhook domain.Webhook) (domain.Webhook, error) {
+			require.Len(t, webhook.Secret, 64)
+			require.True(t, webhook.Active)
+			require.Equal(t, []string{domain.OutboxCoinsTransferred, domain.OutboxMerchPurchased}, webhook.EventTypes)
+			return webhook, nil
+		})
+	_, err := webhookService.CreateWebhook(context.Background(), domain.Webhook{
+		URL:        " https://hooks.example.com/merch ",
+		EventTypes: []string{domain.OutboxMerchPurchased, domain.OutboxCoinsTransferred, domain.OutboxMerchPurchased},
+	})
+	require.NoError(t, err)
+
+	mockRepo.EXPECT().ListWebhooks(gomock.Any()).Return([]domain.Webhook{{ID: 1, Secret: "topsecret-topsecret"}}, nil)
+	webhooks, err := webhookService.ListWebhooks(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, webhooks[0].Secret)
+
+	_, err = webhookService.ListDeliveries(context.Background(), domain.DeliveryFilter{Status: "lost"})
+	require.ErrorIs(t, err, appErrors.ErrInvalidDeliveryStatus)
+}
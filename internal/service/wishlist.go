@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/pkg/logger"
+)
+
+type Wishlist struct {
+	repo domain.WishlistRepository
+	now  func() time.Time
+}
+
+func NewWishlist(repo domain.WishlistRepository) *Wishlist {
+	return &Wishlist{repo: repo, now: time.Now}
+}
+
+func (s *Wishlist) GetWishlist(ctx context.Context, username string) (domain.Wishlist, error) {
+	ctx, span := tracer.Start(ctx, "service.GetWishlist")
+	defer span.End()
+
+	wishlist, err := s.repo.GetWishlist(ctx, username)
+	if err != nil {
+		return domain.Wishlist{}, fmt.Errorf("service.GetWishlist: %w", err)
+	}
+
+	for i := range wishlist.Items {
+		wishlist.Items[i].Affordable = wishlist.Balance >= wishlist.Items[i].Price
+	}
+
+	return wishlist, nil
+}
+
+func (s *Wishlist) AddToWishlist(ctx context.Context, username, item string) error {
+	ctx, span := tracer.Start(ctx, "service.AddToWishlist")
+	defer span.End()
+
+	if err := s.repo.AddToWishlist(ctx, username, strings.TrimSpace(item)); err != nil {
+		return fmt.Errorf("service.AddToWishlist: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Wishlist) RemoveFromWishlist(ctx context.Context, username, item string) error {
+	ctx, span := tracer.Start(ctx, "service.RemoveFromWishlist")
+	defer span.End()
+
+	if err := s.repo.RemoveFromWishlist(ctx, username, item); err != nil {
+		return fmt.Errorf("service.RemoveFromWishlist: %w", err)
+	}
+
+	return nil
+}
+
+// NotifyPromotions notifies wishlisting users about promotions that have
+// started since the last run.
+func (s *Wishlist) NotifyPromotions(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "service.NotifyPromotions")
+	defer span.End()
+
+	sent, err := s.repo.NotifyStartedPromotions(ctx, s.now())
+	if err != nil {
+		return fmt.Errorf("service.NotifyPromotions: %w", err)
+	}
+
+	if sent > 0 {
+		logger.FromContext(ctx).Info("Promotion notifications sent", zap.Int("notifications", sent))
+	}
+
+	return nil
+}
+
+// Run calls NotifyPromotions immediately and then every interval until ctx
+// is done. Failures are logged and retried on the next tick.
+func (s *Wishlist) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.NotifyPromotions(ctx); err != nil && ctx.Err() == nil {
+			logger.FromContext(ctx).Error("Promotion notification run failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Te8va/MerchStore/internal/domain"
+	"github.com/Te8va/MerchStore/internal/domain/mocks"
+	appErrors "github.com/Te8va/MerchStore/internal/errors"
+)
+
+func TestWishlistAffordability(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWishlistRepository(ctrl)
+	wishlistService := NewWishlist(mockRepo)
+
+	mockRepo.EXPECT().GetWishlist(gomock.Any(), "user1").Return(domain.Wishlist{
+		Balance: 300,
+		Items:   []domain.WishlistItem{{Item: "hoody", Price: 300}, {Item: "pink-hoody", Price: 500}},
+	}, nil).Times(1)
+
+	wishlist, err := wishlistService.GetWishlist(context.Background(), "user1")
+	require.NoError(t, err)
+	require.True(t, wishlist.Items[0].Affordable)
+	require.False(t, wishlist.Items[1].Affordable)
+
+	mockRepo.EXPECT().AddToWishlist(gomock.Any(), "user1", "pink-hoody").Return(appErrors.ErrWishlistFull).Times(1)
+	err = wishlistService.AddToWishlist(context.Background(), "user1", " pink-hoody ")
+	require.ErrorIs(t, err, appErrors.ErrWishlistFull)
+}
+
+func TestWishlistNotifyPromotions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWishlistRepository(ctrl)
+	wishlistService := NewWishlist(mockRepo)
+
+	now := time.Date(2025, time.June, 1, 9, 0, 0, 0, time.UTC)
+	wishlistService.now = func() time.Time { return now }
+
+	mockRepo.EXPECT().NotifyStartedPromotions(gomock.Any(), now).Return(3, nil).Times(1)
+	require.NoError(t, wishlistService.NotifyPromotions(context.Background()))
+
+	mockRepo.EXPECT().NotifyStartedPromotions(gomock.Any(), now).Return(0, errors.New("db error")).Times(1)
+	err := wishlistService.NotifyPromotions(context.Background())
+	require.ErrorContains(t, err, "service.NotifyPromotions: db error")
+}
@@ -0,0 +1,38 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestInitFileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+
+	shutdown, err := Init(context.Background(), Options{
+		Exporter:    ExporterFile,
+		FilePath:    path,
+		ServiceName: "merch-test",
+		SampleRatio: 1,
+	})
+	require.NoError(t, err)
+
+	_, span := otel.Tracer("tracing_test").Start(context.Background(), "test-span")
+	span.End()
+
+	require.NoError(t, shutdown(context.Background()))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "test-span")
+	require.Contains(t, string(data), "merch-test")
+}
+
+func TestInitUnknownExporter(t *testing.T) {
+	_, err := Init(context.Background(), Options{Exporter: "zipkin"})
+	require.Error(t, err)
+}
@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS redemptions (
+    id SERIAL PRIMARY KEY,
+    username TEXT NOT NULL,
+    item TEXT NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    office TEXT NOT NULL DEFAULT '',
+    note TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (username) REFERENCES users(username) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS redemptions_username_idx ON redemptions (username, created_at DESC);
+CREATE INDEX IF NOT EXISTS redemptions_status_idx ON redemptions (status, created_at);
+
+COMMIT;
@@ -0,0 +1,39 @@
+BEGIN;
+
+ALTER TABLE merch ADD COLUMN IF NOT EXISTS category TEXT NOT NULL DEFAULT 'other';
+
+UPDATE merch SET category = 'clothing' WHERE item_name IN ('t-shirt', 'hoody', 'pink-hoody', 'socks');
+UPDATE merch SET category = 'accessories' WHERE item_name IN ('cup', 'pen', 'powerbank', 'umbrella', 'wallet');
+UPDATE merch SET category = 'books' WHERE item_name = 'book';
+
+CREATE TABLE IF NOT EXISTS promotions (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    item TEXT,
+    category TEXT,
+    discount_type TEXT NOT NULL CHECK (discount_type IN ('percent', 'fixed')),
+    discount_value INT NOT NULL CHECK (discount_value > 0),
+    code TEXT UNIQUE,
+    max_uses INT NOT NULL DEFAULT 0 CHECK (max_uses >= 0),
+    per_user_limit INT NOT NULL DEFAULT 0 CHECK (per_user_limit >= 0),
+    uses INT NOT NULL DEFAULT 0,
+    starts_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ends_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((item IS NULL) <> (category IS NULL)),
+    CHECK (discount_type <> 'percent' OR discount_value <= 100)
+);
+
+ALTER TABLE purchases
+    ADD COLUMN IF NOT EXISTS paid_price INT,
+    ADD COLUMN IF NOT EXISTS discount INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS promotion_id INT REFERENCES promotions(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS promo_code TEXT NOT NULL DEFAULT '';
+
+UPDATE purchases SET paid_price = price WHERE paid_price IS NULL;
+
+ALTER TABLE purchases ALTER COLUMN paid_price SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS purchases_promotion_idx ON purchases (promotion_id, username) WHERE promotion_id IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,31 @@
+BEGIN;
+
+ALTER TABLE merch
+    ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS image_url TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS merch_variants (
+    id SERIAL PRIMARY KEY,
+    item_name TEXT NOT NULL REFERENCES merch(item_name) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('size', 'colour')),
+    stock INT NOT NULL DEFAULT 0 CHECK (stock >= 0),
+    UNIQUE (item_name, name)
+);
+
+INSERT INTO merch_variants (item_name, name, kind, stock)
+SELECT item_name, size, 'size', 50
+FROM merch, unnest(ARRAY['S', 'M', 'L', 'XL']) AS size
+WHERE item_name IN ('t-shirt', 'hoody', 'pink-hoody')
+ON CONFLICT (item_name, name) DO NOTHING;
+
+ALTER TABLE inventory ADD COLUMN IF NOT EXISTS variant TEXT NOT NULL DEFAULT '';
+ALTER TABLE inventory DROP CONSTRAINT IF EXISTS inventory_user_id_item_name_key;
+ALTER TABLE inventory ADD CONSTRAINT inventory_user_id_item_name_variant_key UNIQUE (user_id, item_name, variant);
+
+ALTER TABLE purchases ADD COLUMN IF NOT EXISTS variant TEXT NOT NULL DEFAULT '';
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS variant TEXT NOT NULL DEFAULT '';
+ALTER TABLE market_listings ADD COLUMN IF NOT EXISTS variant TEXT NOT NULL DEFAULT '';
+ALTER TABLE redemptions ADD COLUMN IF NOT EXISTS variant TEXT NOT NULL DEFAULT '';
+
+COMMIT;
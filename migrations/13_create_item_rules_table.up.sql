@@ -0,0 +1,18 @@
+BEGIN;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'employee';
+
+CREATE TABLE IF NOT EXISTS item_rules (
+    item_name TEXT PRIMARY KEY REFERENCES merch(item_name) ON DELETE CASCADE,
+    max_per_user INT NOT NULL DEFAULT 0 CHECK (max_per_user >= 0),
+    max_per_period INT NOT NULL DEFAULT 0 CHECK (max_per_period >= 0),
+    period TEXT NOT NULL DEFAULT 'monthly' CHECK (period IN ('daily', 'weekly', 'monthly')),
+    min_account_age_days INT NOT NULL DEFAULT 0 CHECK (min_account_age_days >= 0),
+    required_role TEXT NOT NULL DEFAULT '',
+    requires_received_coins BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS purchases_username_item_idx ON purchases (username, item, purchase_date);
+
+COMMIT;
@@ -0,0 +1,29 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS wishlist (
+    username TEXT NOT NULL REFERENCES users(username) ON DELETE CASCADE,
+    item_name TEXT NOT NULL REFERENCES merch(item_name) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (username, item_name)
+);
+
+CREATE INDEX IF NOT EXISTS wishlist_item_idx ON wishlist (item_name);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id BIGSERIAL PRIMARY KEY,
+    username TEXT NOT NULL REFERENCES users(username) ON DELETE CASCADE,
+    kind TEXT NOT NULL,
+    message TEXT NOT NULL,
+    item TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    read_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS notifications_username_idx ON notifications (username, id DESC);
+
+ALTER TABLE promotions ADD COLUMN IF NOT EXISTS notified_at TIMESTAMPTZ;
+
+-- Promotions that already started before wishlists existed have nobody to notify.
+UPDATE promotions SET notified_at = CURRENT_TIMESTAMP WHERE starts_at <= CURRENT_TIMESTAMP;
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+-- event_seq is the last event number handed out to the user. Writers bump
+-- it under the row lock, so a user's events commit in seq order.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS event_seq BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS user_events (
+    id BIGSERIAL PRIMARY KEY,
+    username TEXT NOT NULL REFERENCES users(username) ON DELETE CASCADE,
+    seq BIGINT NOT NULL,
+    kind TEXT NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (username, seq)
+);
+
+CREATE INDEX IF NOT EXISTS user_events_created_at_idx ON user_events (created_at);
+
+COMMIT;
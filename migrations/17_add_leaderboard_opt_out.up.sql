@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS leaderboard_opt_out BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS transactions_kind_created_at_idx ON transactions (kind, created_at);
+CREATE INDEX IF NOT EXISTS purchases_purchase_date_idx ON purchases (purchase_date);
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+-- A registered user who already owns the name would silently become the
+-- source of every grant, so refuse to migrate until they are renamed.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM users WHERE username = 'system' AND password <> '!') THEN
+        RAISE EXCEPTION 'username "system" is taken by a registered user, rename it before migrating';
+    END IF;
+END $$;
+
+INSERT INTO users (username, password, token, balance) VALUES ('system', '!', '', 0)
+ON CONFLICT (username) DO NOTHING;
+
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS kind TEXT NOT NULL DEFAULT 'transfer',
+    ADD COLUMN IF NOT EXISTS reason TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+COMMIT;
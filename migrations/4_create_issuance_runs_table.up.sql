@@ -0,0 +1,14 @@
+BEGIN;
+
+ALTER TABLE users ALTER COLUMN balance SET DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS issuance_runs (
+    policy TEXT NOT NULL,
+    period_key TEXT NOT NULL,
+    amount INT NOT NULL CHECK (amount > 0),
+    recipients INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (policy, period_key)
+);
+
+COMMIT;
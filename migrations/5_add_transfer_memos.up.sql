@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS message TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS category TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS transactions_from_user_idx ON transactions (from_user, created_at DESC);
+CREATE INDEX IF NOT EXISTS transactions_to_user_idx ON transactions (to_user, created_at DESC);
+
+COMMIT;
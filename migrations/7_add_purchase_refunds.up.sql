@@ -0,0 +1,14 @@
+BEGIN;
+
+ALTER TABLE purchases
+    ADD COLUMN IF NOT EXISTS quantity INT NOT NULL DEFAULT 1 CHECK (quantity > 0),
+    ADD COLUMN IF NOT EXISTS refunded_quantity INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS refunded_at TIMESTAMPTZ;
+
+ALTER TABLE purchases
+    ALTER COLUMN purchase_date TYPE TIMESTAMPTZ,
+    ADD CONSTRAINT purchases_refunded_quantity_check CHECK (refunded_quantity >= 0 AND refunded_quantity <= quantity);
+
+CREATE INDEX IF NOT EXISTS purchases_username_idx ON purchases (username, purchase_date DESC);
+
+COMMIT;
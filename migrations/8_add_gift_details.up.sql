@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS item TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS quantity INT NOT NULL DEFAULT 0;
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS market_listings (
+    id SERIAL PRIMARY KEY,
+    seller TEXT NOT NULL,
+    item TEXT NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    price INT NOT NULL CHECK (price > 0),
+    status TEXT NOT NULL DEFAULT 'open',
+    buyer TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    closed_at TIMESTAMPTZ,
+    FOREIGN KEY (seller) REFERENCES users(username) ON DELETE CASCADE,
+    FOREIGN KEY (buyer) REFERENCES users(username) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS market_listings_open_idx ON market_listings (item, created_at DESC) WHERE status = 'open';
+
+COMMIT;
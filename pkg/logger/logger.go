@@ -1,20 +1,51 @@
 package logger
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"os"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+
+	OutputStdout = "stdout"
+	OutputStderr = "stderr"
+	OutputFile   = "file"
+)
+
+type Options struct {
+	Level          string
+	Format         string
+	Outputs        []string
+	FilePath       string
+	MaxSizeMB      int
+	MaxBackups     int
+	MaxAgeDays     int
+	Compress       bool
+	RotateInterval time.Duration
+}
+
 type logger struct {
 	*zap.SugaredLogger
 	once    *sync.Once
 	logFile string
+	level   zap.AtomicLevel
+	files   []*lumberjack.Logger
+	stop    chan struct{}
 }
 
 var (
-	log = &logger{once: &sync.Once{}, logFile: "logfile.log"}
+	log = &logger{once: &sync.Once{}, logFile: "logfile.log", level: zap.NewAtomicLevel()}
 )
 
 func SetLogFile(logFilePath string) {
@@ -24,17 +55,11 @@ func SetLogFile(logFilePath string) {
 func Logger() *logger {
 	var err error
 	log.once.Do(func() {
-		cfg := zap.NewProductionConfig()
-		cfg.OutputPaths = []string{log.logFile, "stdout"}
-		// cfg.Level.SetLevel(zap.WarnLevel) для нагрузочного тестирования
-
-		var logger *zap.Logger
-		logger, err = cfg.Build()
-		if err != nil {
-			return
-		}
-
-		log.SugaredLogger = logger.Sugar()
+		err = log.build(Options{
+			Level:   "info",
+			Format:  FormatJSON,
+			Outputs: []string{OutputFile, OutputStdout},
+		})
 	})
 
 	if err != nil {
@@ -44,7 +69,175 @@ func Logger() *logger {
 	return log
 }
 
+func Init(opts Options) error {
+	if opts.FilePath != "" {
+		SetLogFile(opts.FilePath)
+	}
+
+	var err error
+	initialized := false
+	log.once.Do(func() {
+		initialized = true
+		err = log.build(opts)
+	})
+
+	if !initialized {
+		err = log.rebuild(opts)
+	}
+
+	if err != nil {
+		return fmt.Errorf("logger.Init: %w", err)
+	}
+
+	return nil
+}
+
+func (l *logger) build(opts Options) error {
+	level, err := zapcore.ParseLevel(opts.Level)
+	if err != nil {
+		return err
+	}
+
+	var encoderCfg zapcore.EncoderConfig
+	var encoder zapcore.Encoder
+	switch opts.Format {
+	case "", FormatJSON:
+		encoderCfg = zap.NewProductionEncoderConfig()
+		encoder = zapcore.NewJSONEncoder(encoderCfg)
+	case FormatConsole:
+		encoderCfg = zap.NewDevelopmentEncoderConfig()
+		encoder = zapcore.NewConsoleEncoder(encoderCfg)
+	default:
+		return fmt.Errorf("unknown log format %q", opts.Format)
+	}
+
+	if len(opts.Outputs) == 0 {
+		return errors.New("no log outputs configured")
+	}
+
+	var (
+		sinks []zapcore.WriteSyncer
+		files []*lumberjack.Logger
+	)
+	for _, output := range opts.Outputs {
+		switch output {
+		case OutputStdout:
+			sinks = append(sinks, zapcore.Lock(os.Stdout))
+		case OutputStderr:
+			sinks = append(sinks, zapcore.Lock(os.Stderr))
+		case OutputFile:
+			file := &lumberjack.Logger{
+				Filename:   l.logFile,
+				MaxSize:    opts.MaxSizeMB,
+				MaxBackups: opts.MaxBackups,
+				MaxAge:     opts.MaxAgeDays,
+				Compress:   opts.Compress,
+			}
+			files = append(files, file)
+			sinks = append(sinks, zapcore.AddSync(file))
+		default:
+			return fmt.Errorf("unknown log output %q", output)
+		}
+	}
+
+	core := zapcore.NewCore(encoder, zapcore.NewMultiWriteSyncer(sinks...), l.level)
+	l.level.SetLevel(level)
+	l.SugaredLogger = zap.New(core, zap.AddCaller(), zap.AddStacktrace(zap.ErrorLevel)).Sugar()
+	l.files = files
+	l.stop = nil
+
+	if opts.RotateInterval > 0 && len(files) > 0 {
+		l.stop = make(chan struct{})
+		go rotateEvery(opts.RotateInterval, files, l.stop)
+	}
+
+	return nil
+}
+
+func (l *logger) rebuild(opts Options) error {
+	old := *l
+	if err := l.build(opts); err != nil {
+		return err
+	}
+
+	old.close()
+
+	return nil
+}
+
+func (l *logger) close() {
+	if l.stop != nil {
+		close(l.stop)
+		l.stop = nil
+	}
+	if l.SugaredLogger != nil {
+		_ = l.SugaredLogger.Sync()
+	}
+	for _, file := range l.files {
+		_ = file.Close()
+	}
+}
+
+func rotateEvery(interval time.Duration, files []*lumberjack.Logger, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			for _, file := range files {
+				if err := file.Rotate(); err != nil {
+					log.Errorln("logger: failed to rotate log file:", err)
+				}
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+func Rotate() error {
+	for _, file := range log.files {
+		if err := file.Rotate(); err != nil {
+			return fmt.Errorf("logger.Rotate: %w", err)
+		}
+	}
+	return nil
+}
+
+func SetLevel(level string) error {
+	if err := log.level.UnmarshalText([]byte(level)); err != nil {
+		return fmt.Errorf("logger.SetLevel: %w", err)
+	}
+	return nil
+}
+
+func Level() string {
+	return log.level.String()
+}
+
+func LevelHandler() http.Handler {
+	return log.level
+}
+
+func Close() {
+	log.close()
+}
+
 func (l *logger) Write(p []byte) (n int, err error) {
 	l.Errorln(string(p))
 	return len(p), nil
 }
+
+type ctxKey struct{}
+
+func WithContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+func FromContext(ctx context.Context) *zap.Logger {
+	if l, ok := ctx.Value(ctxKey{}).(*zap.Logger); ok {
+		return l
+	}
+	return Logger().Desugar()
+}
@@ -1,16 +1,69 @@
 package logger
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 )
 
 func TestLogger(t *testing.T) {
 	str := "abc_tmp.txt"
 	SetLogFile(str)
-
 	n, err := Logger().Write([]byte(str))
 	require.NoError(t, err)
 	require.Equal(t, len(str), n)
 }
+
+func TestFromContext(t *testing.T) {
+	require.NotNil(t, FromContext(context.Background()))
+
+	l := zap.NewNop()
+	require.Same(t, l, FromContext(WithContext(context.Background(), l)))
+}
+
+func TestInit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	require.NoError(t, Init(Options{
+		Level:    "warn",
+		Format:   FormatConsole,
+		Outputs:  []string{OutputFile},
+		FilePath: path,
+	}))
+	defer Close()
+
+	Logger().Infoln("hidden message")
+	Logger().Warnln("visible message")
+
+	require.NoError(t, SetLevel("debug"))
+	require.Equal(t, "debug", Level())
+	Logger().Debugln("debug message")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "hidden message")
+	require.Contains(t, string(data), "visible message")
+	require.Contains(t, string(data), "debug message")
+
+	require.Error(t, SetLevel("loud"))
+	require.Error(t, Init(Options{Level: "info", Format: "xml", Outputs: []string{OutputStdout}}))
+	require.Error(t, Init(Options{Level: "info", Outputs: []string{"syslog"}}))
+}
+
+func TestLevelHandler(t *testing.T) {
+	require.NoError(t, SetLevel("info"))
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/admin/log/level", strings.NewReader(`{"level":"error"}`))
+	LevelHandler().ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "error", Level())
+}
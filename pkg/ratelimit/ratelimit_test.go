@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStoreAllow(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	policy := Policy{Name: "test", Rate: 1, Burst: 3}
+
+	for i := 2; i >= 0; i-- {
+		res, err := store.Allow(context.Background(), "user:alice", policy)
+		require.NoError(t, err)
+		require.True(t, res.Allowed)
+		require.Equal(t, 3, res.Limit)
+		require.Equal(t, i, res.Remaining)
+	}
+
+	res, err := store.Allow(context.Background(), "user:alice", policy)
+	require.NoError(t, err)
+	require.False(t, res.Allowed)
+	require.Equal(t, time.Second, res.RetryAfter)
+	require.Equal(t, 3*time.Second, res.ResetAfter)
+
+	res, err = store.Allow(context.Background(), "user:bob", policy)
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+
+	now = now.Add(1500 * time.Millisecond)
+	res, err = store.Allow(context.Background(), "user:alice", policy)
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+	require.Equal(t, 0, res.Remaining)
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	policy := Policy{Name: "test", Rate: 10, Burst: 10}
+
+	for _, key := range []string{"a", "b", "c"} {
+		_, err := store.Allow(context.Background(), key, policy)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, store.Len())
+
+	now = now.Add(2 * sweepInterval)
+	_, err := store.Allow(context.Background(), "d", policy)
+	require.NoError(t, err)
+	require.Equal(t, 1, store.Len())
+}
+
+func TestPolicyWindow(t *testing.T) {
+	require.Equal(t, 10*time.Second, Policy{Rate: 0.5, Burst: 5}.Window())
+	require.Equal(t, time.Duration(0), Policy{Burst: 5}.Window())
+}
@@ -2,21 +2,28 @@ package validator
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	appErrors "github.com/Te8va/MerchStore/internal/errors"
 	"github.com/Te8va/MerchStore/pkg/checker"
 )
 
+var MaxBodyBytes int64 = 1 << 20
+
 func ValidateJSONRequest(r *http.Request, v interface{}) error {
 	if !checker.IsJSONContentTypeCorrect(r) {
 		return appErrors.ErrWrongMIME
 	}
 
-	d := json.NewDecoder(r.Body)
+	d := json.NewDecoder(http.MaxBytesReader(nil, r.Body, MaxBodyBytes))
 	d.DisallowUnknownFields()
 
 	if err := d.Decode(&v); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return appErrors.ErrRequestTooLarge
+		}
 		return appErrors.ErrWrongJSON
 	}
 
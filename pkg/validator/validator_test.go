@@ -73,3 +73,17 @@ func TestValidateJSONRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateJSONRequestTooLarge(t *testing.T) {
+	defer func(limit int64) { MaxBodyBytes = limit }(MaxBodyBytes)
+	MaxBodyBytes = 16
+
+	req, err := http.NewRequest("POST", "/test", strings.NewReader(`{"field1": "a very long value that exceeds the limit"}`))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var output TestStruct
+	assert.ErrorIs(t, ValidateJSONRequest(req, &output), appErrors.ErrRequestTooLarge)
+}